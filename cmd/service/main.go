@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,26 +13,56 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nix-united/golang-echo-boilerplate/docs"
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
+	"github.com/nix-united/golang-echo-boilerplate/internal/authz"
+	"github.com/nix-united/golang-echo-boilerplate/internal/background"
 	"github.com/nix-united/golang-echo-boilerplate/internal/config"
 	"github.com/nix-united/golang-echo-boilerplate/internal/db"
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	"github.com/nix-united/golang-echo-boilerplate/internal/memstore"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/passhash"
+	"github.com/nix-united/golang-echo-boilerplate/internal/passpolicy"
 	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
 	"github.com/nix-united/golang-echo-boilerplate/internal/server"
 	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
 	"github.com/nix-united/golang-echo-boilerplate/internal/server/middleware"
 	"github.com/nix-united/golang-echo-boilerplate/internal/server/routes"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/apikey"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/auth"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/impersonation"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/introspection"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/lockout"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/magiclink"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/mfa"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/oauth"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/password"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/post"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/session"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/user"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/verification"
 	"github.com/nix-united/golang-echo-boilerplate/internal/slogx"
 
 	"github.com/caarlos0/env/v11"
-	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/joho/godotenv"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
-const shutdownTimeout = 20 * time.Second
+const (
+	shutdownTimeout = 20 * time.Second
+
+	// memstorePruneInterval is how often in-memory stores forget expired entries.
+	memstorePruneInterval = time.Minute
+
+	// maxOAuthStates bounds authorization code flows kept in memory, which anyone can start without logging in.
+	maxOAuthStates = 10000
+
+	// Salt and key lengths of argon2id password hashes, as recommended by RFC 9106.
+	passwordSaltLength = 16
+	passwordKeyLength  = 32
+)
 
 //	@title			Echo Demo App
 //	@version		1.0
@@ -45,6 +76,8 @@ const shutdownTimeout = 20 * time.Second
 //	@in							header
 //	@name						Authorization
 
+//	@securityDefinitions.basic	BasicAuth
+
 // @BasePath	/
 func main() {
 	if err := run(); err != nil {
@@ -74,47 +107,234 @@ func run() error {
 		return fmt.Errorf("new db connection: %w", err)
 	}
 
+	// pruneCtx stops pruning of in-memory stores when the service stops.
+	pruneCtx, stopPruning := context.WithCancel(context.Background())
+	defer stopPruning()
+
 	userRepository := repositories.NewUserRepository(gormDB)
-	userService := user.NewService(userRepository)
+	passwordHasher, err := passhash.NewHasher(
+		cfg.Auth.PasswordHashAlgorithm,
+		passhash.Argon2Params{
+			Memory:      cfg.Auth.PasswordArgon2Memory,
+			Iterations:  cfg.Auth.PasswordArgon2Iterations,
+			Parallelism: cfg.Auth.PasswordArgon2Parallelism,
+			SaltLength:  passwordSaltLength,
+			KeyLength:   passwordKeyLength,
+		},
+		cfg.Auth.PasswordBcryptCost,
+	)
+	if err != nil {
+		return fmt.Errorf("new password hasher: %w", err)
+	}
+
+	passwordPolicy, err := newPasswordPolicy(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("new password policy: %w", err)
+	}
+
+	userService := user.NewService(userRepository, passwordHasher, passwordPolicy)
 
 	postRepository := repositories.NewPostRepository(gormDB)
-	postService := post.NewService(postRepository)
+	authorizer := authz.NewAuthorizer(time.Now, authz.DefaultPolicies())
+	postService := post.NewService(postRepository, authorizer)
 
-	provider, err := oidc.NewProvider(context.Background(), "https://accounts.google.com")
+	oAuthProviders, err := oauth.NewProviders(context.Background(), cfg.OAuth)
 	if err != nil {
-		return fmt.Errorf("oidc.NewProvider: %w", err)
+		return fmt.Errorf("new oauth providers: %w", err)
 	}
 
-	verifier := provider.Verifier(&oidc.Config{ClientID: cfg.OAuth.ClientID})
+	keyrings, err := newKeyrings(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("new keyrings: %w", err)
+	}
 
 	tokenService := token.NewService(
 		time.Now,
+		uuid.NewV7,
 		cfg.Auth.AccessTokenDuration,
 		cfg.Auth.RefreshTokenDuration,
-		[]byte(cfg.Auth.AccessSecret),
-		[]byte(cfg.Auth.RefreshSecret),
+		keyrings.access,
+		keyrings.refresh,
+	)
+
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(gormDB)
+	sessionRepository := repositories.NewSessionRepository(gormDB)
+	sessionService := session.NewService(time.Now, uuid.NewV7, refreshTokenRepository, sessionRepository, tokenService)
+
+	accessTokenDenylist, err := newAccessTokenDenylist(pruneCtx, cfg.Auth.DenylistStore, gormDB)
+	if err != nil {
+		return fmt.Errorf("new access token denylist: %w", err)
+	}
+
+	apiKeyRepository := repositories.NewAPIKeyRepository(gormDB)
+	apiKeyService := apikey.NewService(time.Now, apiKeyRepository, userService)
+
+	mailSender, err := newMailSender(cfg.Mail)
+	if err != nil {
+		return fmt.Errorf("new mail sender: %w", err)
+	}
+
+	usedActionTokenRepository := repositories.NewUsedActionTokenRepository(gormDB, time.Now)
+	actionTokenService := token.NewActionService(time.Now, uuid.NewV7, keyrings.action, usedActionTokenRepository)
+	verificationService := verification.NewService(
+		time.Now,
+		userService,
+		actionTokenService,
+		mailSender,
+		cfg.Mail.From,
+		cfg.Mail.LinkBaseURL,
+		cfg.Auth.EmailVerificationDuration,
+	)
+
+	passwordResetRepository := repositories.NewPasswordResetRepository(gormDB, time.Now)
+	passwordService := password.NewService(
+		time.Now,
+		passwordResetRepository,
+		userService,
+		sessionService,
+		mailSender,
+		cfg.Mail.From,
+		cfg.Mail.LinkBaseURL,
+		cfg.Auth.PasswordResetDuration,
 	)
 
-	authService := auth.NewService(userService, tokenService)
-	oAuthService := oauth.NewService(verifier, tokenService, userService)
+	mfaRepository := repositories.NewMFARepository(gormDB)
+	mfaService := mfa.NewService(time.Now, mfaRepository, userService, cfg.Auth.MFAIssuer)
+
+	loginAttemptStore, err := newLoginAttemptStore(pruneCtx, cfg.Auth.LoginAttemptsStore, gormDB)
+	if err != nil {
+		return fmt.Errorf("new login attempt store: %w", err)
+	}
+
+	lockoutService := lockout.NewService(
+		time.Now,
+		loginAttemptStore,
+		cfg.Auth.LoginFailureWindow,
+		lockout.Policy{
+			Threshold:   cfg.Auth.LoginAccountThreshold,
+			Duration:    cfg.Auth.LoginLockoutDuration,
+			MaxDuration: cfg.Auth.LoginLockoutMaxDuration,
+		},
+		lockout.Policy{
+			Threshold:   cfg.Auth.LoginIPThreshold,
+			Duration:    cfg.Auth.LoginLockoutDuration,
+			MaxDuration: cfg.Auth.LoginLockoutMaxDuration,
+		},
+	)
+
+	authService := auth.NewService(
+		userService,
+		tokenService,
+		sessionService,
+		accessTokenDenylist,
+		mfaService,
+		actionTokenService,
+		lockoutService,
+		cfg.Auth.RequireVerifiedEmail,
+	)
+	oAuthStateStore, err := newOAuthStateStore(pruneCtx, cfg.OAuth.StateStore, gormDB)
+	if err != nil {
+		return fmt.Errorf("new oauth state store: %w", err)
+	}
+
+	oAuthProviderRepository := repositories.NewOAuthProviderRepository(gormDB)
+	oAuthService := oauth.NewService(
+		time.Now,
+		oAuthProviders,
+		oAuthStateStore,
+		oAuthProviderRepository,
+		authService,
+		userService,
+	)
+
+	cookieSameSite, err := authcookie.ParseSameSite(cfg.Auth.CookieSameSite)
+	if err != nil {
+		return fmt.Errorf("parse cookie same site mode: %w", err)
+	}
+
+	authCookies := authcookie.New(authcookie.Config{
+		Enabled:              cfg.Auth.CookieAuthEnabled,
+		Domain:               cfg.Auth.CookieDomain,
+		SameSite:             cookieSameSite,
+		RefreshTokenDuration: cfg.Auth.RefreshTokenDuration,
+	}, time.Now)
+
+	// tasks run work that must not delay responses, e.g. sending emails whose timing reveals registered users.
+	tasks := background.NewRunner()
 
 	postHandler := handlers.NewPostHandlers(postService)
-	authHandler := handlers.NewAuthHandler(authService)
-	oAuthHandler := handlers.NewOAuthHandler(oAuthService)
-	registerHandler := handlers.NewRegisterHandler(userService)
+	authHandler := handlers.NewAuthHandler(authService, authCookies)
+	oAuthHandler := handlers.NewOAuthHandler(oAuthService, authCookies)
+	registerHandler := handlers.NewRegisterHandler(userService, verificationService, cfg.Auth.ConcealRegisteredEmails)
+	jwksHandler := handlers.NewJWKSHandler(tokenService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	identityHandler := handlers.NewIdentityHandler(oAuthService)
+	verificationHandler := handlers.NewVerificationHandler(verificationService)
+	passwordHandler := handlers.NewPasswordHandler(passwordService, tasks)
+	mfaHandler := handlers.NewMFAHandler(mfaService)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+	impersonationHandler := handlers.NewImpersonationHandler(
+		impersonation.NewService(userService, tokenService, cfg.Auth.ImpersonationTokenDuration),
+	)
+
+	var magicLinkHandler *handlers.MagicLinkHandler
+	if cfg.Auth.MagicLinkEnabled {
+		magicLinkService := magiclink.NewService(
+			time.Now,
+			userService,
+			actionTokenService,
+			authService,
+			mailSender,
+			cfg.Mail.From,
+			cfg.Mail.LinkBaseURL,
+			cfg.Auth.MagicLinkDuration,
+		)
+		magicLinkHandler = handlers.NewMagicLinkHandler(magicLinkService, authCookies, tasks)
+	}
 
-	authMiddleware := middleware.NewAuthMiddleware(cfg.Auth.AccessSecret)
+	var tokenHandler *handlers.TokenHandler
+	if len(cfg.Auth.TokenClients) > 0 {
+		introspectionService := introspection.NewService(tokenService, accessTokenDenylist, sessionService)
+		tokenHandler = handlers.NewTokenHandler(introspectionService)
+	}
+
+	authMiddleware := middleware.NewAuthMiddleware(
+		tokenService.AccessTokenKeyfunc,
+		accessTokenDenylist,
+		sessionService,
+		apiKeyService,
+		cfg.Auth.CookieAuthEnabled,
+	)
 	reguestLoggerMiddleware := middleware.NewRequestLogger(slogx.NewTraceStarter(uuid.NewV7))
 	requestDebuggerMiddleware := middleware.NewRequestDebugger()
 
+	ipExtractor, err := newIPExtractor(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("new ip extractor: %w", err)
+	}
+
 	engine := routes.ConfigureRoutes(routes.Handlers{
 		PostHandler:               postHandler,
 		AuthHandler:               authHandler,
 		OAuthHandler:              oAuthHandler,
 		RegisterHandler:           registerHandler,
+		JWKSHandler:               jwksHandler,
+		APIKeyHandler:             apiKeyHandler,
+		IdentityHandler:           identityHandler,
+		VerificationHandler:       verificationHandler,
+		PasswordHandler:           passwordHandler,
+		MFAHandler:                mfaHandler,
+		SessionHandler:            sessionHandler,
+		ImpersonationHandler:      impersonationHandler,
+		MagicLinkHandler:          magicLinkHandler,
+		TokenHandler:              tokenHandler,
 		AuthMiddleware:            authMiddleware,
 		RequestLoggerMiddleware:   reguestLoggerMiddleware,
 		RequestDebuggerMiddleware: requestDebuggerMiddleware,
+		PasswordForgotRateLimiter: middleware.RateLimit(cfg.Auth.PasswordForgotRateLimit),
+		MagicLinkRateLimiter:      middleware.RateLimit(cfg.Auth.MagicLinkRateLimit),
+		ClientAuthMiddleware:      middleware.NewClientAuth(cfg.Auth.TokenClients),
+		IPExtractor:               ipExtractor,
 	})
 	if err != nil {
 		return fmt.Errorf("configure routes: %w", err)
@@ -127,8 +347,23 @@ func run() error {
 		}
 	}()
 
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, syscall.SIGHUP)
+	defer signal.Stop(reloadChannel)
+
+	go func() {
+		for range reloadChannel {
+			if err := reloadKeyrings(keyrings); err != nil {
+				slog.Error("Failed to reload signing keys", "err", err.Error())
+				continue
+			}
+
+			slog.Info("Signing keys reloaded")
+		}
+	}()
+
 	shutdownChannel := make(chan os.Signal, 1)
-	signal.Notify(shutdownChannel, os.Interrupt, syscall.SIGHUP, syscall.SIGTERM)
+	signal.Notify(shutdownChannel, os.Interrupt, syscall.SIGTERM)
 	<-shutdownChannel
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
@@ -138,6 +373,11 @@ func run() error {
 		return fmt.Errorf("http server shutdown: %w", err)
 	}
 
+	// Emails requested before the shutdown are still sent.
+	if err := tasks.Wait(shutdownCtx); err != nil {
+		return fmt.Errorf("wait for background tasks: %w", err)
+	}
+
 	dbConnection, err := gormDB.DB()
 	if err != nil {
 		return fmt.Errorf("get db connection: %w", err)
@@ -149,3 +389,199 @@ func run() error {
 
 	return nil
 }
+
+type accessTokenDenylist interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// newAccessTokenDenylist creates the denylist kept in the store. An in-memory denylist is pruned until pruneCtx is done.
+func newAccessTokenDenylist(pruneCtx context.Context, store string, gormDB *gorm.DB) (accessTokenDenylist, error) {
+	switch store {
+	case "memory":
+		denylist := memstore.NewRevokedAccessTokens(time.Now)
+		go memstore.PruneEvery(pruneCtx, memstorePruneInterval, denylist.Prune)
+
+		return denylist, nil
+	case "db":
+		return repositories.NewRevokedAccessTokenRepository(gormDB, time.Now), nil
+	default:
+		return nil, fmt.Errorf("unknown store %q", store)
+	}
+}
+
+type loginAttemptStore interface {
+	Get(ctx context.Context, key string) (models.LoginAttempt, error)
+	AddFailure(ctx context.Context, key string, expiresAt time.Time) (int, error)
+	Lock(ctx context.Context, key string, lockedUntil time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// newLoginAttemptStore creates the login attempt store. An in-memory store is pruned until pruneCtx is done.
+func newLoginAttemptStore(pruneCtx context.Context, store string, gormDB *gorm.DB) (loginAttemptStore, error) {
+	switch store {
+	case "memory":
+		attempts := memstore.NewLoginAttempts(time.Now)
+		go memstore.PruneEvery(pruneCtx, memstorePruneInterval, attempts.Prune)
+
+		return attempts, nil
+	case "db":
+		return repositories.NewLoginAttemptRepository(gormDB, time.Now), nil
+	default:
+		return nil, fmt.Errorf("unknown store %q", store)
+	}
+}
+
+type oAuthStateStore interface {
+	Save(ctx context.Context, state string, oAuthState models.OAuthState) error
+	Take(ctx context.Context, state string) (models.OAuthState, error)
+}
+
+// newOAuthStateStore creates the oauth state store. An in-memory store is pruned until pruneCtx is done.
+func newOAuthStateStore(pruneCtx context.Context, store string, gormDB *gorm.DB) (oAuthStateStore, error) {
+	switch store {
+	case "memory":
+		states := memstore.NewOAuthStates(time.Now, maxOAuthStates)
+		go memstore.PruneEvery(pruneCtx, memstorePruneInterval, states.Prune)
+
+		return states, nil
+	case "db":
+		return repositories.NewOAuthStateRepository(gormDB, time.Now), nil
+	default:
+		return nil, fmt.Errorf("unknown store %q", store)
+	}
+}
+
+type mailSender interface {
+	Send(ctx context.Context, message mail.Message) error
+}
+
+// newIPExtractor trusts the X-Forwarded-For header only when it's set by one of the trusted proxies.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// Loopback, link-local and private addresses are trusted by default, only the configured ranges must be.
+	trustOptions := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, trustedProxy := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(trustedProxy)
+		if err != nil {
+			return nil, fmt.Errorf("parse trusted proxy %q: %w", trustedProxy, err)
+		}
+
+		trustOptions = append(trustOptions, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(trustOptions...), nil
+}
+
+func newMailSender(cfg config.MailConfig) (mailSender, error) {
+	switch cfg.Sender {
+	case "smtp":
+		return mail.NewSMTPSender(time.Now, cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword), nil
+	case "file":
+		return mail.NewFileSender(time.Now, cfg.Dir), nil
+	case "memory":
+		return mail.NewMemorySender(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail sender %q", cfg.Sender)
+	}
+}
+
+func newPasswordPolicy(cfg config.AuthConfig) (*passpolicy.Policy, error) {
+	var breached *passpolicy.BloomFilter
+	if cfg.PasswordCheckBreached {
+		var err error
+		breached, err = passpolicy.LoadBreachedPasswords(cfg.PasswordBreachedListFile)
+		if err != nil {
+			return nil, fmt.Errorf("load breached passwords: %w", err)
+		}
+	}
+
+	rules := passpolicy.Rules{
+		MinLength:            cfg.PasswordMinLength,
+		MaxLength:            cfg.PasswordMaxLength,
+		RequireLowercase:     cfg.PasswordRequireLowercase,
+		RequireUppercase:     cfg.PasswordRequireUppercase,
+		RequireDigit:         cfg.PasswordRequireDigit,
+		RequireSymbol:        cfg.PasswordRequireSymbol,
+		DisallowPersonalInfo: cfg.PasswordDisallowPersonalInfo,
+	}
+
+	return passpolicy.NewPolicy(rules, breached)
+}
+
+type keyrings struct {
+	access  *token.Keyring
+	refresh *token.Keyring
+	action  *token.Keyring
+}
+
+func newKeyrings(cfg config.AuthConfig) (keyrings, error) {
+	if err := token.CheckSecretsDistinct(cfg); err != nil {
+		return keyrings{}, fmt.Errorf("check secrets: %w", err)
+	}
+
+	currentAccessKey, retiredAccessKeys, err := token.LoadAccessKeys(cfg)
+	if err != nil {
+		return keyrings{}, fmt.Errorf("load access keys: %w", err)
+	}
+
+	accessKeyring, err := token.NewKeyring(currentAccessKey, retiredAccessKeys...)
+	if err != nil {
+		return keyrings{}, fmt.Errorf("new access keyring: %w", err)
+	}
+
+	currentRefreshKey, retiredRefreshKeys, err := token.LoadRefreshKeys(cfg)
+	if err != nil {
+		return keyrings{}, fmt.Errorf("load refresh keys: %w", err)
+	}
+
+	refreshKeyring, err := token.NewKeyring(currentRefreshKey, retiredRefreshKeys...)
+	if err != nil {
+		return keyrings{}, fmt.Errorf("new refresh keyring: %w", err)
+	}
+
+	currentActionKey, retiredActionKeys, err := token.LoadActionKeys(cfg)
+	if err != nil {
+		return keyrings{}, fmt.Errorf("load action keys: %w", err)
+	}
+
+	actionKeyring, err := token.NewKeyring(currentActionKey, retiredActionKeys...)
+	if err != nil {
+		return keyrings{}, fmt.Errorf("new action keyring: %w", err)
+	}
+
+	return keyrings{access: accessKeyring, refresh: refreshKeyring, action: actionKeyring}, nil
+}
+
+// reloadKeyrings re-reads the environment and swaps the keys in place,
+// so signing keys can be rotated without restarting the service.
+func reloadKeyrings(keyrings keyrings) error {
+	if err := godotenv.Overload(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("load env file: %w", err)
+	}
+
+	var cfg config.AuthConfig
+	if err := env.Parse(&cfg); err != nil {
+		return fmt.Errorf("parse env: %w", err)
+	}
+
+	// All keys are loaded before any keyring is replaced, so a failed reload keeps every old key.
+	reloaded, err := newKeyrings(cfg)
+	if err != nil {
+		return fmt.Errorf("new keyrings: %w", err)
+	}
+
+	keyrings.access.ReplaceWith(reloaded.access)
+	keyrings.refresh.ReplaceWith(reloaded.refresh)
+	keyrings.action.ReplaceWith(reloaded.action)
+
+	return nil
+}
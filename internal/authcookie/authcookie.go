@@ -0,0 +1,128 @@
+// Package authcookie keeps the tokens of browser clients in cookies.
+//
+// Browser clients opt in with the "X-Auth-Mode: cookie" header on login and refresh requests.
+// The access and refresh tokens are set as HttpOnly cookies, so scripts can't read them, and a CSRF token
+// is set as a cookie scripts can read. Requests authenticated with the cookies must repeat the CSRF token
+// in the "X-CSRF-Token" header, which a cross-site request can't do.
+package authcookie
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	AccessTokenName  = "access_token"
+	RefreshTokenName = "refresh_token"
+	CSRFTokenName    = "csrf_token"
+
+	// CSRFHeader is the header repeating the value of the CSRF token cookie.
+	CSRFHeader = "X-CSRF-Token"
+
+	// ModeHeader selects how tokens are returned on login and refresh, see [ModeCookie].
+	ModeHeader = "X-Auth-Mode"
+	ModeCookie = "cookie"
+
+	// refreshPath is the only path the refresh token cookie is sent to.
+	refreshPath = "/refresh"
+)
+
+type Config struct {
+	// Enabled lets clients switch to cookies, the tokens are returned in the response body otherwise.
+	Enabled bool
+
+	// Domain of the cookies, the host of the request is used when it is empty.
+	Domain string
+
+	SameSite http.SameSite
+
+	// RefreshTokenDuration is how long the refresh token cookie is kept, it should match the refresh token expiration.
+	RefreshTokenDuration time.Duration
+}
+
+type Cookies struct {
+	config Config
+	now    func() time.Time
+}
+
+func New(config Config, now func() time.Time) *Cookies {
+	return &Cookies{config: config, now: now}
+}
+
+// ParseSameSite parses the SameSite attribute, one of "strict", "lax" or "none".
+func ParseSameSite(value string) (http.SameSite, error) {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	default:
+		return 0, fmt.Errorf("unsupported cookie same site mode %q", value)
+	}
+}
+
+// Requested reports whether the client asked for the tokens in cookies and cookies are enabled.
+func (c *Cookies) Requested(r *http.Request) bool {
+	return c.config.Enabled && strings.EqualFold(r.Header.Get(ModeHeader), ModeCookie)
+}
+
+// Set sets the access token, which expires at the exp Unix time, the refresh token and a new CSRF token.
+func (c *Cookies) Set(w http.ResponseWriter, accessToken string, exp int64, refreshToken string) {
+	accessTokenMaxAge := int(time.Unix(exp, 0).Sub(c.now()).Seconds())
+	refreshTokenMaxAge := int(c.config.RefreshTokenDuration.Seconds())
+
+	http.SetCookie(w, c.cookie(AccessTokenName, accessToken, "/", accessTokenMaxAge, true))
+	http.SetCookie(w, c.cookie(RefreshTokenName, refreshToken, refreshPath, refreshTokenMaxAge, true))
+
+	// The CSRF token lives as long as the refresh token, so that it is there whenever the other cookies are.
+	http.SetCookie(w, c.cookie(CSRFTokenName, rand.Text(), "/", refreshTokenMaxAge, false))
+}
+
+// Clear removes the cookies set by [Cookies.Set].
+func (c *Cookies) Clear(w http.ResponseWriter) {
+	http.SetCookie(w, c.cookie(AccessTokenName, "", "/", -1, true))
+	http.SetCookie(w, c.cookie(RefreshTokenName, "", refreshPath, -1, true))
+	http.SetCookie(w, c.cookie(CSRFTokenName, "", "/", -1, false))
+}
+
+func (c *Cookies) cookie(name, value, path string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   c.config.Domain,
+		MaxAge:   maxAge,
+		Secure:   true,
+		HttpOnly: httpOnly,
+		SameSite: c.config.SameSite,
+	}
+}
+
+// HasTokens reports whether the request carries the access or the refresh token cookie.
+func HasTokens(r *http.Request) bool {
+	for _, name := range []string{AccessTokenName, RefreshTokenName} {
+		if cookie, err := r.Cookie(name); err == nil && cookie.Value != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ValidCSRF reports whether the CSRF header of the request matches the CSRF token cookie.
+func ValidCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(CSRFTokenName)
+	if err != nil || cookie.Value == "" {
+		return false
+	}
+
+	header := r.Header.Get(CSRFHeader)
+
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) == 1
+}
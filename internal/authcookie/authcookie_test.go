@@ -0,0 +1,150 @@
+package authcookie_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var currentTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newCookies(enabled bool) *authcookie.Cookies {
+	return authcookie.New(authcookie.Config{
+		Enabled:              enabled,
+		Domain:               "example.com",
+		SameSite:             http.SameSiteStrictMode,
+		RefreshTokenDuration: time.Hour,
+	}, func() time.Time { return currentTime })
+}
+
+func TestCookies_Requested(t *testing.T) {
+	testCases := map[string]struct {
+		enabled bool
+		mode    string
+		want    bool
+	}{
+		"It should accept cookie mode": {
+			enabled: true,
+			mode:    "cookie",
+			want:    true,
+		},
+		"It should reject cookie mode when cookies are disabled": {
+			mode: "cookie",
+		},
+		"It should reject request without cookie mode": {
+			enabled: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login", http.NoBody)
+			request.Header.Set(authcookie.ModeHeader, testCase.mode)
+
+			assert.Equal(t, testCase.want, newCookies(testCase.enabled).Requested(request))
+		})
+	}
+}
+
+func TestCookies_Set(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	newCookies(true).Set(recorder, "access-token", currentTime.Add(time.Minute).Unix(), "refresh-token")
+
+	cookies := make(map[string]*http.Cookie)
+	for _, cookie := range recorder.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+
+	require.Len(t, cookies, 3)
+
+	accessToken := cookies[authcookie.AccessTokenName]
+	assert.Equal(t, "access-token", accessToken.Value)
+	assert.Equal(t, "/", accessToken.Path)
+	assert.Equal(t, 60, accessToken.MaxAge)
+	assert.True(t, accessToken.HttpOnly)
+
+	refreshToken := cookies[authcookie.RefreshTokenName]
+	assert.Equal(t, "refresh-token", refreshToken.Value)
+	assert.Equal(t, "/refresh", refreshToken.Path)
+	assert.Equal(t, 3600, refreshToken.MaxAge)
+	assert.True(t, refreshToken.HttpOnly)
+
+	csrfToken := cookies[authcookie.CSRFTokenName]
+	assert.NotEmpty(t, csrfToken.Value)
+	assert.Equal(t, 3600, csrfToken.MaxAge)
+	assert.False(t, csrfToken.HttpOnly, "scripts must be able to read the CSRF token")
+
+	for _, cookie := range cookies {
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+		assert.Equal(t, "example.com", cookie.Domain)
+	}
+}
+
+func TestCookies_Clear(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	newCookies(true).Clear(recorder)
+
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 3)
+
+	for _, cookie := range cookies {
+		assert.Empty(t, cookie.Value)
+		assert.Negative(t, cookie.MaxAge)
+	}
+}
+
+func TestValidCSRF(t *testing.T) {
+	testCases := map[string]struct {
+		cookie string
+		header string
+		want   bool
+	}{
+		"It should accept matching token": {
+			cookie: "csrf-token",
+			header: "csrf-token",
+			want:   true,
+		},
+		"It should reject missing header": {
+			cookie: "csrf-token",
+		},
+		"It should reject missing cookie": {
+			header: "csrf-token",
+		},
+		"It should reject mismatching token": {
+			cookie: "csrf-token",
+			header: "another-token",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/posts", http.NoBody)
+			if testCase.cookie != "" {
+				request.AddCookie(&http.Cookie{Name: authcookie.CSRFTokenName, Value: testCase.cookie})
+			}
+			if testCase.header != "" {
+				request.Header.Set(authcookie.CSRFHeader, testCase.header)
+			}
+
+			assert.Equal(t, testCase.want, authcookie.ValidCSRF(request))
+		})
+	}
+}
+
+func TestParseSameSite(t *testing.T) {
+	sameSite, err := authcookie.ParseSameSite("Lax")
+	require.NoError(t, err)
+	assert.Equal(t, http.SameSiteLaxMode, sameSite)
+
+	_, err = authcookie.ParseSameSite("sometimes")
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+// Package authz decides whether an actor is allowed to perform an action on a resource.
+//
+// Policies are declared in Go as lists of rules per resource type and action.
+// An action is allowed if any of its rules allows it, and denied if there are no rules for it.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+type Action string
+
+const (
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+type ResourceType string
+
+const ResourcePost ResourceType = "post"
+
+// Resource describes the attributes of a resource which rules are evaluated against.
+type Resource struct {
+	Type      ResourceType
+	OwnerID   uint
+	CreatedAt time.Time
+}
+
+// Request is an authorization request a rule is evaluated for.
+type Request struct {
+	Actor    domain.Actor
+	Action   Action
+	Resource Resource
+
+	// Now is the time the request is evaluated at.
+	Now time.Time
+}
+
+// Rule reports whether the request is allowed.
+type Rule func(ctx context.Context, request Request) bool
+
+// Policy lists rules allowing each action on a resource type.
+type Policy map[Action][]Rule
+
+// Policies holds a policy for each resource type.
+type Policies map[ResourceType]Policy
+
+type Authorizer struct {
+	now      func() time.Time
+	policies Policies
+}
+
+func NewAuthorizer(now func() time.Time, policies Policies) *Authorizer {
+	return &Authorizer{now: now, policies: policies}
+}
+
+// Can returns an error wrapping [models.ErrForbidden] if the actor is not allowed to perform the action on the resource.
+func (a *Authorizer) Can(ctx context.Context, actor domain.Actor, action Action, resource Resource) error {
+	request := Request{
+		Actor:    actor,
+		Action:   action,
+		Resource: resource,
+		Now:      a.now(),
+	}
+
+	for _, rule := range a.policies[resource.Type][action] {
+		if rule(ctx, request) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s %s", models.ErrForbidden, action, resource.Type)
+}
@@ -0,0 +1,131 @@
+package authz_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/authz"
+	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizer_Can(t *testing.T) {
+	const actionHide authz.Action = "hide"
+
+	currentTime := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	// The policy shows how business rules are declared:
+	// owners can edit within a day, moderators can hide but not delete posts.
+	policies := authz.Policies{
+		authz.ResourcePost: {
+			authz.ActionUpdate: {
+				authz.All(authz.IsOwner(), authz.CreatedWithin(24*time.Hour)),
+				authz.HasRole(models.RoleAdmin),
+			},
+			authz.ActionDelete: {authz.IsOwner(), authz.HasRole(models.RoleAdmin)},
+			actionHide:         {authz.HasRole(models.RoleModerator, models.RoleAdmin)},
+		},
+	}
+
+	owner := domain.Actor{UserID: 1, Roles: models.Roles{models.RoleUser}}
+	moderator := domain.Actor{UserID: 2, Roles: models.Roles{models.RoleUser, models.RoleModerator}}
+	admin := domain.Actor{UserID: 3, Roles: models.Roles{models.RoleAdmin}}
+
+	freshPost := authz.Resource{Type: authz.ResourcePost, OwnerID: 1, CreatedAt: currentTime.Add(-time.Hour)}
+	oldPost := authz.Resource{Type: authz.ResourcePost, OwnerID: 1, CreatedAt: currentTime.Add(-48 * time.Hour)}
+
+	testCases := map[string]struct {
+		actor     domain.Actor
+		action    authz.Action
+		resource  authz.Resource
+		wantError error
+	}{
+		"It should allow the owner to update a fresh post": {
+			actor:    owner,
+			action:   authz.ActionUpdate,
+			resource: freshPost,
+		},
+		"It should forbid the owner to update an old post": {
+			actor:     owner,
+			action:    authz.ActionUpdate,
+			resource:  oldPost,
+			wantError: models.ErrForbidden,
+		},
+		"It should allow an admin to update an old post": {
+			actor:    admin,
+			action:   authz.ActionUpdate,
+			resource: oldPost,
+		},
+		"It should allow a moderator to hide a post": {
+			actor:    moderator,
+			action:   actionHide,
+			resource: oldPost,
+		},
+		"It should forbid a moderator to delete a post": {
+			actor:     moderator,
+			action:    authz.ActionDelete,
+			resource:  oldPost,
+			wantError: models.ErrForbidden,
+		},
+		"It should forbid the owner to hide a post": {
+			actor:     owner,
+			action:    actionHide,
+			resource:  freshPost,
+			wantError: models.ErrForbidden,
+		},
+		"It should forbid actions without rules": {
+			actor:     admin,
+			action:    "archive",
+			resource:  freshPost,
+			wantError: models.ErrForbidden,
+		},
+		"It should forbid actions on resources without policies": {
+			actor:     admin,
+			action:    authz.ActionDelete,
+			resource:  authz.Resource{Type: "comment", OwnerID: 3},
+			wantError: models.ErrForbidden,
+		},
+	}
+
+	authorizer := authz.NewAuthorizer(func() time.Time { return currentTime }, policies)
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			err := authorizer.Can(t.Context(), testCase.actor, testCase.action, testCase.resource)
+			assert.ErrorIs(t, err, testCase.wantError)
+		})
+	}
+}
+
+func TestDefaultPolicies(t *testing.T) {
+	post := authz.Resource{Type: authz.ResourcePost, OwnerID: 1, CreatedAt: time.Now().Add(-365 * 24 * time.Hour)}
+
+	testCases := map[string]struct {
+		actor     domain.Actor
+		wantError error
+	}{
+		"It should allow the owner to change a post": {
+			actor: domain.Actor{UserID: 1, Roles: models.Roles{models.RoleUser}},
+		},
+		"It should allow an admin to change any post": {
+			actor: domain.Actor{UserID: 2, Roles: models.Roles{models.RoleAdmin}},
+		},
+		"It should forbid other users to change a post": {
+			actor:     domain.Actor{UserID: 2, Roles: models.Roles{models.RoleUser, models.RoleModerator}},
+			wantError: models.ErrForbidden,
+		},
+	}
+
+	authorizer := authz.NewAuthorizer(time.Now, authz.DefaultPolicies())
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			for _, action := range []authz.Action{authz.ActionUpdate, authz.ActionDelete} {
+				err := authorizer.Can(t.Context(), testCase.actor, action, post)
+				assert.ErrorIs(t, err, testCase.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package authz
+
+import "github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+// DefaultPolicies returns the policies of the application.
+func DefaultPolicies() Policies {
+	return Policies{
+		ResourcePost: {
+			ActionUpdate: {IsOwner(), HasRole(models.RoleAdmin)},
+			ActionDelete: {IsOwner(), HasRole(models.RoleAdmin)},
+		},
+	}
+}
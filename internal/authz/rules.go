@@ -0,0 +1,42 @@
+package authz
+
+import (
+	"context"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+// HasRole allows actors having any of the roles.
+func HasRole(roles ...models.Role) Rule {
+	return func(_ context.Context, request Request) bool {
+		return request.Actor.Roles.Has(roles...)
+	}
+}
+
+// IsOwner allows the actor who owns the resource.
+func IsOwner() Rule {
+	return func(_ context.Context, request Request) bool {
+		return request.Actor.UserID == request.Resource.OwnerID
+	}
+}
+
+// CreatedWithin allows the request only during the period since the resource was created.
+func CreatedWithin(period time.Duration) Rule {
+	return func(_ context.Context, request Request) bool {
+		return request.Now.Sub(request.Resource.CreatedAt) <= period
+	}
+}
+
+// All allows the request only if all of the rules allow it, e.g. All(IsOwner(), CreatedWithin(24*time.Hour)).
+func All(rules ...Rule) Rule {
+	return func(ctx context.Context, request Request) bool {
+		for _, rule := range rules {
+			if !rule(ctx, request) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
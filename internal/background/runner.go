@@ -0,0 +1,46 @@
+// Package background runs tasks off the request path, so that neither their duration nor their errors
+// reach the client.
+package background
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Runner runs tasks in goroutines and keeps track of them, so that they can be finished on shutdown.
+type Runner struct {
+	wg sync.WaitGroup
+}
+
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Go runs the task in a goroutine. The task gets the values of the context, but isn't canceled with it,
+// as the request the task is started by is usually over before the task is. Errors are logged.
+func (r *Runner) Go(ctx context.Context, name string, task func(ctx context.Context) error) {
+	ctx = context.WithoutCancel(ctx)
+
+	r.wg.Go(func() {
+		if err := task(ctx); err != nil {
+			slog.ErrorContext(ctx, "Background task failed", "task", name, "err", err)
+		}
+	})
+}
+
+// Wait waits for all started tasks to finish.
+func (r *Runner) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package background_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/background"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner(t *testing.T) {
+	t.Run("It should wait for started tasks", func(t *testing.T) {
+		runner := background.NewRunner()
+
+		var finished atomic.Int32
+		for range 3 {
+			runner.Go(t.Context(), "test", func(context.Context) error {
+				finished.Add(1)
+				return errors.New("test error")
+			})
+		}
+
+		require.NoError(t, runner.Wait(t.Context()))
+		assert.Equal(t, int32(3), finished.Load())
+	})
+
+	t.Run("It should not cancel tasks with the context they are started with", func(t *testing.T) {
+		runner := background.NewRunner()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		var taskErr error
+		runner.Go(ctx, "test", func(ctx context.Context) error {
+			taskErr = ctx.Err()
+			return nil
+		})
+
+		require.NoError(t, runner.Wait(t.Context()))
+		assert.NoError(t, taskErr)
+	})
+
+	t.Run("It should stop waiting when the context is done", func(t *testing.T) {
+		runner := background.NewRunner()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		runner.Go(t.Context(), "test", func(context.Context) error {
+			<-release
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorIs(t, runner.Wait(ctx), context.DeadlineExceeded)
+	})
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -8,6 +9,7 @@ type Config struct {
 	Logger LogConfig
 	Auth   AuthConfig
 	OAuth  OAuthConfig
+	Mail   MailConfig
 	DB     DBConfig
 	HTTP   HTTPConfig
 }
@@ -26,16 +28,184 @@ type AuthConfig struct {
 	RefreshTokenDuration time.Duration `env:"REFRESH_SECRET_DURATION" envDefault:"168h"`
 	AccessSecret         string        `env:"ACCESS_SECRET"`
 	RefreshSecret        string        `env:"REFRESH_SECRET"`
+
+	// Access token signing algorithm. One of: "HS256", "RS256", "ES256", "EdDSA". Default: "HS256".
+	// HS256 signs with AccessSecret, other algorithms sign with the key from AccessPrivateKeyFile.
+	AccessSigningAlgorithm string `env:"ACCESS_SIGNING_ALGORITHM" envDefault:"HS256"`
+
+	// Path to a PEM encoded private key to sign access tokens with asymmetric algorithms.
+	AccessPrivateKeyFile string `env:"ACCESS_PRIVATE_KEY_FILE"`
+
+	// Retired keys are no longer used for signing but still accepted for verification,
+	// so keys can be rotated without invalidating issued tokens.
+	// Retired key files are listed in "<algorithm>:<path>" format, e.g. "RS256:/keys/old.pem".
+	AccessRetiredSecrets  []string `env:"ACCESS_RETIRED_SECRETS"`
+	AccessRetiredKeyFiles []string `env:"ACCESS_RETIRED_KEY_FILES"`
+	RefreshRetiredSecrets []string `env:"REFRESH_RETIRED_SECRETS"`
+
+	// Action tokens confirm actions out of band, e.g. email verification links. They are signed with HS256.
+	ActionSecret         string   `env:"ACTION_SECRET"`
+	ActionRetiredSecrets []string `env:"ACTION_RETIRED_SECRETS"`
+
+	EmailVerificationDuration time.Duration `env:"EMAIL_VERIFICATION_DURATION" envDefault:"24h"`
+
+	// RequireVerifiedEmail blocks password login until the user verifies the email.
+	RequireVerifiedEmail bool `env:"REQUIRE_VERIFIED_EMAIL"`
+
+	// ConcealRegisteredEmails makes registration respond the same way for new and registered emails,
+	// the owner of a registered email is notified by email instead. Use it with RequireVerifiedEmail.
+	ConcealRegisteredEmails bool `env:"CONCEAL_REGISTERED_EMAILS"`
+
+	PasswordResetDuration time.Duration `env:"PASSWORD_RESET_DURATION" envDefault:"1h"`
+
+	// Password hashing algorithm. One of: "argon2id", "bcrypt". Default: "argon2id".
+	// Stored hashes with another algorithm or parameters are rehashed when the user logs in.
+	PasswordHashAlgorithm string `env:"PASSWORD_HASH_ALGORITHM" envDefault:"argon2id"`
+
+	// Argon2id parameters, the memory is in KiB.
+	PasswordArgon2Memory      uint32 `env:"PASSWORD_ARGON2_MEMORY" envDefault:"65536"`
+	PasswordArgon2Iterations  uint32 `env:"PASSWORD_ARGON2_ITERATIONS" envDefault:"3"`
+	PasswordArgon2Parallelism uint8  `env:"PASSWORD_ARGON2_PARALLELISM" envDefault:"2"`
+
+	PasswordBcryptCost int `env:"PASSWORD_BCRYPT_COST" envDefault:"10"`
+
+	// Password policy for new passwords, lengths are in characters.
+	// Bcrypt ignores passwords beyond 72 bytes, keep the max length lower with it.
+	PasswordMinLength            int  `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordMaxLength            int  `env:"PASSWORD_MAX_LENGTH" envDefault:"128"`
+	PasswordRequireLowercase     bool `env:"PASSWORD_REQUIRE_LOWERCASE"`
+	PasswordRequireUppercase     bool `env:"PASSWORD_REQUIRE_UPPERCASE"`
+	PasswordRequireDigit         bool `env:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSymbol        bool `env:"PASSWORD_REQUIRE_SYMBOL"`
+	PasswordDisallowPersonalInfo bool `env:"PASSWORD_DISALLOW_PERSONAL_INFO" envDefault:"true"`
+
+	// New passwords are checked against breached passwords unless PasswordCheckBreached is false.
+	// PasswordBreachedListFile has one password per line, the bundled list of common passwords is used without it.
+	PasswordCheckBreached    bool   `env:"PASSWORD_CHECK_BREACHED" envDefault:"true"`
+	PasswordBreachedListFile string `env:"PASSWORD_BREACHED_LIST_FILE"`
+
+	// How many forgot password requests a single client IP can make per minute, 0 disables the limit.
+	PasswordForgotRateLimit int `env:"PASSWORD_FORGOT_RATE_LIMIT" envDefault:"5"`
+
+	// MagicLinkEnabled lets users log in with single-use links sent to their email instead of the password.
+	// Links are valid for MagicLinkDuration, a single client IP can request MagicLinkRateLimit links per minute.
+	// A MagicLinkRateLimit of 0 disables the limit.
+	MagicLinkEnabled   bool          `env:"MAGIC_LINK_ENABLED"`
+	MagicLinkDuration  time.Duration `env:"MAGIC_LINK_DURATION" envDefault:"15m"`
+	MagicLinkRateLimit int           `env:"MAGIC_LINK_RATE_LIMIT" envDefault:"5"`
+
+	// CookieAuthEnabled lets browser clients get the tokens as HttpOnly cookies with the "X-Auth-Mode: cookie" header.
+	// CookieSameSite is one of: "strict", "lax", "none". The cookies are set for the host of the request
+	// unless CookieDomain is set.
+	CookieAuthEnabled bool   `env:"COOKIE_AUTH_ENABLED"`
+	CookieDomain      string `env:"COOKIE_DOMAIN"`
+	CookieSameSite    string `env:"COOKIE_SAME_SITE" envDefault:"strict"`
+
+	// TokenClients are credentials of other services allowed to introspect and revoke tokens, mapping client IDs
+	// to secrets in "id:secret,id2:secret2" format. The endpoints are disabled when no clients are configured.
+	TokenClients map[string]string `env:"TOKEN_CLIENTS"`
+
+	// How long access tokens issued to admins impersonating users are valid. They can't be refreshed.
+	ImpersonationTokenDuration time.Duration `env:"IMPERSONATION_TOKEN_DURATION" envDefault:"15m"`
+
+	// MFAIssuer names the service in authenticator apps.
+	MFAIssuer string `env:"MFA_ISSUER" envDefault:"Echo Boilerplate"`
+
+	// Failed logins are counted per account and per client IP and forgotten after LoginFailureWindow without failures.
+	// Once a threshold is reached, logins are locked for LoginLockoutDuration, doubled with every further failure
+	// up to LoginLockoutMaxDuration.
+	LoginFailureWindow      time.Duration `env:"LOGIN_FAILURE_WINDOW" envDefault:"15m"`
+	LoginAccountThreshold   int           `env:"LOGIN_ACCOUNT_LOCKOUT_THRESHOLD" envDefault:"5"`
+	LoginIPThreshold        int           `env:"LOGIN_IP_LOCKOUT_THRESHOLD" envDefault:"20"`
+	LoginLockoutDuration    time.Duration `env:"LOGIN_LOCKOUT_DURATION" envDefault:"1m"`
+	LoginLockoutMaxDuration time.Duration `env:"LOGIN_LOCKOUT_MAX_DURATION" envDefault:"1h"`
+
+	// Where failed login counters are stored. One of: "memory", "db". Default: "memory".
+	// The "memory" store is not shared between replicas of the service.
+	LoginAttemptsStore string `env:"LOGIN_ATTEMPTS_STORE" envDefault:"memory"`
+
+	// Where revoked access tokens are stored. One of: "memory", "db". Default: "memory".
+	// The "memory" store is not shared between replicas of the service.
+	DenylistStore string `env:"ACCESS_TOKEN_DENYLIST_STORE" envDefault:"memory"`
 }
 
 type OAuthConfig struct {
+	// Client ID for Google. Google is configured as the "google" provider when it is set.
 	ClientID string `env:"OPEN_ID_CLIENT_ID"`
+
+	// Client secret and redirect URL for Google, required by the server-side authorization code flow only.
+	ClientSecret string `env:"OPEN_ID_CLIENT_SECRET"`
+	RedirectURL  string `env:"OPEN_ID_REDIRECT_URL"`
+
+	// JSON list of OpenID Connect providers, e.g.
+	// [{"name":"keycloak","issuer":"https://sso.example.com/realms/main","client_id":"echo"}].
+	Providers OIDCProviders `env:"OIDC_PROVIDERS"`
+
+	// Where states of authorization code flows are kept until the provider redirects the user back.
+	// One of: "memory", "db". Default: "memory".
+	// The "memory" store is not shared between replicas of the service.
+	StateStore string `env:"OAUTH_STATE_STORE" envDefault:"memory"`
+}
+
+type OIDCProvider struct {
+	// Name identifies the provider in the "/oauth/:provider" route.
+	Name      string `json:"name"`
+	IssuerURL string `json:"issuer"`
+	ClientID  string `json:"client_id"`
+
+	// ClientSecret and RedirectURL are required by the server-side authorization code flow only.
+	// RedirectURL must point to the "/oauth/:provider/callback" route.
+	ClientSecret string `json:"client_secret"`
+	RedirectURL  string `json:"redirect_url"`
+
+	// Audiences accepted in ID tokens in addition to ClientID.
+	Audiences []string `json:"audiences"`
+
+	// Claims maps user attributes to ID token claims, when the provider uses non-standard claim names.
+	Claims ClaimMapping `json:"claims"`
+}
+
+type ClaimMapping struct {
+	// Default: "email".
+	Email string `json:"email"`
+
+	// Default: "name".
+	Name string `json:"name"`
+
+	// Default: "email_verified".
+	EmailVerified string `json:"email_verified"`
+}
+
+type OIDCProviders []OIDCProvider
+
+func (p *OIDCProviders) UnmarshalText(text []byte) error {
+	return json.Unmarshal(text, (*[]OIDCProvider)(p))
+}
+
+type MailConfig struct {
+	// How emails are delivered. One of: "smtp", "file", "memory". Default: "file".
+	// The "file" sender writes emails to Dir, the "memory" sender drops them and is meant for tests.
+	Sender string `env:"MAIL_SENDER" envDefault:"file"`
+	From   string `env:"MAIL_FROM" envDefault:"no-reply@localhost"`
+	Dir    string `env:"MAIL_DIR" envDefault:"mail"`
+
+	SMTPHost     string `env:"SMTP_HOST"`
+	SMTPPort     string `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+
+	// LinkBaseURL is the URL of the frontend, links in emails point to its pages, e.g. "<LinkBaseURL>/verify-email".
+	LinkBaseURL string `env:"MAIL_LINK_BASE_URL" envDefault:"http://localhost:7788"`
 }
 
 type HTTPConfig struct {
 	Host       string `env:"HOST"`
 	Port       string `env:"PORT"`
 	ExposePort string `env:"EXPOSE_PORT"`
+
+	// TrustedProxies are CIDR ranges of proxies whose X-Forwarded-For header is trusted for the client IP.
+	// The client IP is the remote address of the connection when empty.
+	TrustedProxies []string `env:"TRUSTED_PROXIES"`
 }
 
 type LogConfig struct {
@@ -1,8 +1,20 @@
 package domain
 
-type UpdatePostRequest struct {
-	// UserID is a user which make request.
+import (
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+// Actor is an authenticated user performing an operation.
+type Actor struct {
 	UserID uint
+	Roles  models.Roles
+}
+
+type UpdatePostRequest struct {
+	// Actor is a user which make request.
+	Actor Actor
 
 	// PostID is the post to update.
 	PostID uint
@@ -12,9 +24,72 @@ type UpdatePostRequest struct {
 }
 
 type DeletePostRequest struct {
-	// UserID is a user which make request.
-	UserID uint
+	// Actor is a user which make request.
+	Actor Actor
 
 	// PostID is the post to update.
 	PostID uint
 }
+
+// PostSortField is a field posts are sorted by. Posts with equal values are sorted by ID.
+type PostSortField string
+
+const (
+	PostSortCreatedAt PostSortField = "created_at"
+	PostSortUpdatedAt PostSortField = "updated_at"
+	PostSortTitle     PostSortField = "title"
+)
+
+// PostSort orders posts by the field, posts are sorted by creation time when the field is empty.
+type PostSort struct {
+	Field      PostSortField
+	Descending bool
+}
+
+// PostFilter narrows down posts, nil fields don't filter.
+type PostFilter struct {
+	AuthorID *uint
+
+	// CreatedFrom and CreatedTo bound the creation time, both bounds are inclusive.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+}
+
+type GetPostsRequest struct {
+	Filter PostFilter
+	Sort   PostSort
+
+	// Limit is the page size, the default page size is used when it is zero.
+	Limit int
+
+	// Cursor is the next cursor of the previous page, the first page is returned when it is empty.
+	Cursor string
+}
+
+type PostsPage struct {
+	Posts []models.Post
+
+	// NextCursor points at the next page, it is empty on the last page.
+	NextCursor string
+}
+
+// PostCursor is a position in sorted posts: the sort field value and ID of the last post of a page.
+type PostCursor struct {
+	Sort PostSort
+
+	// Time is the value of created_at or updated_at, Title is the value of title.
+	Time  time.Time
+	Title string
+
+	ID uint
+}
+
+// PostsQuery selects a page of posts from the repository.
+type PostsQuery struct {
+	Filter PostFilter
+	Sort   PostSort
+	Limit  int
+
+	// After selects posts following the cursor, the first page is selected when it is nil.
+	After *PostCursor
+}
@@ -0,0 +1,35 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileSender writes emails to .eml files in a directory instead of delivering them, for local development.
+type FileSender struct {
+	now func() time.Time
+	dir string
+}
+
+func NewFileSender(now func() time.Time, dir string) *FileSender {
+	return &FileSender{now: now, dir: dir}
+}
+
+func (s *FileSender) Send(_ context.Context, message Message) error {
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return fmt.Errorf("create mail directory: %w", err)
+	}
+
+	date := s.now()
+	name := fmt.Sprintf("%s-%s.eml", date.UTC().Format("20060102T150405Z"), rand.Text()[:8])
+
+	if err := os.WriteFile(filepath.Join(s.dir, name), message.bytes(date), 0o600); err != nil {
+		return fmt.Errorf("write mail file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package mail_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	date := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sender := mail.NewFileSender(func() time.Time { return date }, dir)
+
+	err := sender.Send(t.Context(), mail.Message{
+		From:    "no-reply@example.com",
+		To:      "user@example.com",
+		Subject: "Verify your email",
+		Body:    "First line\nSecond line",
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+
+	wantContent := "From: no-reply@example.com\r\n" +
+		"To: user@example.com\r\n" +
+		"Subject: Verify your email\r\n" +
+		"Date: Thu, 02 Jan 2025 03:04:05 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"First line\r\nSecond line"
+
+	assert.Equal(t, wantContent, string(content))
+}
+
+func TestMemorySender(t *testing.T) {
+	sender := mail.NewMemorySender()
+
+	message := mail.Message{To: "user@example.com", Subject: "Subject", Body: "Body"}
+
+	err := sender.Send(t.Context(), message)
+	require.NoError(t, err)
+
+	assert.Equal(t, []mail.Message{message}, sender.Messages())
+}
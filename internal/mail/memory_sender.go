@@ -0,0 +1,34 @@
+package mail
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemorySender keeps emails in memory instead of delivering them, for tests.
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) Send(_ context.Context, message Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, message)
+
+	return nil
+}
+
+// Messages returns emails sent so far.
+func (s *MemorySender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.messages)
+}
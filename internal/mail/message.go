@@ -0,0 +1,32 @@
+package mail
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Message is a plain text email.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Body    string
+}
+
+// bytes formats the message as defined by RFC 5322.
+func (m Message) bytes(date time.Time) []byte {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "From: %s\r\n", m.From)
+	fmt.Fprintf(&builder, "To: %s\r\n", m.To)
+	fmt.Fprintf(&builder, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&builder, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+
+	return []byte(builder.String())
+}
@@ -0,0 +1,38 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPSender delivers emails through an SMTP server. The connection is upgraded with STARTTLS
+// when the server supports it, which is required for authentication with anything but a local server.
+type SMTPSender struct {
+	now  func() time.Time
+	addr string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(now func() time.Time, host, port, username, password string) *SMTPSender {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPSender{
+		now:  now,
+		addr: net.JoinHostPort(host, port),
+		auth: auth,
+	}
+}
+
+func (s *SMTPSender) Send(_ context.Context, message Message) error {
+	if err := smtp.SendMail(s.addr, s.auth, message.From, []string{message.To}, message.bytes(s.now())); err != nil {
+		return fmt.Errorf("send mail via %s: %w", s.addr, err)
+	}
+
+	return nil
+}
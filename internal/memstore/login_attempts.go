@@ -0,0 +1,106 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+// LoginAttempts keeps failed login counters in memory.
+//
+// It is suitable for a single replica only: every replica counts failures on its own,
+// so an attacker spreading attempts across replicas gets more guesses.
+type LoginAttempts struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	attempts map[string]models.LoginAttempt
+}
+
+func NewLoginAttempts(now func() time.Time) *LoginAttempts {
+	return &LoginAttempts{
+		now:      now,
+		attempts: make(map[string]models.LoginAttempt),
+	}
+}
+
+// Get returns recent failures of the key. It returns a zero attempt when there are none.
+func (s *LoginAttempts) Get(_ context.Context, key string) (models.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.get(key), nil
+}
+
+// AddFailure counts a failure of the key, keeps the failures until expiresAt and returns how many there are.
+func (s *LoginAttempts) AddFailure(_ context.Context, key string, expiresAt time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt := s.get(key)
+	attempt.Key = key
+	attempt.Failures++
+	attempt.ExpiresAt = later(attempt.ExpiresAt, expiresAt)
+	s.attempts[key] = attempt
+
+	return attempt.Failures, nil
+}
+
+// Lock locks the key until lockedUntil. Failures are kept at least until the lock ends.
+func (s *LoginAttempts) Lock(_ context.Context, key string, lockedUntil time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt := s.get(key)
+	attempt.Key = key
+	attempt.LockedUntil = &lockedUntil
+	attempt.ExpiresAt = later(attempt.ExpiresAt, lockedUntil)
+	s.attempts[key] = attempt
+
+	return nil
+}
+
+// Reset forgets failures of the key.
+func (s *LoginAttempts) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+
+	return nil
+}
+
+// Prune forgets attempts that have already expired.
+func (s *LoginAttempts) Prune() {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, attempt := range s.attempts {
+		if attempt.ExpiresAt.Before(now) {
+			delete(s.attempts, key)
+		}
+	}
+}
+
+// get returns the attempt of the key, or a zero attempt if it has expired but isn't pruned yet.
+// The caller must hold the lock.
+func (s *LoginAttempts) get(key string) models.LoginAttempt {
+	attempt, ok := s.attempts[key]
+	if !ok || attempt.ExpiresAt.Before(s.now()) {
+		return models.LoginAttempt{}
+	}
+
+	return attempt
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
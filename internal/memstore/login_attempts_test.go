@@ -0,0 +1,93 @@
+package memstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/memstore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttempts(t *testing.T) {
+	currentTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	store := memstore.NewLoginAttempts(func() time.Time { return currentTime })
+
+	t.Run("It should return zero attempt for unknown key", func(t *testing.T) {
+		attempt, err := store.Get(t.Context(), "unknown")
+		require.NoError(t, err)
+		assert.Zero(t, attempt.Failures)
+		assert.Nil(t, attempt.LockedUntil)
+	})
+
+	t.Run("It should count failures until they expire", func(t *testing.T) {
+		failures, err := store.AddFailure(t.Context(), "counted", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, failures)
+
+		failures, err = store.AddFailure(t.Context(), "counted", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 2, failures)
+
+		currentTime = currentTime.Add(2 * time.Minute)
+
+		attempt, err := store.Get(t.Context(), "counted")
+		require.NoError(t, err)
+		assert.Zero(t, attempt.Failures)
+
+		failures, err = store.AddFailure(t.Context(), "counted", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, failures)
+	})
+
+	t.Run("It should keep failures until lock ends", func(t *testing.T) {
+		_, err := store.AddFailure(t.Context(), "locked", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+
+		lockedUntil := currentTime.Add(time.Hour)
+		require.NoError(t, store.Lock(t.Context(), "locked", lockedUntil))
+
+		currentTime = currentTime.Add(30 * time.Minute)
+
+		attempt, err := store.Get(t.Context(), "locked")
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempt.Failures)
+		require.NotNil(t, attempt.LockedUntil)
+		assert.Equal(t, lockedUntil, *attempt.LockedUntil)
+	})
+
+	t.Run("It should reset failures", func(t *testing.T) {
+		_, err := store.AddFailure(t.Context(), "reset", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+
+		require.NoError(t, store.Reset(t.Context(), "reset"))
+
+		attempt, err := store.Get(t.Context(), "reset")
+		require.NoError(t, err)
+		assert.Zero(t, attempt.Failures)
+	})
+
+	t.Run("It should not count failures that expired before they were pruned", func(t *testing.T) {
+		_, err := store.AddFailure(t.Context(), "stale", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+
+		currentTime = currentTime.Add(2 * time.Minute)
+
+		failures, err := store.AddFailure(t.Context(), "stale", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, failures)
+	})
+
+	t.Run("It should keep failures that haven't expired on prune", func(t *testing.T) {
+		_, err := store.AddFailure(t.Context(), "pruned", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+
+		store.Prune()
+
+		attempt, err := store.Get(t.Context(), "pruned")
+		require.NoError(t, err)
+		assert.Equal(t, 1, attempt.Failures)
+	})
+}
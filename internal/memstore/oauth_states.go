@@ -0,0 +1,81 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+// OAuthStates keeps the state of authorization code flows in memory.
+//
+// It is suitable for a single replica only: the provider may redirect the user back to another replica,
+// which does not know the state and rejects the callback.
+type OAuthStates struct {
+	now       func() time.Time
+	maxStates int
+
+	mu     sync.Mutex
+	states map[string]models.OAuthState
+}
+
+// NewOAuthStates creates the store that keeps at most maxStates flows, so that flows started and never completed
+// can't exhaust the memory.
+func NewOAuthStates(now func() time.Time, maxStates int) *OAuthStates {
+	return &OAuthStates{
+		now:       now,
+		maxStates: maxStates,
+		states:    make(map[string]models.OAuthState),
+	}
+}
+
+// Save stores the flow state.
+// It returns [models.ErrTooManyOAuthStates] when the store is full until expired states are pruned.
+func (s *OAuthStates) Save(_ context.Context, state string, oAuthState models.OAuthState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.states) >= s.maxStates {
+		return models.ErrTooManyOAuthStates
+	}
+
+	oAuthState.State = state
+	s.states[state] = oAuthState
+
+	return nil
+}
+
+// Take removes the flow state so that it can be used only once.
+// It returns [models.ErrInvalidOAuthState] when the state is unknown or expired.
+func (s *OAuthStates) Take(_ context.Context, state string) (models.OAuthState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	oAuthState, ok := s.states[state]
+	if !ok {
+		return models.OAuthState{}, models.ErrInvalidOAuthState
+	}
+
+	delete(s.states, state)
+
+	if oAuthState.ExpiresAt.Before(s.now()) {
+		return models.OAuthState{}, models.ErrInvalidOAuthState
+	}
+
+	return oAuthState, nil
+}
+
+// Prune removes states that have already expired.
+func (s *OAuthStates) Prune() {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for state, oAuthState := range s.states {
+		if oAuthState.ExpiresAt.Before(now) {
+			delete(s.states, state)
+		}
+	}
+}
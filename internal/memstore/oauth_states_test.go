@@ -0,0 +1,69 @@
+package memstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/memstore"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthStates(t *testing.T) {
+	currentTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	store := memstore.NewOAuthStates(func() time.Time { return currentTime }, 2)
+
+	oAuthState := models.OAuthState{
+		Provider:     "google",
+		CodeVerifier: "verifier",
+		Nonce:        "nonce",
+		ExpiresAt:    currentTime.Add(time.Minute),
+	}
+
+	t.Run("It should return an error for unknown state", func(t *testing.T) {
+		_, err := store.Take(t.Context(), "unknown")
+		assert.ErrorIs(t, err, models.ErrInvalidOAuthState)
+	})
+
+	t.Run("It should take state only once", func(t *testing.T) {
+		err := store.Save(t.Context(), "state", oAuthState)
+		require.NoError(t, err)
+
+		gotState, err := store.Take(t.Context(), "state")
+		require.NoError(t, err)
+		wantState := oAuthState
+		wantState.State = "state"
+		assert.Equal(t, wantState, gotState)
+
+		_, err = store.Take(t.Context(), "state")
+		assert.ErrorIs(t, err, models.ErrInvalidOAuthState)
+	})
+
+	t.Run("It should return an error for expired state", func(t *testing.T) {
+		err := store.Save(t.Context(), "state", oAuthState)
+		require.NoError(t, err)
+
+		currentTime = currentTime.Add(2 * time.Minute)
+
+		_, err = store.Take(t.Context(), "state")
+		assert.ErrorIs(t, err, models.ErrInvalidOAuthState)
+	})
+
+	t.Run("It should reject states when the store is full until expired ones are pruned", func(t *testing.T) {
+		oAuthState.ExpiresAt = currentTime.Add(time.Minute)
+
+		require.NoError(t, store.Save(t.Context(), "first", oAuthState))
+		require.NoError(t, store.Save(t.Context(), "second", oAuthState))
+
+		err := store.Save(t.Context(), "third", oAuthState)
+		assert.ErrorIs(t, err, models.ErrTooManyOAuthStates)
+
+		currentTime = currentTime.Add(2 * time.Minute)
+		store.Prune()
+
+		assert.NoError(t, store.Save(t.Context(), "third", oAuthState))
+	})
+}
@@ -0,0 +1,22 @@
+package memstore
+
+import (
+	"context"
+	"time"
+)
+
+// PruneEvery calls prune every interval until the context is done. Stores are pruned in the background
+// rather than on writes, so that a write doesn't scan the whole store under its lock.
+func PruneEvery(ctx context.Context, interval time.Duration, prune func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			prune()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
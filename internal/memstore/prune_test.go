@@ -0,0 +1,38 @@
+package memstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/memstore"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneEvery(t *testing.T) {
+	t.Run("It should prune until the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+
+		pruned := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			memstore.PruneEvery(ctx, time.Millisecond, func() {
+				select {
+				case pruned <- struct{}{}:
+				default:
+				}
+			})
+			close(done)
+		}()
+
+		<-pruned
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			assert.Fail(t, "PruneEvery hasn't returned after the context is done")
+		}
+	})
+}
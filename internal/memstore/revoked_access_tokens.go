@@ -0,0 +1,61 @@
+package memstore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RevokedAccessTokens is an in-memory access token denylist.
+//
+// It is suitable for a single replica only: every replica keeps its own denylist,
+// so a token revoked on one replica is still accepted by the others.
+type RevokedAccessTokens struct {
+	now func() time.Time
+
+	mu     sync.RWMutex
+	tokens map[string]time.Time
+}
+
+func NewRevokedAccessTokens(now func() time.Time) *RevokedAccessTokens {
+	return &RevokedAccessTokens{
+		now:    now,
+		tokens: make(map[string]time.Time),
+	}
+}
+
+// Revoke adds the token to the denylist.
+func (s *RevokedAccessTokens) Revoke(_ context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[tokenID] = expiresAt
+
+	return nil
+}
+
+func (s *RevokedAccessTokens) IsRevoked(_ context.Context, tokenID string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expiresAt, ok := s.tokens[tokenID]
+	if !ok {
+		return false, nil
+	}
+
+	return !expiresAt.Before(s.now()), nil
+}
+
+// Prune removes entries of tokens that have already expired.
+func (s *RevokedAccessTokens) Prune() {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, expiresAt := range s.tokens {
+		if expiresAt.Before(now) {
+			delete(s.tokens, id)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package memstore_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/memstore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokedAccessTokens(t *testing.T) {
+	currentTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	store := memstore.NewRevokedAccessTokens(func() time.Time { return currentTime })
+
+	t.Run("It should not report unknown token as revoked", func(t *testing.T) {
+		revoked, err := store.IsRevoked(t.Context(), "unknown")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("It should report revoked token until it expires", func(t *testing.T) {
+		err := store.Revoke(t.Context(), "token-id", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+
+		revoked, err := store.IsRevoked(t.Context(), "token-id")
+		require.NoError(t, err)
+		assert.True(t, revoked)
+
+		currentTime = currentTime.Add(2 * time.Minute)
+
+		revoked, err = store.IsRevoked(t.Context(), "token-id")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("It should keep tokens that haven't expired on prune", func(t *testing.T) {
+		err := store.Revoke(t.Context(), "pruned", currentTime.Add(time.Minute))
+		require.NoError(t, err)
+
+		store.Prune()
+
+		revoked, err := store.IsRevoked(t.Context(), "pruned")
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+}
@@ -0,0 +1,15 @@
+package models
+
+// ActionPurpose is the action an action token confirms. A token issued for one purpose is rejected for others.
+type ActionPurpose string
+
+const (
+	ActionVerifyEmail ActionPurpose = "verify_email"
+	ActionChangeEmail ActionPurpose = "change_email"
+
+	// ActionMFALogin tokens are issued after the password check and exchanged for a session with a second factor.
+	ActionMFALogin ActionPurpose = "mfa_login"
+
+	// ActionMagicLinkLogin tokens are emailed to the user and exchanged for a session instead of the password.
+	ActionMagicLinkLogin ActionPurpose = "magic_link_login"
+)
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey is a personal access token for machine clients. Only a hash of the key is stored.
+type APIKey struct {
+	gorm.Model
+	UserID uint
+	Name   string `gorm:"type:varchar(100)"`
+
+	// Prefix is the beginning of the key, so users can tell their keys apart.
+	Prefix  string `gorm:"type:varchar(16)"`
+	KeyHash string `gorm:"type:char(64)"`
+	Scopes  Scopes `gorm:"type:json;serializer:json"`
+
+	// ExpiresAt is nil for keys which never expire.
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+}
@@ -1,13 +1,74 @@
 package models
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
 
 var (
-	ErrUserNotFound     = errors.New("user not found")
-	ErrInvalidPassword  = errors.New("invalid password")
-	ErrInvalidAuthToken = errors.New("invalid authorization jwt token")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists = errors.New("user already exists")
+	ErrInvalidPassword   = errors.New("invalid password")
+	ErrInvalidAuthToken  = errors.New("invalid authorization jwt token")
+	ErrInvalidScope      = errors.New("invalid scope")
+	ErrLastLoginMethod   = errors.New("last login method of the user")
+	ErrEmailNotVerified  = errors.New("email is not verified")
+	ErrLoginLocked       = errors.New("login is locked")
+	ErrWeakPassword      = errors.New("password does not meet the password policy")
+
+	ErrRefreshTokenNotFound = errors.New("refresh token not found")
+	ErrRefreshTokenReused   = errors.New("refresh token reuse detected")
+	ErrSessionNotFound      = errors.New("session not found")
+
+	ErrInvalidActionToken = errors.New("invalid action token")
+	ErrInvalidResetToken  = errors.New("invalid password reset token")
+
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	ErrMFANotEnrolled    = errors.New("mfa is not enrolled")
+	ErrMFAAlreadyEnabled = errors.New("mfa is already enabled")
+	ErrInvalidMFACode    = errors.New("invalid mfa code")
+
+	ErrOAuthProviderNotFound = errors.New("oauth provider not found")
+	ErrInvalidOAuthState     = errors.New("invalid oauth state")
+	ErrTooManyOAuthStates    = errors.New("too many pending oauth states")
+	ErrOAuthIdentityNotFound = errors.New("oauth identity not found")
+	ErrOAuthIdentityLinked   = errors.New("oauth identity is linked to another user")
+	ErrOAuthEmailNotVerified = errors.New("oauth email is not verified")
 
-	ErrPostNotFound = errors.New("post not found")
+	ErrPostNotFound  = errors.New("post not found")
+	ErrInvalidCursor = errors.New("invalid cursor")
 
 	ErrForbidden = errors.New("operation forbidden")
 )
+
+// LoginLockedError is returned when login is temporarily locked after too many failed attempts.
+// It matches [ErrLoginLocked] with [errors.Is].
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Error() + ": retry after " + strconv.Itoa(int(e.RetryAfter.Seconds())) + "s"
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
+// PasswordPolicyError is returned when a new password breaks the password policy.
+// It matches [ErrWeakPassword] with [errors.Is].
+type PasswordPolicyError struct {
+	// Violations explain every rule the password breaks.
+	Violations []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return ErrWeakPassword.Error() + ": " + strings.Join(e.Violations, "; ")
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
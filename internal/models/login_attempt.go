@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// LoginAttempt counts recent failed logins of an account or a client IP.
+type LoginAttempt struct {
+	Key      string `gorm:"column:attempt_key;primaryKey;type:varchar(72)"`
+	Failures int
+
+	// LockedUntil is set once failures reach the lockout threshold.
+	LockedUntil *time.Time
+
+	// ExpiresAt is when the failures are forgotten.
+	ExpiresAt time.Time
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// TOTPSecret is the shared secret of the user's authenticator app.
+// MFA is enabled for the user once the secret is confirmed with a valid code.
+type TOTPSecret struct {
+	UserID uint   `gorm:"primaryKey;autoIncrement:false"`
+	Secret string `gorm:"type:varchar(64)"`
+
+	// LastUsedStep is the time step of the last accepted code, so that a code can't be used twice.
+	LastUsedStep int64
+
+	ConfirmedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the authenticator app is lost.
+// Only a hash of the code is stored.
+type RecoveryCode struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint
+	CodeHash  string `gorm:"type:char(64)"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
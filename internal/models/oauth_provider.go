@@ -6,9 +6,15 @@ type Providers string
 
 const GOOGLE Providers = "google"
 
+// LegacyOAuthSubjectPrefix prefixes subjects of identities stored before subjects were,
+// until the user logs in with the provider again and the real subject is adopted.
+const LegacyOAuthSubjectPrefix = "legacy:"
+
+// OAuthProviders is an identity of the user at an OpenID Connect provider, identified by the provider and subject.
 type OAuthProviders struct {
 	gorm.Model
 	UserID   uint      `json:"user_id"`
 	Token    string    `json:"token"`
 	Provider Providers `json:"provider"`
+	Subject  string    `json:"subject" gorm:"type:varchar(255)"`
 }
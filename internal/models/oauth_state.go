@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// OAuthState is an authorization code flow started by the server, kept until the provider redirects the user back.
+type OAuthState struct {
+	State        string `gorm:"primaryKey;type:varchar(64)"`
+	Provider     string `gorm:"type:varchar(64)"`
+	CodeVerifier string `gorm:"type:varchar(128)"`
+	Nonce        string `gorm:"type:varchar(64)"`
+	ExpiresAt    time.Time
+}
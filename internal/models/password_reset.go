@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// PasswordReset is a pending password reset. Only a hash of the emailed token is stored.
+type PasswordReset struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint
+	TokenHash string `gorm:"type:char(64)"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a persisted refresh token. Only a hash of the token is stored.
+//
+// Every login starts a new family, and each rotation of a refresh token issues a new token within
+// the same family. Presenting an already used token revokes the whole family.
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint
+	FamilyID  string `gorm:"type:varchar(36)"`
+	TokenHash string `gorm:"type:char(64)"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// RevokedAccessToken is an access token that must be rejected before it expires.
+type RevokedAccessToken struct {
+	TokenID   string `gorm:"primaryKey;type:varchar(36)"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
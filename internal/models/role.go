@@ -0,0 +1,21 @@
+package models
+
+import "slices"
+
+type Role string
+
+const (
+	RoleAdmin     Role = "admin"
+	RoleModerator Role = "moderator"
+	RoleUser      Role = "user"
+)
+
+// Roles is a set of roles granted to a user.
+type Roles []Role
+
+// Has reports whether any of the given roles is granted.
+func (r Roles) Has(roles ...Role) bool {
+	return slices.ContainsFunc(roles, func(role Role) bool {
+		return slices.Contains(r, role)
+	})
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scope limits what a token is allowed to do, independently of the roles of its user.
+type Scope string
+
+const (
+	ScopePostsRead  Scope = "posts:read"
+	ScopePostsWrite Scope = "posts:write"
+
+	// ScopeAccount allows managing the account itself, e.g. its API keys.
+	ScopeAccount Scope = "account"
+
+	// ScopeAdmin allows admin actions, for users with the admin role only.
+	ScopeAdmin Scope = "admin"
+)
+
+// Scopes is a set of scopes. It is serialized as a space-separated string in tokens, as in OAuth 2.0.
+type Scopes []Scope
+
+// AllScopes returns every known scope. Tokens get all scopes unless a subset is requested.
+func AllScopes() Scopes {
+	return Scopes{ScopePostsRead, ScopePostsWrite, ScopeAccount, ScopeAdmin}
+}
+
+// ParseScopes parses a space-separated list of scopes.
+func ParseScopes(scope string) Scopes {
+	fields := strings.Fields(scope)
+
+	scopes := make(Scopes, 0, len(fields))
+	for _, field := range fields {
+		scopes = append(scopes, Scope(field))
+	}
+
+	return scopes
+}
+
+func (s Scopes) String() string {
+	fields := make([]string, 0, len(s))
+	for _, scope := range s {
+		fields = append(fields, string(scope))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// Contains reports whether all the given scopes are in the set.
+func (s Scopes) Contains(scopes ...Scope) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(s, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Grant returns the requested scopes if they are a subset of the set, or the whole set if none are requested.
+// It returns [ErrInvalidScope] if any requested scope is not in the set.
+func (s Scopes) Grant(requested Scopes) (Scopes, error) {
+	if len(requested) == 0 {
+		return slices.Clone(s), nil
+	}
+
+	for _, scope := range requested {
+		if !s.Contains(scope) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidScope, scope)
+		}
+	}
+
+	return slices.Clone(requested), nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Providers of the first factor sessions are started with, sessions started with OAuth have the name of the provider.
+const (
+	LoginProviderPassword  = "password"
+	LoginProviderMagicLink = "magic_link"
+)
+
+// Session is a login of the user on a device. Its ID is the family ID of the refresh tokens issued for it.
+type Session struct {
+	ID        string `gorm:"primaryKey;type:varchar(36)"`
+	UserID    uint
+	UserAgent string `gorm:"type:varchar(512)"`
+	IPAddress string `gorm:"type:varchar(45)"`
+	Provider  string `gorm:"type:varchar(32)"`
+	CreatedAt time.Time
+
+	// LastUsedAt is when the session was started or its refresh token was last rotated.
+	LastUsedAt time.Time
+
+	// ExpiresAt is when the latest refresh token of the session expires.
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+// Client is the device a request is made from.
+type Client struct {
+	IPAddress string
+	UserAgent string
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// UsedActionToken is an action token that has been used and must be rejected until it expires.
+type UsedActionToken struct {
+	TokenID   string `gorm:"primaryKey;type:varchar(36)"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
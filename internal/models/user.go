@@ -1,11 +1,19 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
 	gorm.Model
 	Email    string `json:"email" gorm:"type:varchar(200);"`
 	Name     string `json:"name" gorm:"type:varchar(200);"`
 	Password string `json:"password" gorm:"type:varchar(200);"`
-	Post     []Post
+	Roles    Roles  `json:"roles" gorm:"type:json;serializer:json"`
+
+	// VerifiedAt is when the user proved the ownership of the email. It is nil until then.
+	VerifiedAt *time.Time `json:"verified_at"`
+	Post       []Post
 }
@@ -0,0 +1,216 @@
+package passhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+var errUnknownFormat = errors.New("unknown password hash format")
+
+// Argon2Params are parameters of argon2id hashes, see RFC 9106 for recommended values.
+type Argon2Params struct {
+	// Memory is in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// Hasher hashes passwords with the configured algorithm and checks hashes of every supported algorithm,
+// so that stored hashes can be upgraded one by one.
+//
+// Hashes are encoded in the PHC string format, e.g. "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>",
+// bcrypt hashes keep their own "$2a$<cost>$..." format. Both describe the algorithm and parameters they use.
+type Hasher struct {
+	algorithm    string
+	argon2Params Argon2Params
+	bcryptCost   int
+
+	// dummyHash is compared instead of missing hashes.
+	dummyHash string
+}
+
+// NewHasher creates the hasher. New hashes use the algorithm, which is one of [AlgorithmArgon2id] and [AlgorithmBcrypt].
+func NewHasher(algorithm string, argon2Params Argon2Params, bcryptCost int) (*Hasher, error) {
+	switch algorithm {
+	case AlgorithmArgon2id:
+		if argon2Params.Memory == 0 || argon2Params.Iterations == 0 || argon2Params.Parallelism == 0 {
+			return nil, errors.New("argon2id memory, iterations and parallelism must be positive")
+		}
+	case AlgorithmBcrypt:
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", algorithm)
+	}
+
+	hasher := &Hasher{
+		algorithm:    algorithm,
+		argon2Params: argon2Params,
+		bcryptCost:   bcryptCost,
+	}
+
+	dummyHash, err := hasher.Hash(rand.Text())
+	if err != nil {
+		return nil, fmt.Errorf("hash dummy password: %w", err)
+	}
+
+	hasher.dummyHash = dummyHash
+
+	return hasher, nil
+}
+
+// Hash hashes the password with the configured algorithm.
+func (h *Hasher) Hash(password string) (string, error) {
+	if h.algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.bcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("generate bcrypt hash: %w", err)
+		}
+
+		return string(hash), nil
+	}
+
+	salt := make([]byte, h.argon2Params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+
+	params := h.argon2Params
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return encodeArgon2id(params, salt, key), nil
+}
+
+// Compare checks the password against the hash. An empty hash never matches, but it takes as long to check
+// as a real one, so that the response time doesn't reveal users without a password.
+// It returns [models.ErrInvalidPassword] when the password doesn't match.
+//
+// needsRehash reports whether the hash uses another algorithm or other parameters than the configured ones,
+// so it should be replaced with a new hash of the password.
+func (h *Hasher) Compare(hash, password string) (needsRehash bool, err error) {
+	if hash == "" {
+		_, _ = h.Compare(h.dummyHash, password)
+
+		return false, fmt.Errorf("%w: empty password hash", models.ErrInvalidPassword)
+	}
+
+	switch {
+	case strings.HasPrefix(hash, "$"+AlgorithmArgon2id+"$"):
+		return h.compareArgon2id(hash, password)
+	case strings.HasPrefix(hash, "$2"):
+		return h.compareBcrypt(hash, password)
+	default:
+		return false, errUnknownFormat
+	}
+}
+
+func (h *Hasher) compareBcrypt(hash, password string) (bool, error) {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return false, errors.Join(models.ErrInvalidPassword, fmt.Errorf("compare bcrypt hash: %w", err))
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false, fmt.Errorf("get bcrypt cost: %w", err)
+	}
+
+	return h.algorithm != AlgorithmBcrypt || cost != h.bcryptCost, nil
+}
+
+func (h *Hasher) compareArgon2id(hash, password string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		return false, fmt.Errorf("decode argon2id hash: %w", err)
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return false, fmt.Errorf("%w: argon2id key mismatch", models.ErrInvalidPassword)
+	}
+
+	currentParams := h.argon2Params
+	needsRehash := h.algorithm != AlgorithmArgon2id ||
+		params.Memory != currentParams.Memory ||
+		params.Iterations != currentParams.Iterations ||
+		params.Parallelism != currentParams.Parallelism ||
+		params.SaltLength != currentParams.SaltLength ||
+		params.KeyLength != currentParams.KeyLength
+
+	return needsRehash, nil
+}
+
+func encodeArgon2id(params Argon2Params, salt, key []byte) string {
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id,
+		argon2.Version,
+		params.Memory,
+		params.Iterations,
+		params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decodeArgon2id(hash string) (Argon2Params, []byte, []byte, error) {
+	// The hash looks like "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>".
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return Argon2Params{}, nil, nil, errUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("parse version: %w", err)
+	}
+
+	if version != argon2.Version {
+		return Argon2Params{}, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var params Argon2Params
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("parse parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("decode salt: %w", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("decode key: %w", err)
+	}
+
+	params.SaltLength, err = safecast.Convert[uint32](len(salt))
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("convert salt length: %w", err)
+	}
+
+	params.KeyLength, err = safecast.Convert[uint32](len(key))
+	if err != nil {
+		return Argon2Params{}, nil, nil, fmt.Errorf("convert key length: %w", err)
+	}
+
+	return params, salt, key, nil
+}
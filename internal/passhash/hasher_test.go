@@ -0,0 +1,143 @@
+package passhash_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/passhash"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var argon2Params = passhash.Argon2Params{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func newHasher(t *testing.T, algorithm string, argon2Params passhash.Argon2Params, bcryptCost int) *passhash.Hasher {
+	t.Helper()
+
+	hasher, err := passhash.NewHasher(algorithm, argon2Params, bcryptCost)
+	require.NoError(t, err)
+
+	return hasher
+}
+
+func TestNewHasher(t *testing.T) {
+	testCases := map[string]struct {
+		algorithm    string
+		argon2Params passhash.Argon2Params
+		bcryptCost   int
+	}{
+		"It should reject unknown algorithm": {
+			algorithm: "md5",
+		},
+		"It should reject argon2id without memory": {
+			algorithm:    passhash.AlgorithmArgon2id,
+			argon2Params: passhash.Argon2Params{Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+		},
+		"It should reject bcrypt cost out of range": {
+			algorithm:  passhash.AlgorithmBcrypt,
+			bcryptCost: bcrypt.MaxCost + 1,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			_, err := passhash.NewHasher(testCase.algorithm, testCase.argon2Params, testCase.bcryptCost)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHasher(t *testing.T) {
+	testCases := map[string]struct {
+		algorithm  string
+		wantPrefix string
+	}{
+		"It should hash password with argon2id": {
+			algorithm:  passhash.AlgorithmArgon2id,
+			wantPrefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+		"It should hash password with bcrypt": {
+			algorithm:  passhash.AlgorithmBcrypt,
+			wantPrefix: "$2a$04$",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			hasher := newHasher(t, testCase.algorithm, argon2Params, bcrypt.MinCost)
+
+			hash, err := hasher.Hash("password")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(hash, testCase.wantPrefix), hash)
+
+			needsRehash, err := hasher.Compare(hash, "password")
+			require.NoError(t, err)
+			assert.False(t, needsRehash)
+
+			_, err = hasher.Compare(hash, "other-password")
+			assert.ErrorIs(t, err, models.ErrInvalidPassword)
+		})
+	}
+}
+
+func TestHasher_Compare(t *testing.T) {
+	argon2Hasher := newHasher(t, passhash.AlgorithmArgon2id, argon2Params, bcrypt.MinCost)
+	bcryptHasher := newHasher(t, passhash.AlgorithmBcrypt, argon2Params, bcrypt.MinCost)
+
+	argon2Hash, err := argon2Hasher.Hash("password")
+	require.NoError(t, err)
+
+	bcryptHash, err := bcryptHasher.Hash("password")
+	require.NoError(t, err)
+
+	t.Run("It should request rehash of bcrypt hash when argon2id is configured", func(t *testing.T) {
+		needsRehash, err := argon2Hasher.Compare(bcryptHash, "password")
+		require.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("It should request rehash of argon2id hash when bcrypt is configured", func(t *testing.T) {
+		needsRehash, err := bcryptHasher.Compare(argon2Hash, "password")
+		require.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("It should request rehash when argon2id parameters change", func(t *testing.T) {
+		strongerParams := argon2Params
+		strongerParams.Iterations = 2
+
+		hasher := newHasher(t, passhash.AlgorithmArgon2id, strongerParams, bcrypt.MinCost)
+
+		needsRehash, err := hasher.Compare(argon2Hash, "password")
+		require.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("It should request rehash when bcrypt cost changes", func(t *testing.T) {
+		hasher := newHasher(t, passhash.AlgorithmBcrypt, argon2Params, bcrypt.MinCost+1)
+
+		needsRehash, err := hasher.Compare(bcryptHash, "password")
+		require.NoError(t, err)
+		assert.True(t, needsRehash)
+	})
+
+	t.Run("It should not match empty hash", func(t *testing.T) {
+		_, err := argon2Hasher.Compare("", "")
+		assert.ErrorIs(t, err, models.ErrInvalidPassword)
+	})
+
+	t.Run("It should return error for unknown hash format", func(t *testing.T) {
+		_, err := argon2Hasher.Compare("plain-text", "plain-text")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, models.ErrInvalidPassword)
+	})
+}
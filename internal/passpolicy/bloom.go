@@ -0,0 +1,63 @@
+package passpolicy
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// BloomFilter is a compact set of strings. Contains never misses an added string,
+// but reports a string that has not been added with the false positive rate the filter is sized for.
+type BloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// NewBloomFilter creates a filter sized for the number of items, so that Contains reports
+// a missing item with at most the false positive rate, which is between 0 and 1.
+func NewBloomFilter(items int, falsePositiveRate float64) *BloomFilter {
+	items = max(items, 1)
+
+	// The optimal size is -n*ln(p)/ln(2)^2 bits and the optimal number of hashes is size/n*ln(2).
+	size := uint64(math.Ceil(-float64(items) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	size = max(size, 64)
+	hashes := uint64(math.Round(float64(size) / float64(items) * math.Ln2))
+	hashes = max(hashes, 1)
+
+	return &BloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+func (f *BloomFilter) Add(item string) {
+	h1, h2 := hashPair(item)
+	for i := range f.hashes {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+func (f *BloomFilter) Contains(item string) bool {
+	h1, h2 := hashPair(item)
+	for i := range f.hashes {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hashPair splits the 64-bit FNV-1a hash of the item into its low and high 32 bits.
+// The filter derives the i-th bit index as (h1 + i*h2) % size.
+func hashPair(item string) (uint64, uint64) {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(item))
+	sum := hash.Sum64()
+
+	// The second hash is made odd, so it's never zero, which would put every index on the same bit.
+	return sum & math.MaxUint32, sum>>32 | 1
+}
@@ -0,0 +1,68 @@
+package passpolicy
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// breachedFalsePositiveRate is the share of passwords wrongly rejected as breached.
+const breachedFalsePositiveRate = 0.0001
+
+// bundledBreachedPasswords are some of the most common passwords from public breaches.
+//
+//go:embed breached_passwords.txt
+var bundledBreachedPasswords []byte
+
+// LoadBreachedPasswords builds a filter of the passwords in the file, one password per line.
+// The bundled list of common passwords is loaded when the path is empty.
+func LoadBreachedPasswords(path string) (*BloomFilter, error) {
+	if path == "" {
+		return ReadBreachedPasswords(bytes.NewReader(bundledBreachedPasswords))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open breached passwords file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadBreachedPasswords(file)
+}
+
+// ReadBreachedPasswords builds a filter of the passwords, one password per line.
+// The passwords are read twice, first to size the filter and then to fill it, so large lists aren't kept in memory.
+func ReadBreachedPasswords(r io.ReadSeeker) (*BloomFilter, error) {
+	count := 0
+	err := scanPasswords(r, func(string) { count++ })
+	if err != nil {
+		return nil, fmt.Errorf("count breached passwords: %w", err)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewind breached passwords: %w", err)
+	}
+
+	filter := NewBloomFilter(count, breachedFalsePositiveRate)
+	if err := scanPasswords(r, filter.Add); err != nil {
+		return nil, fmt.Errorf("read breached passwords: %w", err)
+	}
+
+	return filter, nil
+}
+
+func scanPasswords(r io.Reader, fn func(password string)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		password := strings.TrimSpace(scanner.Text())
+		if password != "" {
+			fn(password)
+		}
+	}
+
+	return scanner.Err()
+}
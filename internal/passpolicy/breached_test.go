@@ -0,0 +1,69 @@
+package passpolicy_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/passpolicy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	filter := passpolicy.NewBloomFilter(1000, 0.01)
+	for i := range 1000 {
+		filter.Add(fmt.Sprintf("added-%d", i))
+	}
+
+	for i := range 1000 {
+		require.True(t, filter.Contains(fmt.Sprintf("added-%d", i)))
+	}
+
+	falsePositives := 0
+	for i := range 10000 {
+		if filter.Contains(fmt.Sprintf("missing-%d", i)) {
+			falsePositives++
+		}
+	}
+
+	// The filter is sized for 1% of false positives, the margin keeps the test stable.
+	assert.Less(t, falsePositives, 300)
+}
+
+func TestLoadBreachedPasswords(t *testing.T) {
+	t.Run("It should load bundled passwords", func(t *testing.T) {
+		filter, err := passpolicy.LoadBreachedPasswords("")
+		require.NoError(t, err)
+
+		assert.True(t, filter.Contains("password123"))
+		assert.False(t, filter.Contains("an unusual passphrase of a user"))
+	})
+
+	t.Run("It should load passwords from file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "breached.txt")
+		require.NoError(t, os.WriteFile(path, []byte("first-password\r\n\n  second-password  \n"), 0o600))
+
+		filter, err := passpolicy.LoadBreachedPasswords(path)
+		require.NoError(t, err)
+
+		assert.True(t, filter.Contains("first-password"))
+		assert.True(t, filter.Contains("second-password"))
+		assert.False(t, filter.Contains("password123"))
+	})
+
+	t.Run("It should fail for missing file", func(t *testing.T) {
+		_, err := passpolicy.LoadBreachedPasswords(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+
+	t.Run("It should read passwords without trailing newline", func(t *testing.T) {
+		filter, err := passpolicy.ReadBreachedPasswords(strings.NewReader("only-password"))
+		require.NoError(t, err)
+
+		assert.True(t, filter.Contains("only-password"))
+	})
+}
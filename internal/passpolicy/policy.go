@@ -0,0 +1,129 @@
+package passpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+// minPersonalInfoLength is the length of the shortest part of the email or name that passwords can't contain,
+// shorter parts would reject too many passwords by chance.
+const minPersonalInfoLength = 3
+
+// Rules are the rules every new password must follow. Lengths are in characters.
+type Rules struct {
+	MinLength int
+	MaxLength int
+
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+
+	// DisallowPersonalInfo rejects passwords which contain the name of the user or the local part of the email.
+	DisallowPersonalInfo bool
+}
+
+// Policy checks new passwords against the rules and a list of breached passwords.
+type Policy struct {
+	rules    Rules
+	breached *BloomFilter
+}
+
+// NewPolicy creates the policy. Passwords aren't checked against breached passwords when the filter is nil.
+func NewPolicy(rules Rules, breached *BloomFilter) (*Policy, error) {
+	if rules.MinLength < 1 {
+		return nil, errors.New("min password length must be positive")
+	}
+
+	if rules.MaxLength < rules.MinLength {
+		return nil, errors.New("max password length must not be less than min password length")
+	}
+
+	return &Policy{rules: rules, breached: breached}, nil
+}
+
+// Check checks the new password of the user with the email and name.
+// It returns [*models.PasswordPolicyError] which explains every rule the password breaks.
+func (p *Policy) Check(password, email, name string) error {
+	var violations []string
+
+	length := utf8.RuneCountInString(password)
+	if length < p.rules.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", p.rules.MinLength))
+	}
+
+	if length > p.rules.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters long", p.rules.MaxLength))
+	}
+
+	var hasLowercase, hasUppercase, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLowercase = true
+		case unicode.IsUpper(r):
+			hasUppercase = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.rules.RequireLowercase && !hasLowercase {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+
+	if p.rules.RequireUppercase && !hasUppercase {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+
+	if p.rules.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+
+	if p.rules.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if p.rules.DisallowPersonalInfo && containsPersonalInfo(password, email, name) {
+		violations = append(violations, "must not contain your email or name")
+	}
+
+	if p.breached != nil && (p.breached.Contains(password) || p.breached.Contains(strings.ToLower(password))) {
+		violations = append(violations, "is too common, it has appeared in a data breach")
+	}
+
+	if len(violations) > 0 {
+		return &models.PasswordPolicyError{Violations: violations}
+	}
+
+	return nil
+}
+
+func containsPersonalInfo(password, email, name string) bool {
+	localPart, _, _ := strings.Cut(email, "@")
+
+	// The parts are compared separately as well, e.g. "john.doe" is split into "john" and "doe".
+	parts := []string{localPart}
+	parts = append(parts, strings.FieldsFunc(localPart, isSeparator)...)
+	parts = append(parts, strings.FieldsFunc(name, isSeparator)...)
+
+	password = strings.ToLower(password)
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(password, strings.ToLower(part)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
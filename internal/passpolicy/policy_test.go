@@ -0,0 +1,106 @@
+package passpolicy_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/passpolicy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicy(t *testing.T) {
+	testCases := map[string]passpolicy.Rules{
+		"It should reject zero min length":                 {MinLength: 0, MaxLength: 64},
+		"It should reject max length less than min length": {MinLength: 12, MaxLength: 8},
+	}
+
+	for testName, rules := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			_, err := passpolicy.NewPolicy(rules, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	breached, err := passpolicy.ReadBreachedPasswords(strings.NewReader("password123\ncorrecthorse\n"))
+	require.NoError(t, err)
+
+	rules := passpolicy.Rules{
+		MinLength:            8,
+		MaxLength:            16,
+		RequireLowercase:     true,
+		RequireUppercase:     true,
+		RequireDigit:         true,
+		RequireSymbol:        true,
+		DisallowPersonalInfo: true,
+	}
+
+	policy, err := passpolicy.NewPolicy(rules, breached)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		password       string
+		wantViolations []string
+	}{
+		"It should accept password following every rule": {
+			password: "Tr0ub4dor&3",
+		},
+		"It should count length in characters": {
+			password: "Ünïcödé-1",
+		},
+		"It should reject short password": {
+			password:       "Ab1!",
+			wantViolations: []string{"must be at least 8 characters long"},
+		},
+		"It should reject long password": {
+			password:       "Tr0ub4dor&3Tr0ub4dor&3",
+			wantViolations: []string{"must be at most 16 characters long"},
+		},
+		"It should reject password without required character classes": {
+			password: "troubadour",
+			wantViolations: []string{
+				"must contain an uppercase letter",
+				"must contain a digit",
+				"must contain a symbol",
+			},
+		},
+		"It should reject password containing email": {
+			password:       "Jdoe-2024!",
+			wantViolations: []string{"must not contain your email or name"},
+		},
+		"It should reject password containing part of name": {
+			password:       "Smith#2024x",
+			wantViolations: []string{"must not contain your email or name"},
+		},
+		"It should reject breached password regardless of case": {
+			password:       "CorrectHorse",
+			wantViolations: []string{"must contain a digit", "must contain a symbol", "is too common, it has appeared in a data breach"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			err := policy.Check(testCase.password, "jdoe@example.com", "Anna Smith")
+			if testCase.wantViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var policyErr *models.PasswordPolicyError
+			require.ErrorAs(t, err, &policyErr)
+			assert.ErrorIs(t, err, models.ErrWeakPassword)
+			assert.Equal(t, testCase.wantViolations, policyErr.Violations)
+		})
+	}
+}
+
+func TestPolicy_Check_WithoutBreachedPasswords(t *testing.T) {
+	policy, err := passpolicy.NewPolicy(passpolicy.Rules{MinLength: 8, MaxLength: 64}, nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.Check("password123", "jdoe@example.com", "jdoe"))
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) Create(ctx context.Context, apiKey *models.APIKey) error {
+	if err := r.db.WithContext(ctx).Create(apiKey).Error; err != nil {
+		return fmt.Errorf("execute insert api key query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, keyHash string) (models.APIKey, error) {
+	var apiKey models.APIKey
+	err := r.db.WithContext(ctx).Where("key_hash = ?", keyHash).Take(&apiKey).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.APIKey{}, errors.Join(models.ErrAPIKeyNotFound, err)
+	} else if err != nil {
+		return models.APIKey{}, fmt.Errorf("execute select api key by hash query: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// GetByUser returns keys of the user which are not revoked.
+func (r *APIKeyRepository) GetByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	var apiKeys []models.APIKey
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("id").
+		Find(&apiKeys).
+		Error
+	if err != nil {
+		return nil, fmt.Errorf("execute select api keys by user query: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// Revoke revokes the key of the user. It returns [models.ErrAPIKeyNotFound] when the user has no such active key.
+func (r *APIKeyRepository) Revoke(ctx context.Context, userID, id uint, revokedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return fmt.Errorf("execute update api key revoked_at query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
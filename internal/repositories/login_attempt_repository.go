@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttemptRepository is a database backed store of failed login counters.
+// Unlike the in-memory store it is shared between all replicas of the service.
+type LoginAttemptRepository struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewLoginAttemptRepository(db *gorm.DB, now func() time.Time) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db, now: now}
+}
+
+// Get returns recent failures of the key. It returns a zero attempt when there are none.
+func (r *LoginAttemptRepository) Get(ctx context.Context, key string) (models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := r.db.WithContext(ctx).Where("attempt_key = ? AND expires_at >= ?", key, r.now()).Take(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.LoginAttempt{}, nil
+	} else if err != nil {
+		return models.LoginAttempt{}, fmt.Errorf("execute select login attempt query: %w", err)
+	}
+
+	return attempt, nil
+}
+
+// AddFailure counts a failure of the key, keeps the failures until expiresAt and returns how many there are.
+// The counter is updated under a row lock, so concurrent failures on different replicas are all counted.
+// It prunes attempts that have already expired.
+func (r *LoginAttemptRepository) AddFailure(ctx context.Context, key string, expiresAt time.Time) (int, error) {
+	now := r.now()
+
+	err := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		return 0, fmt.Errorf("execute delete expired login attempts query: %w", err)
+	}
+
+	var failures int
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		newAttempt := &models.LoginAttempt{Key: key, ExpiresAt: expiresAt}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(newAttempt).Error; err != nil {
+			return fmt.Errorf("execute insert login attempt query: %w", err)
+		}
+
+		var attempt models.LoginAttempt
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("attempt_key = ?", key).
+			Take(&attempt).Error
+		if err != nil {
+			return fmt.Errorf("execute select login attempt for update query: %w", err)
+		}
+
+		failures = attempt.Failures + 1
+
+		err = tx.Model(&attempt).Updates(map[string]any{
+			"failures":   failures,
+			"expires_at": gorm.Expr("GREATEST(expires_at, ?)", expiresAt),
+		}).Error
+		if err != nil {
+			return fmt.Errorf("execute update login attempt failures query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("add login failure in transaction: %w", err)
+	}
+
+	return failures, nil
+}
+
+// Lock locks the key until lockedUntil. Failures are kept at least until the lock ends.
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, lockedUntil time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.LoginAttempt{}).
+		Where("attempt_key = ?", key).
+		Updates(map[string]any{
+			"locked_until": lockedUntil,
+			"expires_at":   gorm.Expr("GREATEST(expires_at, ?)", lockedUntil),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("execute update login attempt locked_until query: %w", err)
+	}
+
+	return nil
+}
+
+// Reset forgets failures of the key.
+func (r *LoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	err := r.db.WithContext(ctx).Where("attempt_key = ?", key).Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		return fmt.Errorf("execute delete login attempt query: %w", err)
+	}
+
+	return nil
+}
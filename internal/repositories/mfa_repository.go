@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MFARepository struct {
+	db *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) *MFARepository {
+	return &MFARepository{db: db}
+}
+
+// Enroll stores a new unconfirmed TOTP secret of the user and replaces the recovery codes.
+// A pending enrollment is replaced as well. It returns [models.ErrMFAAlreadyEnabled]
+// when the user has a confirmed secret.
+func (r *MFARepository) Enroll(ctx context.Context, totpSecret *models.TOTPSecret, recoveryCodes []models.RecoveryCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.TOTPSecret
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("user_id = ?", totpSecret.UserID).
+			Take(&existing).Error
+		switch {
+		case err == nil && existing.ConfirmedAt != nil:
+			return models.ErrMFAAlreadyEnabled
+		case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+			return fmt.Errorf("execute select totp secret for update query: %w", err)
+		}
+
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "last_used_step", "confirmed_at", "updated_at"}),
+		}).Create(totpSecret).Error
+		if err != nil {
+			return fmt.Errorf("execute upsert totp secret query: %w", err)
+		}
+
+		if err := tx.Where("user_id = ?", totpSecret.UserID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return fmt.Errorf("execute delete recovery codes query: %w", err)
+		}
+
+		if err := tx.Create(&recoveryCodes).Error; err != nil {
+			return fmt.Errorf("execute insert recovery codes query: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetTOTPSecret returns the TOTP secret of the user. It returns [models.ErrMFANotEnrolled] when there is none.
+func (r *MFARepository) GetTOTPSecret(ctx context.Context, userID uint) (models.TOTPSecret, error) {
+	var totpSecret models.TOTPSecret
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Take(&totpSecret).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.TOTPSecret{}, errors.Join(models.ErrMFANotEnrolled, err)
+	} else if err != nil {
+		return models.TOTPSecret{}, fmt.Errorf("execute select totp secret query: %w", err)
+	}
+
+	return totpSecret, nil
+}
+
+// ConfirmTOTPSecret enables MFA for the user with the time step of the code the secret was confirmed with.
+// It returns [models.ErrMFANotEnrolled] when there is no pending enrollment.
+func (r *MFARepository) ConfirmTOTPSecret(ctx context.Context, userID uint, step int64, confirmedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.TOTPSecret{}).
+		Where("user_id = ? AND confirmed_at IS NULL", userID).
+		Updates(map[string]any{"last_used_step": step, "confirmed_at": confirmedAt})
+	if result.Error != nil {
+		return fmt.Errorf("execute update totp secret confirmed_at query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrMFANotEnrolled
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the time step of an accepted code.
+// It returns [models.ErrInvalidMFACode] when a code of the same or a later step has been used already.
+func (r *MFARepository) UseTOTPStep(ctx context.Context, userID uint, step int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.TOTPSecret{}).
+		Where("user_id = ? AND confirmed_at IS NOT NULL AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return fmt.Errorf("execute update totp secret last_used_step query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: code already used", models.ErrInvalidMFACode)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks the recovery code of the user as used.
+// It returns [models.ErrInvalidMFACode] when the code is unknown or has been used already.
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return fmt.Errorf("execute update recovery code used_at query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: unknown recovery code", models.ErrInvalidMFACode)
+	}
+
+	return nil
+}
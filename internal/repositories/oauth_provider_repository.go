@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OAuthProviderRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthProviderRepository(db *gorm.DB) *OAuthProviderRepository {
+	return &OAuthProviderRepository{db: db}
+}
+
+func (r *OAuthProviderRepository) Create(ctx context.Context, oAuthProvider *models.OAuthProviders) error {
+	if err := r.db.WithContext(ctx).Create(oAuthProvider).Error; err != nil {
+		return fmt.Errorf("execute insert oauth provider query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OAuthProviderRepository) GetBySubject(
+	ctx context.Context,
+	provider models.Providers,
+	subject string,
+) (models.OAuthProviders, error) {
+	var oAuthProvider models.OAuthProviders
+	err := r.db.WithContext(ctx).Where("provider = ? AND subject = ?", provider, subject).Take(&oAuthProvider).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.OAuthProviders{}, errors.Join(models.ErrOAuthIdentityNotFound, err)
+	} else if err != nil {
+		return models.OAuthProviders{}, fmt.Errorf("execute select oauth provider by subject query: %w", err)
+	}
+
+	return oAuthProvider, nil
+}
+
+func (r *OAuthProviderRepository) GetByUser(ctx context.Context, userID uint) ([]models.OAuthProviders, error) {
+	var oAuthProviders []models.OAuthProviders
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&oAuthProviders).Error
+	if err != nil {
+		return nil, fmt.Errorf("execute select oauth providers by user query: %w", err)
+	}
+
+	return oAuthProviders, nil
+}
+
+// AdoptLegacy replaces the placeholder subject of a legacy identity of the user at the provider
+// with the subject and ID token the provider has just issued.
+//
+// It returns [models.ErrOAuthIdentityNotFound] when the user has no legacy identity at the provider.
+func (r *OAuthProviderRepository) AdoptLegacy(
+	ctx context.Context,
+	userID uint,
+	provider models.Providers,
+	subject string,
+	token string,
+) (models.OAuthProviders, error) {
+	var oAuthProvider models.OAuthProviders
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("user_id = ? AND provider = ? AND subject LIKE ?", userID, provider, models.LegacyOAuthSubjectPrefix+"%").
+			Order("id").
+			Take(&oAuthProvider).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Join(models.ErrOAuthIdentityNotFound, err)
+		} else if err != nil {
+			return fmt.Errorf("execute select legacy oauth provider query: %w", err)
+		}
+
+		oAuthProvider.Subject = subject
+		oAuthProvider.Token = token
+
+		err = tx.Model(&oAuthProvider).Select("subject", "token").Updates(&oAuthProvider).Error
+		if err != nil {
+			return fmt.Errorf("execute update oauth provider subject query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.OAuthProviders{}, err
+	}
+
+	return oAuthProvider, nil
+}
+
+// Delete deletes the identity of the user permanently, so that it can be linked again.
+//
+// It returns [models.ErrOAuthIdentityNotFound] when the user has no such identity,
+// and [models.ErrLastLoginMethod] when the user has no password and no other identity to log in with.
+// Legacy identities don't count, as they can only be adopted while the email at the provider still matches.
+func (r *OAuthProviderRepository) Delete(ctx context.Context, userID, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The user row is locked, so that concurrent requests can't delete the last two identities at once.
+		var user models.User
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).Where("id = ?", userID).Take(&user).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Join(models.ErrUserNotFound, err)
+		} else if err != nil {
+			return fmt.Errorf("execute select user for update query: %w", err)
+		}
+
+		var otherIdentities int64
+		err = tx.Model(&models.OAuthProviders{}).
+			Where("user_id = ? AND id <> ? AND subject NOT LIKE ?", userID, id, models.LegacyOAuthSubjectPrefix+"%").
+			Count(&otherIdentities).Error
+		if err != nil {
+			return fmt.Errorf("execute count oauth providers query: %w", err)
+		}
+
+		result := tx.Unscoped().
+			Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userID).
+			Delete(&models.OAuthProviders{})
+		if result.Error != nil {
+			return fmt.Errorf("execute delete oauth provider query: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return models.ErrOAuthIdentityNotFound
+		}
+
+		if user.Password == "" && otherIdentities == 0 {
+			return models.ErrLastLoginMethod
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OAuthStateRepository is a database backed store of authorization code flow states.
+// Unlike the in-memory store it is shared between all replicas of the service,
+// so the provider may redirect the user back to any of them.
+type OAuthStateRepository struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewOAuthStateRepository(db *gorm.DB, now func() time.Time) *OAuthStateRepository {
+	return &OAuthStateRepository{db: db, now: now}
+}
+
+// Save stores the flow state and prunes states that have already expired.
+func (r *OAuthStateRepository) Save(ctx context.Context, state string, oAuthState models.OAuthState) error {
+	oAuthState.State = state
+	if err := r.db.WithContext(ctx).Create(&oAuthState).Error; err != nil {
+		return fmt.Errorf("execute insert oauth state query: %w", err)
+	}
+
+	err := r.db.WithContext(ctx).Where("expires_at < ?", r.now()).Delete(&models.OAuthState{}).Error
+	if err != nil {
+		return fmt.Errorf("execute delete expired oauth states query: %w", err)
+	}
+
+	return nil
+}
+
+// Take removes the flow state so that it can be used only once, even by concurrent callbacks on different replicas.
+// It returns [models.ErrInvalidOAuthState] when the state is unknown or expired.
+func (r *OAuthStateRepository) Take(ctx context.Context, state string) (models.OAuthState, error) {
+	var oAuthState models.OAuthState
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("state = ?", state).
+			Take(&oAuthState).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ErrInvalidOAuthState
+		} else if err != nil {
+			return fmt.Errorf("execute select oauth state for update query: %w", err)
+		}
+
+		if err := tx.Delete(&oAuthState).Error; err != nil {
+			return fmt.Errorf("execute delete oauth state query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.OAuthState{}, fmt.Errorf("take oauth state in transaction: %w", err)
+	}
+
+	if oAuthState.ExpiresAt.Before(r.now()) {
+		return models.OAuthState{}, models.ErrInvalidOAuthState
+	}
+
+	return oAuthState, nil
+}
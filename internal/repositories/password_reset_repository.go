@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PasswordResetRepository struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewPasswordResetRepository(db *gorm.DB, now func() time.Time) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db, now: now}
+}
+
+// Create stores the password reset and prunes password resets that have already expired.
+func (r *PasswordResetRepository) Create(ctx context.Context, passwordReset *models.PasswordReset) error {
+	if err := r.db.WithContext(ctx).Create(passwordReset).Error; err != nil {
+		return fmt.Errorf("execute insert password reset query: %w", err)
+	}
+
+	err := r.db.WithContext(ctx).Where("expires_at < ?", r.now()).Delete(&models.PasswordReset{}).Error
+	if err != nil {
+		return fmt.Errorf("execute delete expired password resets query: %w", err)
+	}
+
+	return nil
+}
+
+// CountCreatedSince returns how many password resets have been requested for the user since the time.
+func (r *PasswordResetRepository) CountCreatedSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.PasswordReset{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("execute count password resets query: %w", err)
+	}
+
+	return count, nil
+}
+
+// Get returns the password reset with the token hash without consuming it.
+// It returns [models.ErrInvalidResetToken] when the token is unknown, already used or expired.
+func (r *PasswordResetRepository) Get(ctx context.Context, tokenHash string) (models.PasswordReset, error) {
+	var passwordReset models.PasswordReset
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&passwordReset).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.PasswordReset{}, errors.Join(models.ErrInvalidResetToken, err)
+	} else if err != nil {
+		return models.PasswordReset{}, fmt.Errorf("execute select password reset query: %w", err)
+	}
+
+	if !r.now().Before(passwordReset.ExpiresAt) {
+		return models.PasswordReset{}, fmt.Errorf("%w: expired", models.ErrInvalidResetToken)
+	}
+
+	return passwordReset, nil
+}
+
+// Consume returns the password reset with the token hash and deletes all password resets of its user,
+// so that neither this token nor tokens requested earlier can be used again.
+// It returns [models.ErrInvalidResetToken] when the token is unknown, already used or expired.
+func (r *PasswordResetRepository) Consume(ctx context.Context, tokenHash string) (models.PasswordReset, error) {
+	var passwordReset models.PasswordReset
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("token_hash = ?", tokenHash).
+			Take(&passwordReset).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.Join(models.ErrInvalidResetToken, err)
+		} else if err != nil {
+			return fmt.Errorf("execute select password reset for update query: %w", err)
+		}
+
+		err = tx.Where("user_id = ?", passwordReset.UserID).Delete(&models.PasswordReset{}).Error
+		if err != nil {
+			return fmt.Errorf("execute delete password resets query: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.PasswordReset{}, err
+	}
+
+	if !r.now().Before(passwordReset.ExpiresAt) {
+		return models.PasswordReset{}, fmt.Errorf("%w: expired", models.ErrInvalidResetToken)
+	}
+
+	return passwordReset, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 
 	"gorm.io/gorm"
@@ -26,9 +27,59 @@ func (r *PostRepository) Create(ctx context.Context, post *models.Post) error {
 	return nil
 }
 
-func (r *PostRepository) GetPosts(ctx context.Context) ([]models.Post, error) {
+// postSortColumns maps sort fields to columns, so that only known columns get into queries.
+var postSortColumns = map[domain.PostSortField]string{
+	domain.PostSortCreatedAt: "created_at",
+	domain.PostSortUpdatedAt: "updated_at",
+	domain.PostSortTitle:     "title",
+}
+
+// GetPosts selects a page of posts along with their authors. Posts with equal sort values are ordered by ID,
+// so the cursor always points at a single post.
+func (r *PostRepository) GetPosts(ctx context.Context, query domain.PostsQuery) ([]models.Post, error) {
+	column, ok := postSortColumns[query.Sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field %q", query.Sort.Field)
+	}
+
+	db := r.db.WithContext(ctx).Preload("User")
+
+	if query.Filter.AuthorID != nil {
+		db = db.Where("user_id = ?", *query.Filter.AuthorID)
+	}
+
+	if query.Filter.CreatedFrom != nil {
+		db = db.Where("created_at >= ?", *query.Filter.CreatedFrom)
+	}
+
+	if query.Filter.CreatedTo != nil {
+		db = db.Where("created_at <= ?", *query.Filter.CreatedTo)
+	}
+
+	direction, comparison := "ASC", ">"
+	if query.Sort.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if query.After != nil {
+		var value any = query.After.Time
+		if query.Sort.Field == domain.PostSortTitle {
+			value = query.After.Title
+		}
+
+		db = db.Where(
+			fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))", column, comparison),
+			value, value, query.After.ID,
+		)
+	}
+
 	var posts []models.Post
-	if err := r.db.WithContext(ctx).Find(&posts).Error; err != nil {
+	err := db.
+		Order(fmt.Sprintf("%[1]s %[2]s, id %[2]s", column, direction)).
+		Limit(query.Limit).
+		Find(&posts).
+		Error
+	if err != nil {
 		return nil, fmt.Errorf("execute select posts query: %w", err)
 	}
 
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+func (r *RefreshTokenRepository) Create(ctx context.Context, refreshToken *models.RefreshToken) error {
+	if err := r.db.WithContext(ctx).Create(refreshToken).Error; err != nil {
+		return fmt.Errorf("execute insert refresh token query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	var refreshToken models.RefreshToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).Take(&refreshToken).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.RefreshToken{}, errors.Join(models.ErrRefreshTokenNotFound, err)
+	} else if err != nil {
+		return models.RefreshToken{}, fmt.Errorf("execute select refresh token by hash query: %w", err)
+	}
+
+	return refreshToken, nil
+}
+
+// MarkUsed marks the refresh token as used. It returns [models.ErrRefreshTokenReused] when the token
+// has already been used or revoked, which also covers two concurrent rotations of the same token.
+func (r *RefreshTokenRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return fmt.Errorf("execute update refresh token used_at query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update refresh token family revoked_at query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeUserFamily(ctx context.Context, userID uint, familyID string, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND family_id = ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update user refresh token family revoked_at query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update user refresh tokens revoked_at query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUserExcept(ctx context.Context, userID uint, familyID string, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, familyID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update other user refresh tokens revoked_at query: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedAccessTokenRepository is a database backed access token denylist.
+// Unlike the in-memory denylist it is shared between all replicas of the service.
+type RevokedAccessTokenRepository struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewRevokedAccessTokenRepository(db *gorm.DB, now func() time.Time) *RevokedAccessTokenRepository {
+	return &RevokedAccessTokenRepository{db: db, now: now}
+}
+
+// Revoke adds the token to the denylist and prunes entries of tokens that have already expired.
+func (r *RevokedAccessTokenRepository) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	revokedAccessToken := &models.RevokedAccessToken{
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+	}
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(revokedAccessToken).Error
+	if err != nil {
+		return fmt.Errorf("execute insert revoked access token query: %w", err)
+	}
+
+	err = r.db.WithContext(ctx).Where("expires_at < ?", r.now()).Delete(&models.RevokedAccessToken{}).Error
+	if err != nil {
+		return fmt.Errorf("execute delete expired revoked access tokens query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RevokedAccessTokenRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.RevokedAccessToken{}).
+		Where("token_id = ? AND expires_at >= ?", tokenID, r.now()).
+		Count(&count).
+		Error
+	if err != nil {
+		return false, fmt.Errorf("execute select revoked access token query: %w", err)
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	if err := r.db.WithContext(ctx).Create(session).Error; err != nil {
+		return fmt.Errorf("execute insert session query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (models.Session, error) {
+	var session models.Session
+	err := r.db.WithContext(ctx).Where("id = ?", id).Take(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Session{}, errors.Join(models.ErrSessionNotFound, err)
+	} else if err != nil {
+		return models.Session{}, fmt.Errorf("execute select session by id query: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetActiveByUser returns sessions of the user which are neither revoked nor expired, most recently used first.
+func (r *SessionRepository) GetActiveByUser(ctx context.Context, userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("execute select active sessions query: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Touch records the use of the session, which lasts until the new expiration time.
+func (r *SessionRepository) Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"last_used_at": lastUsedAt, "expires_at": expiresAt}).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update session last_used_at query: %w", err)
+	}
+
+	return nil
+}
+
+// Revoke revokes the session of the user.
+// It returns [models.ErrSessionNotFound] when the user has no such session or it has been revoked already.
+func (r *SessionRepository) Revoke(ctx context.Context, userID uint, id string, revokedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return fmt.Errorf("execute update session revoked_at query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update user sessions revoked_at query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) RevokeByUserExcept(ctx context.Context, userID uint, id string, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, id).
+		Update("revoked_at", revokedAt).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update other user sessions revoked_at query: %w", err)
+	}
+
+	return nil
+}
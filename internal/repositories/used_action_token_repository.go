@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UsedActionTokenRepository struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewUsedActionTokenRepository(db *gorm.DB, now func() time.Time) *UsedActionTokenRepository {
+	return &UsedActionTokenRepository{db: db, now: now}
+}
+
+// MarkUsed marks the token as used and prunes entries of tokens that have already expired.
+// It returns [models.ErrInvalidActionToken] when the token has been used already.
+func (r *UsedActionTokenRepository) MarkUsed(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	usedActionToken := &models.UsedActionToken{
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+	}
+
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(usedActionToken)
+	if result.Error != nil {
+		return fmt.Errorf("execute insert used action token query: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: already used", models.ErrInvalidActionToken)
+	}
+
+	err := r.db.WithContext(ctx).Where("expires_at < ?", r.now()).Delete(&models.UsedActionToken{}).Error
+	if err != nil {
+		return fmt.Errorf("execute delete expired used action tokens query: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 
@@ -50,6 +51,38 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (mode
 	return user, nil
 }
 
+func (r *UserRepository) MarkVerified(ctx context.Context, id uint, verifiedAt time.Time) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("verified_at", verifiedAt).Error
+	if err != nil {
+		return fmt.Errorf("execute update user verified_at query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("password", passwordHash).Error
+	if err != nil {
+		return fmt.Errorf("execute update user password query: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEmail replaces the email of the user with a verified one.
+func (r *UserRepository) UpdateEmail(ctx context.Context, id uint, email string, verifiedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"email": email, "verified_at": verifiedAt}).
+		Error
+	if err != nil {
+		return fmt.Errorf("execute update user email query: %w", err)
+	}
+
+	return nil
+}
+
 func (r *UserRepository) CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oAuthProvider *models.OAuthProviders) error {
 	tx := r.db.Begin()
 
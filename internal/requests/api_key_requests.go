@@ -0,0 +1,26 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const maxAPIKeyNameLength = 100
+
+type CreateAPIKeyRequest struct {
+	Name   string        `json:"name" validate:"required" example:"CI"`
+	Scopes models.Scopes `json:"scopes" example:"posts:read"`
+
+	// ExpiresAt is optional, keys without it never expire.
+	ExpiresAt *time.Time `json:"expiresAt" example:"2030-01-01T00:00:00Z"`
+}
+
+func (r CreateAPIKeyRequest) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Name, validation.Required, validation.Length(0, maxAPIKeyNameLength)),
+		validation.Field(&r.ExpiresAt, validation.Min(time.Now())),
+	)
+}
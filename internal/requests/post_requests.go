@@ -1,6 +1,13 @@
 package requests
 
-import validation "github.com/go-ozzo/ozzo-validation/v4"
+import (
+	"errors"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
 
 type BasicPost struct {
 	Title   string `json:"title" validate:"required" example:"Echo"`
@@ -21,3 +28,38 @@ type CreatePostRequest struct {
 type UpdatePostRequest struct {
 	BasicPost
 }
+
+type GetPostsRequest struct {
+	// Limit is the page size, the server returns at most 100 posts regardless of it.
+	Limit  int    `query:"limit" example:"20"`
+	Cursor string `query:"cursor"`
+
+	// Sort is one of "created_at", "updated_at", "title" and Order is "asc" or "desc".
+	// Posts are sorted by creation time, newest first, by default.
+	Sort  domain.PostSortField `query:"sort" example:"created_at"`
+	Order string               `query:"order" example:"desc"`
+
+	// Author is the ID of the user who wrote the posts.
+	Author *uint `query:"author" example:"1"`
+
+	// CreatedFrom and CreatedTo bound the creation time in RFC 3339 format, both bounds are inclusive.
+	CreatedFrom *time.Time `query:"created_from" example:"2025-01-01T00:00:00Z"`
+	CreatedTo   *time.Time `query:"created_to" example:"2025-12-31T23:59:59Z"`
+}
+
+func (gpr GetPostsRequest) Validate() error {
+	err := validation.ValidateStruct(&gpr,
+		validation.Field(&gpr.Limit, validation.Min(0)),
+		validation.Field(&gpr.Sort, validation.In(domain.PostSortCreatedAt, domain.PostSortUpdatedAt, domain.PostSortTitle)),
+		validation.Field(&gpr.Order, validation.In("asc", "desc")),
+	)
+	if err != nil {
+		return err
+	}
+
+	if gpr.CreatedFrom != nil && gpr.CreatedTo != nil && gpr.CreatedTo.Before(*gpr.CreatedFrom) {
+		return errors.New("created_to must not be before created_from")
+	}
+
+	return nil
+}
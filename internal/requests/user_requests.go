@@ -1,28 +1,29 @@
 package requests
 
 import (
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
-const (
-	minPathLength = 8
-)
-
 type BasicAuth struct {
 	Email    string `json:"email" validate:"required" example:"john.doe@example.com"`
-	Password string `json:"password" validate:"required" example:"11111111"`
+	Password string `json:"password" validate:"required" example:"amber-canyon-42"`
 }
 
 func (ba BasicAuth) Validate() error {
 	return validation.ValidateStruct(&ba,
 		validation.Field(&ba.Email, is.Email),
-		validation.Field(&ba.Password, validation.Length(minPathLength, 0)),
+		validation.Field(&ba.Password, validation.Required),
 	)
 }
 
 type LoginRequest struct {
 	BasicAuth
+
+	// Scopes is an optional subset of scopes to grant, all scopes are granted by default.
+	Scopes models.Scopes `json:"scopes" example:"posts:read"`
 }
 
 type RegisterRequest struct {
@@ -53,4 +54,148 @@ func (oar OAuthRequest) Validate() error {
 
 type RefreshRequest struct {
 	Token string `json:"token" validate:"required" example:"refresh_token"`
+
+	// Scopes is an optional subset of the session scopes to grant to the access token.
+	Scopes models.Scopes `json:"scopes" example:"posts:read"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required" example:"verification_token"`
+}
+
+func (ver VerifyEmailRequest) Validate() error {
+	return validation.ValidateStruct(&ver,
+		validation.Field(&ver.Token, validation.Required),
+	)
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required" example:"john.doe@example.com"`
+}
+
+func (rvr ResendVerificationRequest) Validate() error {
+	return validation.ValidateStruct(&rvr,
+		validation.Field(&rvr.Email, validation.Required, is.EmailFormat),
+	)
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required" example:"john.doe@example.com"`
+}
+
+func (fpr ForgotPasswordRequest) Validate() error {
+	return validation.ValidateStruct(&fpr,
+		validation.Field(&fpr.Email, validation.Required, is.EmailFormat),
+	)
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required" example:"reset_token"`
+	Password string `json:"password" validate:"required" example:"amber-canyon-42"`
+}
+
+func (rpr ResetPasswordRequest) Validate() error {
+	return validation.ValidateStruct(&rpr,
+		validation.Field(&rpr.Token, validation.Required),
+		validation.Field(&rpr.Password, validation.Required),
+	)
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required" example:"amber-canyon-42"`
+	Password        string `json:"password" validate:"required" example:"velvet-harbor-17"`
+}
+
+func (cpr ChangePasswordRequest) Validate() error {
+	return validation.ValidateStruct(&cpr,
+		validation.Field(&cpr.CurrentPassword, validation.Required),
+		validation.Field(&cpr.Password, validation.Required),
+	)
+}
+
+// ChangeEmailRequest carries the new email and the current password of the user.
+type ChangeEmailRequest struct {
+	BasicAuth
+}
+
+func (cer ChangeEmailRequest) Validate() error {
+	if err := cer.BasicAuth.Validate(); err != nil {
+		return err
+	}
+
+	return validation.ValidateStruct(&cer,
+		validation.Field(&cer.Email, validation.Required),
+		validation.Field(&cer.Password, validation.Required),
+	)
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required" example:"change_token"`
+}
+
+func (cecr ConfirmEmailChangeRequest) Validate() error {
+	return validation.ValidateStruct(&cecr,
+		validation.Field(&cecr.Token, validation.Required),
+	)
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required" example:"mfa_token"`
+
+	// Code is either a code from the authenticator app or a recovery code.
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+func (mlr MFALoginRequest) Validate() error {
+	return validation.ValidateStruct(&mlr,
+		validation.Field(&mlr.MFAToken, validation.Required),
+		validation.Field(&mlr.Code, validation.Required),
+	)
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+func (mcr MFACodeRequest) Validate() error {
+	return validation.ValidateStruct(&mcr,
+		validation.Field(&mcr.Code, validation.Required),
+	)
+}
+
+type MagicLinkRequest struct {
+	Email string `json:"email" validate:"required" example:"john.doe@example.com"`
+}
+
+func (mlr MagicLinkRequest) Validate() error {
+	return validation.ValidateStruct(&mlr,
+		validation.Field(&mlr.Email, validation.Required, is.EmailFormat),
+	)
+}
+
+type MagicLinkLoginRequest struct {
+	Token string `json:"token" validate:"required" example:"magic_link_token"`
+
+	// Scopes is an optional subset of scopes to grant, all scopes are granted by default.
+	Scopes models.Scopes `json:"scopes" example:"posts:read"`
+}
+
+func (mllr MagicLinkLoginRequest) Validate() error {
+	return validation.ValidateStruct(&mllr,
+		validation.Field(&mllr.Token, validation.Required),
+	)
+}
+
+// TokenRequest is a token sent by another service to be introspected or revoked, as defined by RFC 7662 and RFC 7009.
+type TokenRequest struct {
+	Token string `form:"token" validate:"required" example:"access_token"`
+
+	// TokenTypeHint is an optional type of the token: "access_token" or "refresh_token".
+	TokenTypeHint string `form:"token_type_hint" example:"access_token"`
+}
+
+func (tr TokenRequest) Validate() error {
+	return validation.ValidateStruct(&tr,
+		validation.Field(&tr.Token, validation.Required),
+	)
 }
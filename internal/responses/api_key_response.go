@@ -0,0 +1,49 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+type APIKeyResponse struct {
+	ID        uint          `json:"id" example:"1"`
+	Name      string        `json:"name" example:"CI"`
+	Prefix    string        `json:"prefix" example:"ak_ABCDEFGH"`
+	Scopes    models.Scopes `json:"scopes"`
+	ExpiresAt *time.Time    `json:"expiresAt"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// CreatedAPIKeyResponse contains the key itself, which is shown only once.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func NewAPIKeyResponse(apiKey models.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:        apiKey.ID,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    apiKey.Scopes,
+		ExpiresAt: apiKey.ExpiresAt,
+		CreatedAt: apiKey.CreatedAt,
+	}
+}
+
+func NewAPIKeysResponse(apiKeys []models.APIKey) []APIKeyResponse {
+	apiKeysResponse := make([]APIKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		apiKeysResponse = append(apiKeysResponse, NewAPIKeyResponse(apiKey))
+	}
+
+	return apiKeysResponse
+}
+
+func NewCreatedAPIKeyResponse(key string, apiKey models.APIKey) CreatedAPIKeyResponse {
+	return CreatedAPIKeyResponse{
+		APIKeyResponse: NewAPIKeyResponse(apiKey),
+		Key:            key,
+	}
+}
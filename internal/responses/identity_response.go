@@ -0,0 +1,30 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+type IdentityResponse struct {
+	ID        uint             `json:"id" example:"1"`
+	Provider  models.Providers `json:"provider" example:"google"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+func NewIdentityResponse(oAuthProvider models.OAuthProviders) IdentityResponse {
+	return IdentityResponse{
+		ID:        oAuthProvider.ID,
+		Provider:  oAuthProvider.Provider,
+		CreatedAt: oAuthProvider.CreatedAt,
+	}
+}
+
+func NewIdentitiesResponse(oAuthProviders []models.OAuthProviders) []IdentityResponse {
+	identitiesResponse := make([]IdentityResponse, 0, len(oAuthProviders))
+	for _, oAuthProvider := range oAuthProviders {
+		identitiesResponse = append(identitiesResponse, NewIdentityResponse(oAuthProvider))
+	}
+
+	return identitiesResponse
+}
@@ -0,0 +1,22 @@
+package responses
+
+// IntrospectionResponse describes a token as defined by RFC 7662, so its fields are named as in the RFC.
+// Only Active is set for tokens which are invalid, expired or revoked.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Subject   string `json:"sub,omitempty" example:"1"`
+	Scope     string `json:"scope,omitempty" example:"posts:read posts:write"`
+	Exp       int64  `json:"exp,omitempty"`
+	TokenType string `json:"token_type,omitempty" example:"access_token"`
+
+	// Actor is set for tokens of admins impersonating the user.
+	Actor *IntrospectionActor `json:"act,omitempty"`
+}
+
+type IntrospectionActor struct {
+	Subject string `json:"sub" example:"2"`
+}
+
+func NewInactiveIntrospectionResponse() *IntrospectionResponse {
+	return &IntrospectionResponse{Active: false}
+}
@@ -1,9 +1,12 @@
 package responses
 
 type LoginResponse struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refreshToken"`
-	Exp          int64  `json:"exp"`
+	AccessToken  string `json:"accessToken,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	Exp          int64  `json:"exp,omitempty"`
+
+	// MFAToken is returned instead of the other tokens when the user has to pass the second factor.
+	MFAToken string `json:"mfaToken,omitempty"`
 }
 
 func NewLoginResponse(token, refreshToken string, exp int64) *LoginResponse {
@@ -13,3 +16,12 @@ func NewLoginResponse(token, refreshToken string, exp int64) *LoginResponse {
 		Exp:          exp,
 	}
 }
+
+// NewCookieLoginResponse returns only the expiration of the access token, the tokens themselves are set as cookies.
+func NewCookieLoginResponse(exp int64) *LoginResponse {
+	return &LoginResponse{Exp: exp}
+}
+
+func NewMFAChallengeResponse(mfaToken string) *LoginResponse {
+	return &LoginResponse{MFAToken: mfaToken}
+}
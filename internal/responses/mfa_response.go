@@ -0,0 +1,19 @@
+package responses
+
+type TOTPEnrollmentResponse struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+
+	// URI is the otpauth URI to render as a QR code for authenticator apps.
+	URI string `json:"uri" example:"otpauth://totp/Echo:user@example.com?issuer=Echo&secret=JBSWY3DPEHPK3PXP"`
+
+	// RecoveryCodes are single-use codes which replace the authenticator app when it's lost.
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func NewTOTPEnrollmentResponse(secret, uri string, recoveryCodes []string) TOTPEnrollmentResponse {
+	return TOTPEnrollmentResponse{
+		Secret:        secret,
+		URI:           uri,
+		RecoveryCodes: recoveryCodes,
+	}
+}
@@ -25,3 +25,17 @@ func NewPostResponse(posts []models.Post) *[]PostResponse {
 
 	return &postResponse
 }
+
+// PostsPageResponse is a page of posts. NextCursor is passed as the cursor to get the next page,
+// it is omitted on the last page.
+type PostsPageResponse struct {
+	Posts      []PostResponse `json:"posts"`
+	NextCursor string         `json:"next_cursor,omitempty" example:"eyJmIjoiY3JlYXRlZF9hdCIsImlkIjoxfQ"`
+}
+
+func NewPostsPageResponse(posts []models.Post, nextCursor string) *PostsPageResponse {
+	return &PostsPageResponse{
+		Posts:      *NewPostResponse(posts),
+		NextCursor: nextCursor,
+	}
+}
@@ -3,6 +3,9 @@ package responses
 type ErrorResponse struct {
 	Code  int    `json:"code"`
 	Error string `json:"error"`
+
+	// Fields explain why each invalid field of the request is invalid.
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 type MessageResponse struct {
@@ -19,3 +22,11 @@ func NewErrorResponse(message string, code int) ErrorResponse {
 		Error: message,
 	}
 }
+
+func NewFieldsErrorResponse(message string, code int, fields map[string]string) ErrorResponse {
+	return ErrorResponse{
+		Code:   code,
+		Error:  message,
+		Fields: fields,
+	}
+}
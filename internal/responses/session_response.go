@@ -0,0 +1,40 @@
+package responses
+
+import (
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+type SessionResponse struct {
+	ID         string    `json:"id" example:"0b7f6d2e-5c1a-4d8e-9f3b-2a6c8e4d1f07"`
+	UserAgent  string    `json:"userAgent" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IPAddress  string    `json:"ipAddress" example:"192.0.2.1"`
+	Provider   string    `json:"provider" example:"password"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+
+	// Current marks the session the request is made from.
+	Current bool `json:"current"`
+}
+
+func NewSessionResponse(session models.Session, currentSessionID string) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		Provider:   session.Provider,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		Current:    session.ID == currentSessionID,
+	}
+}
+
+func NewSessionsResponse(sessions []models.Session, currentSessionID string) []SessionResponse {
+	sessionsResponse := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		sessionsResponse = append(sessionsResponse, NewSessionResponse(session, currentSessionID))
+	}
+
+	return sessionsResponse
+}
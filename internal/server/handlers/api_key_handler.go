@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=api_key_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type apiKeyService interface {
+	Create(
+		ctx context.Context,
+		userID uint,
+		allowed models.Scopes,
+		request *requests.CreateAPIKeyRequest,
+	) (string, models.APIKey, error)
+	GetByUser(ctx context.Context, userID uint) ([]models.APIKey, error)
+	Revoke(ctx context.Context, userID, id uint) error
+}
+
+type APIKeyHandler struct {
+	apiKeyService apiKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService apiKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create API key
+//	@Description	Create an API key for machine clients. The key is returned only once
+//	@ID				api-keys-create
+//	@Tags			API Keys Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.CreateAPIKeyRequest	true	"API key name, scopes and optional expiry"
+//	@Success		201		{object}	responses.CreatedAPIKeyResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		401		{object}	responses.ErrorResponse
+//	@Failure		403		{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	var request requests.CreateAPIKeyRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or not valid", http.StatusBadRequest))
+	}
+
+	key, apiKey, err := h.apiKeyService.Create(c.Request().Context(), claims.ID, claims.Scopes(), &request)
+	switch {
+	case errors.Is(err, models.ErrInvalidScope):
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid scope", http.StatusBadRequest))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusCreated, responses.NewCreatedAPIKeyResponse(key, apiKey))
+}
+
+// GetAPIKeys godoc
+//
+//	@Summary		Get API keys
+//	@Description	Get the list of active API keys of the user
+//	@ID				api-keys-get
+//	@Tags			API Keys Actions
+//	@Produce		json
+//	@Success		200	{array}		responses.APIKeyResponse
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	apiKeys, err := h.apiKeyService.GetByUser(c.Request().Context(), claims.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewAPIKeysResponse(apiKeys))
+}
+
+// RevokeAPIKey godoc
+//
+//	@Summary		Revoke API key
+//	@Description	Revoke an API key of the user
+//	@ID				api-keys-revoke
+//	@Tags			API Keys Actions
+//	@Param			id	path	int	true	"API key ID"
+//	@Success		204	"No Content"
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	parsedID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to parse api key id: "+err.Error(), http.StatusBadRequest))
+	}
+
+	id, err := safecast.Convert[uint](parsedID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to parse api key id: "+err.Error(), http.StatusBadRequest))
+	}
+
+	err = h.apiKeyService.Revoke(c.Request().Context(), claims.ID, id)
+	switch {
+	case errors.Is(err, models.ErrAPIKeyNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("API key not found", http.StatusNotFound))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: api_key_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=api_key_handler.go -destination=api_key_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	requests "github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockapiKeyService is a mock of apiKeyService interface.
+type MockapiKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockapiKeyServiceMockRecorder is the mock recorder for MockapiKeyService.
+type MockapiKeyServiceMockRecorder struct {
+	mock *MockapiKeyService
+}
+
+// NewMockapiKeyService creates a new mock instance.
+func NewMockapiKeyService(ctrl *gomock.Controller) *MockapiKeyService {
+	mock := &MockapiKeyService{ctrl: ctrl}
+	mock.recorder = &MockapiKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyService) EXPECT() *MockapiKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockapiKeyService) Create(ctx context.Context, userID uint, allowed models.Scopes, request *requests.CreateAPIKeyRequest) (string, models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, allowed, request)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(models.APIKey)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockapiKeyServiceMockRecorder) Create(ctx, userID, allowed, request any) *MockapiKeyServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockapiKeyService)(nil).Create), ctx, userID, allowed, request)
+	return &MockapiKeyServiceCreateCall{Call: call}
+}
+
+// MockapiKeyServiceCreateCall wrap *gomock.Call
+type MockapiKeyServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockapiKeyServiceCreateCall) Return(arg0 string, arg1 models.APIKey, arg2 error) *MockapiKeyServiceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockapiKeyServiceCreateCall) Do(f func(context.Context, uint, models.Scopes, *requests.CreateAPIKeyRequest) (string, models.APIKey, error)) *MockapiKeyServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockapiKeyServiceCreateCall) DoAndReturn(f func(context.Context, uint, models.Scopes, *requests.CreateAPIKeyRequest) (string, models.APIKey, error)) *MockapiKeyServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByUser mocks base method.
+func (m *MockapiKeyService) GetByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockapiKeyServiceMockRecorder) GetByUser(ctx, userID any) *MockapiKeyServiceGetByUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockapiKeyService)(nil).GetByUser), ctx, userID)
+	return &MockapiKeyServiceGetByUserCall{Call: call}
+}
+
+// MockapiKeyServiceGetByUserCall wrap *gomock.Call
+type MockapiKeyServiceGetByUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockapiKeyServiceGetByUserCall) Return(arg0 []models.APIKey, arg1 error) *MockapiKeyServiceGetByUserCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockapiKeyServiceGetByUserCall) Do(f func(context.Context, uint) ([]models.APIKey, error)) *MockapiKeyServiceGetByUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockapiKeyServiceGetByUserCall) DoAndReturn(f func(context.Context, uint) ([]models.APIKey, error)) *MockapiKeyServiceGetByUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockapiKeyService) Revoke(ctx context.Context, userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockapiKeyServiceMockRecorder) Revoke(ctx, userID, id any) *MockapiKeyServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockapiKeyService)(nil).Revoke), ctx, userID, id)
+	return &MockapiKeyServiceRevokeCall{Call: call}
+}
+
+// MockapiKeyServiceRevokeCall wrap *gomock.Call
+type MockapiKeyServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockapiKeyServiceRevokeCall) Return(arg0 error) *MockapiKeyServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockapiKeyServiceRevokeCall) Do(f func(context.Context, uint, uint) error) *MockapiKeyServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockapiKeyServiceRevokeCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockapiKeyServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
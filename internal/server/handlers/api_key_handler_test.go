@@ -0,0 +1,226 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newAPIKeyHandler(t *testing.T) (*handlers.APIKeyHandler, *MockapiKeyService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	apiKeyService := NewMockapiKeyService(ctrl)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+
+	return apiKeyHandler, apiKeyService
+}
+
+func TestAPIKeyHandler_CreateAPIKey(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1, Scope: "posts:read account"}}
+	allowedScopes := models.Scopes{models.ScopePostsRead, models.ScopeAccount}
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	apiKey := models.APIKey{
+		Model:  gorm.Model{ID: 10, CreatedAt: createdAt},
+		UserID: 1,
+		Name:   "CI",
+		Prefix: "ak_ABCDEFGH",
+		Scopes: models.Scopes{models.ScopePostsRead},
+	}
+
+	testCases := map[string]struct {
+		setExpectations func(apiKeyService *MockapiKeyService)
+		request         requests.CreateAPIKeyRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when name is empty": {
+			setExpectations: func(*MockapiKeyService) {},
+			request:         requests.CreateAPIKeyRequest{},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or not valid",
+			},
+		},
+		"It should respond with a 400 status code when scope is not allowed": {
+			setExpectations: func(apiKeyService *MockapiKeyService) {
+				apiKeyService.
+					EXPECT().
+					Create(gomock.Any(), uint(1), allowedScopes, &requests.CreateAPIKeyRequest{Name: "CI"}).
+					Return("", models.APIKey{}, models.ErrInvalidScope)
+			},
+			request:    requests.CreateAPIKeyRequest{Name: "CI"},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Invalid scope",
+			},
+		},
+		"It should respond with a 500 status code when failed to create api key": {
+			setExpectations: func(apiKeyService *MockapiKeyService) {
+				apiKeyService.
+					EXPECT().
+					Create(gomock.Any(), uint(1), allowedScopes, &requests.CreateAPIKeyRequest{Name: "CI"}).
+					Return("", models.APIKey{}, errors.New("error from api key service"))
+			},
+			request:    requests.CreateAPIKeyRequest{Name: "CI"},
+			wantStatus: http.StatusInternalServerError,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusInternalServerError,
+				Error: "Internal Server Error",
+			},
+		},
+		"It should create api key": {
+			setExpectations: func(apiKeyService *MockapiKeyService) {
+				apiKeyService.
+					EXPECT().
+					Create(gomock.Any(), uint(1), allowedScopes, &requests.CreateAPIKeyRequest{Name: "CI"}).
+					Return("ak_ABCDEFGHIJKLMNOPQRSTUVWXYZ", apiKey, nil)
+			},
+			request:    requests.CreateAPIKeyRequest{Name: "CI"},
+			wantStatus: http.StatusCreated,
+			wantResponse: responses.CreatedAPIKeyResponse{
+				APIKeyResponse: responses.APIKeyResponse{
+					ID:        10,
+					Name:      "CI",
+					Prefix:    "ak_ABCDEFGH",
+					Scopes:    models.Scopes{models.ScopePostsRead},
+					CreatedAt: createdAt,
+				},
+				Key: "ak_ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			apiKeyHandler, apiKeyService := newAPIKeyHandler(t)
+
+			testCase.setExpectations(apiKeyService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/me/api-keys", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("user", authClaims)
+
+			err = apiKeyHandler.CreateAPIKey(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestAPIKeyHandler_GetAPIKeys(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	apiKeys := []models.APIKey{{
+		Model:  gorm.Model{ID: 10},
+		UserID: 1,
+		Name:   "CI",
+		Prefix: "ak_ABCDEFGH",
+		Scopes: models.Scopes{models.ScopePostsRead},
+	}}
+
+	apiKeyHandler, apiKeyService := newAPIKeyHandler(t)
+
+	apiKeyService.
+		EXPECT().
+		GetByUser(gomock.Any(), uint(1)).
+		Return(apiKeys, nil)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me/api-keys", http.NoBody)
+
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+	c.Set("user", authClaims)
+
+	err := apiKeyHandler.GetAPIKeys(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+	wantResponse, err := json.Marshal(responses.NewAPIKeysResponse(apiKeys))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	testCases := map[string]struct {
+		setExpectations func(apiKeyService *MockapiKeyService)
+		wantStatus      int
+	}{
+		"It should respond with a 404 status code when api key not found": {
+			setExpectations: func(apiKeyService *MockapiKeyService) {
+				apiKeyService.
+					EXPECT().
+					Revoke(gomock.Any(), uint(1), uint(10)).
+					Return(models.ErrAPIKeyNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		"It should revoke api key": {
+			setExpectations: func(apiKeyService *MockapiKeyService) {
+				apiKeyService.
+					EXPECT().
+					Revoke(gomock.Any(), uint(1), uint(10)).
+					Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			apiKeyHandler, apiKeyService := newAPIKeyHandler(t)
+
+			testCase.setExpectations(apiKeyService)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodDelete, "/me/api-keys/10", http.NoBody)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.SetPath("/me/api-keys/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("10")
+			c.Set("user", authClaims)
+
+			err := apiKeyHandler.RevokeAPIKey(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+		})
+	}
+}
@@ -3,11 +3,15 @@ package handlers
 import (
 	"context"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
 	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 
 	"github.com/labstack/echo/v4"
 )
@@ -15,29 +19,44 @@ import (
 //go:generate go tool mockgen -source=$GOFILE -destination=auth_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
 
 type authService interface {
-	GenerateToken(ctx context.Context, request *requests.LoginRequest) (*responses.LoginResponse, error)
+	GenerateToken(ctx context.Context, request *requests.LoginRequest, client models.Client) (*responses.LoginResponse, error)
+	CompleteMFALogin(
+		ctx context.Context,
+		request *requests.MFALoginRequest,
+		client models.Client,
+	) (*responses.LoginResponse, error)
 	RefreshToken(ctx context.Context, request *requests.RefreshRequest) (*responses.LoginResponse, error)
+	Logout(ctx context.Context, claims *token.JwtCustomClaims) error
+	LogoutAll(ctx context.Context, claims *token.JwtCustomClaims) error
 }
 
 type AuthHandler struct {
 	authService authService
+	cookies     *authcookie.Cookies
 }
 
-func NewAuthHandler(authService authService) *AuthHandler {
-	return &AuthHandler{authService: authService}
+// NewAuthHandler creates the handler. The cookies keep the tokens of browser clients which ask for them.
+func NewAuthHandler(authService authService, cookies *authcookie.Cookies) *AuthHandler {
+	return &AuthHandler{authService: authService, cookies: cookies}
 }
 
 // Login godoc
 //
 //	@Summary		Authenticate a user
-//	@Description	Perform user login
+//	@Description	Perform user login. When MFA is enabled, only mfaToken is returned and the login is completed at /login/mfa.
+//	@Description	With "X-Auth-Mode: cookie" the tokens are set as HttpOnly cookies along with a CSRF token cookie
 //	@ID				user-login
 //	@Tags			User Actions
 //	@Accept			json
 //	@Produce		json
-//	@Param			params	body		requests.LoginRequest	true	"User's credentials"
+//	@Param			X-Auth-Mode	header		string					false	"Set to cookie to get the tokens as cookies"
+//	@Param			params		body		requests.LoginRequest	true	"User's credentials"
 //	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.ErrorResponse
 //	@Failure		401		{object}	responses.ErrorResponse
+//	@Failure		403		{object}	responses.ErrorResponse
+//	@Failure		429		{object}	responses.ErrorResponse
+//	@Header			429		{integer}	Retry-After	"Seconds until login is unlocked"
 //	@Router			/login [post]
 func (h *AuthHandler) Login(c echo.Context) error {
 	var request requests.LoginRequest
@@ -49,27 +68,79 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or not valid", http.StatusBadRequest))
 	}
 
-	response, err := h.authService.GenerateToken(c.Request().Context(), &request)
+	response, err := h.authService.GenerateToken(c.Request().Context(), &request, newClient(c))
 	switch {
+	case errors.Is(err, models.ErrLoginLocked):
+		return loginLockedResponse(c, err)
+	case errors.Is(err, models.ErrInvalidScope):
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid scope", http.StatusBadRequest))
 	case errors.Is(err, models.ErrUserNotFound), errors.Is(err, models.ErrInvalidPassword):
 		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Invalid credentials", http.StatusUnauthorized))
+	case errors.Is(err, models.ErrEmailNotVerified):
+		return c.JSON(http.StatusForbidden, responses.NewErrorResponse("Email is not verified", http.StatusForbidden))
 	case err != nil:
 		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
 	}
 
-	return c.JSON(http.StatusOK, response)
+	return loginResponse(c, h.cookies, response)
+}
+
+// LoginMFA godoc
+//
+//	@Summary		Complete login with MFA
+//	@Description	Exchange the MFA token from /login and a code from the authenticator app or a recovery code for tokens.
+//	@Description	The MFA token is single-use, so the user has to log in again after an invalid code
+//	@ID				user-login-mfa
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Auth-Mode	header		string						false	"Set to cookie to get the tokens as cookies"
+//	@Param			params		body		requests.MFALoginRequest	true	"MFA token and code"
+//	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		401		{object}	responses.ErrorResponse
+//	@Failure		429		{object}	responses.ErrorResponse
+//	@Header			429		{integer}	Retry-After	"Seconds until login is unlocked"
+//	@Router			/login/mfa [post]
+func (h *AuthHandler) LoginMFA(c echo.Context) error {
+	var request requests.MFALoginRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or not valid", http.StatusBadRequest))
+	}
+
+	response, err := h.authService.CompleteMFALogin(c.Request().Context(), &request, newClient(c))
+	switch {
+	case errors.Is(err, models.ErrLoginLocked):
+		return loginLockedResponse(c, err)
+	case errors.Is(err, models.ErrInvalidActionToken):
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Invalid or expired MFA token", http.StatusUnauthorized))
+	case errors.Is(err, models.ErrInvalidMFACode):
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Invalid code", http.StatusUnauthorized))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return loginResponse(c, h.cookies, response)
 }
 
 // RefreshToken godoc
 //
 //	@Summary		Refresh access token
-//	@Description	Perform refresh access token
+//	@Description	Perform refresh access token. With "X-Auth-Mode: cookie" the refresh token is read from the cookie
+//	@Description	when the body has none, and the new tokens are set as cookies. Cookie requests require the CSRF token
 //	@ID				user-refresh
 //	@Tags			User Actions
 //	@Accept			json
 //	@Produce		json
-//	@Param			params	body		requests.RefreshRequest	true	"Refresh token"
+//	@Param			X-Auth-Mode		header		string					false	"Set to cookie to use the token cookies"
+//	@Param			X-CSRF-Token	header		string					false	"Value of the csrf_token cookie, required with cookies"
+//	@Param			params			body		requests.RefreshRequest	true	"Refresh token"
 //	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.ErrorResponse
 //	@Failure		401		{object}	responses.ErrorResponse
 //	@Router			/refresh [post]
 func (h *AuthHandler) RefreshToken(c echo.Context) error {
@@ -78,13 +149,102 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
 	}
 
+	if request.Token == "" && h.cookies.Requested(c.Request()) {
+		if cookie, err := c.Cookie(authcookie.RefreshTokenName); err == nil {
+			request.Token = cookie.Value
+		}
+	}
+
 	response, err := h.authService.RefreshToken(c.Request().Context(), &request)
 	switch {
+	case errors.Is(err, models.ErrInvalidScope):
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid scope", http.StatusBadRequest))
+	case errors.Is(err, models.ErrRefreshTokenReused):
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Refresh token has already been used", http.StatusUnauthorized))
 	case errors.Is(err, models.ErrUserNotFound), errors.Is(err, models.ErrInvalidAuthToken):
 		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
 	case err != nil:
 		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
 	}
 
-	return c.JSON(http.StatusOK, response)
+	return loginResponse(c, h.cookies, response)
+}
+
+// Logout godoc
+//
+//	@Summary		Log out
+//	@Description	Revoke the current session and its access token
+//	@ID				user-logout
+//	@Tags			User Actions
+//	@Success		204	"No Content"
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/logout [post]
+func (h *AuthHandler) Logout(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	if err := h.authService.Logout(c.Request().Context(), claims); err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	if authcookie.HasTokens(c.Request()) {
+		h.cookies.Clear(c.Response())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+//
+//	@Summary		Log out everywhere
+//	@Description	Revoke every session of the user and the current access token
+//	@ID				user-logout-all
+//	@Tags			User Actions
+//	@Success		204	"No Content"
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Failure		403	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/logout-all [post]
+func (h *AuthHandler) LogoutAll(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	if err := h.authService.LogoutAll(c.Request().Context(), claims); err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	if authcookie.HasTokens(c.Request()) {
+		h.cookies.Clear(c.Response())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// loginResponse responds with the tokens, which are set as cookies instead when the client asks for them.
+// The MFA challenge is always returned in the body, the tokens come once it is completed.
+func loginResponse(c echo.Context, cookies *authcookie.Cookies, response *responses.LoginResponse) error {
+	if response.MFAToken != "" || !cookies.Requested(c.Request()) {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	cookies.Set(c.Response(), response.AccessToken, response.Exp, response.RefreshToken)
+
+	return c.JSON(http.StatusOK, responses.NewCookieLoginResponse(response.Exp))
+}
+
+func loginLockedResponse(c echo.Context, err error) error {
+	var lockedErr *models.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		retryAfter := int(math.Ceil(lockedErr.RetryAfter.Seconds()))
+		c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+
+	errorResponse := responses.NewErrorResponse("Too many failed login attempts", http.StatusTooManyRequests)
+
+	return c.JSON(http.StatusTooManyRequests, errorResponse)
 }
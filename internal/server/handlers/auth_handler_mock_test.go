@@ -13,8 +13,10 @@ import (
 	context "context"
 	reflect "reflect"
 
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
 	requests "github.com/nix-united/golang-echo-boilerplate/internal/requests"
 	responses "github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	token "github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,19 +44,58 @@ func (m *MockauthService) EXPECT() *MockauthServiceMockRecorder {
 	return m.recorder
 }
 
+// CompleteMFALogin mocks base method.
+func (m *MockauthService) CompleteMFALogin(ctx context.Context, request *requests.MFALoginRequest, client models.Client) (*responses.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMFALogin", ctx, request, client)
+	ret0, _ := ret[0].(*responses.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMFALogin indicates an expected call of CompleteMFALogin.
+func (mr *MockauthServiceMockRecorder) CompleteMFALogin(ctx, request, client any) *MockauthServiceCompleteMFALoginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMFALogin", reflect.TypeOf((*MockauthService)(nil).CompleteMFALogin), ctx, request, client)
+	return &MockauthServiceCompleteMFALoginCall{Call: call}
+}
+
+// MockauthServiceCompleteMFALoginCall wrap *gomock.Call
+type MockauthServiceCompleteMFALoginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockauthServiceCompleteMFALoginCall) Return(arg0 *responses.LoginResponse, arg1 error) *MockauthServiceCompleteMFALoginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockauthServiceCompleteMFALoginCall) Do(f func(context.Context, *requests.MFALoginRequest, models.Client) (*responses.LoginResponse, error)) *MockauthServiceCompleteMFALoginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockauthServiceCompleteMFALoginCall) DoAndReturn(f func(context.Context, *requests.MFALoginRequest, models.Client) (*responses.LoginResponse, error)) *MockauthServiceCompleteMFALoginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GenerateToken mocks base method.
-func (m *MockauthService) GenerateToken(ctx context.Context, request *requests.LoginRequest) (*responses.LoginResponse, error) {
+func (m *MockauthService) GenerateToken(ctx context.Context, request *requests.LoginRequest, client models.Client) (*responses.LoginResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", ctx, request)
+	ret := m.ctrl.Call(m, "GenerateToken", ctx, request, client)
 	ret0, _ := ret[0].(*responses.LoginResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockauthServiceMockRecorder) GenerateToken(ctx, request any) *MockauthServiceGenerateTokenCall {
+func (mr *MockauthServiceMockRecorder) GenerateToken(ctx, request, client any) *MockauthServiceGenerateTokenCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockauthService)(nil).GenerateToken), ctx, request)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockauthService)(nil).GenerateToken), ctx, request, client)
 	return &MockauthServiceGenerateTokenCall{Call: call}
 }
 
@@ -70,13 +111,89 @@ func (c *MockauthServiceGenerateTokenCall) Return(arg0 *responses.LoginResponse,
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockauthServiceGenerateTokenCall) Do(f func(context.Context, *requests.LoginRequest) (*responses.LoginResponse, error)) *MockauthServiceGenerateTokenCall {
+func (c *MockauthServiceGenerateTokenCall) Do(f func(context.Context, *requests.LoginRequest, models.Client) (*responses.LoginResponse, error)) *MockauthServiceGenerateTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockauthServiceGenerateTokenCall) DoAndReturn(f func(context.Context, *requests.LoginRequest, models.Client) (*responses.LoginResponse, error)) *MockauthServiceGenerateTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Logout mocks base method.
+func (m *MockauthService) Logout(ctx context.Context, claims *token.JwtCustomClaims) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockauthServiceMockRecorder) Logout(ctx, claims any) *MockauthServiceLogoutCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockauthService)(nil).Logout), ctx, claims)
+	return &MockauthServiceLogoutCall{Call: call}
+}
+
+// MockauthServiceLogoutCall wrap *gomock.Call
+type MockauthServiceLogoutCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockauthServiceLogoutCall) Return(arg0 error) *MockauthServiceLogoutCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockauthServiceLogoutCall) Do(f func(context.Context, *token.JwtCustomClaims) error) *MockauthServiceLogoutCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockauthServiceLogoutCall) DoAndReturn(f func(context.Context, *token.JwtCustomClaims) error) *MockauthServiceLogoutCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LogoutAll mocks base method.
+func (m *MockauthService) LogoutAll(ctx context.Context, claims *token.JwtCustomClaims) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", ctx, claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockauthServiceMockRecorder) LogoutAll(ctx, claims any) *MockauthServiceLogoutAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockauthService)(nil).LogoutAll), ctx, claims)
+	return &MockauthServiceLogoutAllCall{Call: call}
+}
+
+// MockauthServiceLogoutAllCall wrap *gomock.Call
+type MockauthServiceLogoutAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockauthServiceLogoutAllCall) Return(arg0 error) *MockauthServiceLogoutAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockauthServiceLogoutAllCall) Do(f func(context.Context, *token.JwtCustomClaims) error) *MockauthServiceLogoutAllCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockauthServiceGenerateTokenCall) DoAndReturn(f func(context.Context, *requests.LoginRequest) (*responses.LoginResponse, error)) *MockauthServiceGenerateTokenCall {
+func (c *MockauthServiceLogoutAllCall) DoAndReturn(f func(context.Context, *token.JwtCustomClaims) error) *MockauthServiceLogoutAllCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -3,27 +3,45 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
 	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
 	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	gomock "go.uber.org/mock/gomock"
 )
 
+// client is the client of the test requests, httptest sets the remote address to 192.0.2.1.
+var client = models.Client{IPAddress: "192.0.2.1", UserAgent: "Mozilla/5.0"}
+
+func newAuthCookies() *authcookie.Cookies {
+	config := authcookie.Config{
+		Enabled:              true,
+		SameSite:             http.SameSiteStrictMode,
+		RefreshTokenDuration: time.Hour,
+	}
+
+	return authcookie.New(config, func() time.Time { return time.Unix(0, 0) })
+}
+
 func newAuthHandler(t *testing.T) (*handlers.AuthHandler, *MockauthService) {
 	t.Helper()
 
 	ctrl := gomock.NewController(t)
 	authService := NewMockauthService(ctrl)
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(authService, newAuthCookies())
 
 	return authHandler, authService
 }
@@ -64,11 +82,25 @@ func TestAuthHandler_Login(t *testing.T) {
 				Error: "Required fields are empty or not valid",
 			},
 		},
+		"It should return 429 status code when login is locked": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					GenerateToken(gomock.Any(), request, client).
+					Return(nil, &models.LoginLockedError{RetryAfter: time.Minute})
+			},
+			request:    request,
+			wantStatus: http.StatusTooManyRequests,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusTooManyRequests,
+				Error: "Too many failed login attempts",
+			},
+		},
 		"It should return 401 status code when user does not exist": {
 			setExpectations: func(authService *MockauthService) {
 				authService.
 					EXPECT().
-					GenerateToken(gomock.Any(), request).
+					GenerateToken(gomock.Any(), request, client).
 					Return(nil, models.ErrUserNotFound)
 			},
 			request:    request,
@@ -82,7 +114,7 @@ func TestAuthHandler_Login(t *testing.T) {
 			setExpectations: func(authService *MockauthService) {
 				authService.
 					EXPECT().
-					GenerateToken(gomock.Any(), request).
+					GenerateToken(gomock.Any(), request, client).
 					Return(nil, models.ErrInvalidPassword)
 			},
 			request:    request,
@@ -92,11 +124,25 @@ func TestAuthHandler_Login(t *testing.T) {
 				Error: "Invalid credentials",
 			},
 		},
+		"It should return 403 status code when email is not verified": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					GenerateToken(gomock.Any(), request, client).
+					Return(nil, models.ErrEmailNotVerified)
+			},
+			request:    request,
+			wantStatus: http.StatusForbidden,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusForbidden,
+				Error: "Email is not verified",
+			},
+		},
 		"It should authorize user": {
 			setExpectations: func(authService *MockauthService) {
 				authService.
 					EXPECT().
-					GenerateToken(gomock.Any(), request).
+					GenerateToken(gomock.Any(), request, client).
 					Return(response, nil)
 			},
 			request:      request,
@@ -121,6 +167,7 @@ func TestAuthHandler_Login(t *testing.T) {
 				bytes.NewBuffer(rawRequest),
 			)
 			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			request.Header.Set("User-Agent", client.UserAgent)
 
 			recorder := httptest.NewRecorder()
 			c := echo.New().NewContext(request, recorder)
@@ -170,6 +217,19 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 				Error: "Unauthorized",
 			},
 		},
+		"It should respond with a 400 status code when scope is invalid": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					RefreshToken(gomock.Any(), request).
+					Return(nil, models.ErrInvalidScope)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Invalid scope",
+			},
+		},
 		"It should respond with a 401 status code when token is invalid": {
 			setExpectations: func(authService *MockauthService) {
 				authService.
@@ -183,6 +243,19 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 				Error: "Unauthorized",
 			},
 		},
+		"It should respond with a 401 status code when refresh token has already been used": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					RefreshToken(gomock.Any(), request).
+					Return(nil, models.ErrRefreshTokenReused)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusUnauthorized,
+				Error: "Refresh token has already been used",
+			},
+		},
 		"It should refresh token": {
 			setExpectations: func(authService *MockauthService) {
 				authService.
@@ -228,3 +301,326 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_LoginMFA(t *testing.T) {
+	request := &requests.MFALoginRequest{
+		MFAToken: "mfa-token",
+		Code:     "123456",
+	}
+
+	rawRequest, err := json.Marshal(request)
+	require.NoError(t, err)
+
+	response := &responses.LoginResponse{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		Exp:          123,
+	}
+
+	testCases := map[string]struct {
+		setExpectations func(authService *MockauthService)
+		wantStatus      int
+		wantRetryAfter  string
+		wantResponse    any
+	}{
+		"It should respond with a 401 status code when MFA token is invalid": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), request, client).
+					Return(nil, models.ErrInvalidActionToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusUnauthorized,
+				Error: "Invalid or expired MFA token",
+			},
+		},
+		"It should respond with a 401 status code when code is invalid": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), request, client).
+					Return(nil, models.ErrInvalidMFACode)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusUnauthorized,
+				Error: "Invalid code",
+			},
+		},
+		"It should respond with a 429 status code when login is locked": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), request, client).
+					Return(nil, &models.LoginLockedError{RetryAfter: 90 * time.Second})
+			},
+			wantStatus:     http.StatusTooManyRequests,
+			wantRetryAfter: "90",
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusTooManyRequests,
+				Error: "Too many failed login attempts",
+			},
+		},
+		"It should complete login": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					CompleteMFALogin(gomock.Any(), request, client).
+					Return(response, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: responses.LoginResponse{
+				AccessToken:  "access-token",
+				RefreshToken: "refresh-token",
+				Exp:          123,
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			authHandler, authService := newAuthHandler(t)
+
+			testCase.setExpectations(authService)
+
+			request := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodPost,
+				"/login/mfa",
+				bytes.NewBuffer(rawRequest),
+			)
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			request.Header.Set("User-Agent", client.UserAgent)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = authHandler.LoginMFA(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+			assert.Equal(t, testCase.wantRetryAfter, recorder.Header().Get("Retry-After"))
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	claims := &token.JwtCustomClaims{
+		ID:        1,
+		SessionID: "session-id",
+	}
+
+	testCases := map[string]struct {
+		setExpectations func(authService *MockauthService)
+		wantStatus      int
+	}{
+		"It should respond with a 500 status code when failed to logout": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					Logout(gomock.Any(), claims).
+					Return(errors.New("error from auth service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		"It should logout": {
+			setExpectations: func(authService *MockauthService) {
+				authService.
+					EXPECT().
+					Logout(gomock.Any(), claims).
+					Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			authHandler, authService := newAuthHandler(t)
+
+			testCase.setExpectations(authService)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/logout", http.NoBody)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("user", &jwt.Token{Claims: claims})
+
+			err := authHandler.Logout(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+		})
+	}
+}
+
+func TestAuthHandler_LogoutAll(t *testing.T) {
+	claims := &token.JwtCustomClaims{
+		ID:        1,
+		SessionID: "session-id",
+	}
+
+	authHandler, authService := newAuthHandler(t)
+
+	authService.
+		EXPECT().
+		LogoutAll(gomock.Any(), claims).
+		Return(nil)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/logout-all", http.NoBody)
+
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+	c.Set("user", &jwt.Token{Claims: claims})
+
+	err := authHandler.LogoutAll(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+}
+
+func TestAuthHandler_CookieMode(t *testing.T) {
+	response := &responses.LoginResponse{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		Exp:          123,
+	}
+
+	cookiesByName := func(recorder *httptest.ResponseRecorder) map[string]*http.Cookie {
+		cookies := make(map[string]*http.Cookie)
+		for _, cookie := range recorder.Result().Cookies() {
+			cookies[cookie.Name] = cookie
+		}
+
+		return cookies
+	}
+
+	t.Run("It should set tokens as cookies on login", func(t *testing.T) {
+		authHandler, authService := newAuthHandler(t)
+
+		loginRequest := &requests.LoginRequest{
+			BasicAuth: requests.BasicAuth{
+				Email:    "example@example.com",
+				Password: "some-pass",
+			},
+		}
+
+		authService.
+			EXPECT().
+			GenerateToken(gomock.Any(), loginRequest, gomock.Any()).
+			Return(response, nil)
+
+		rawRequest, err := json.Marshal(loginRequest)
+		require.NoError(t, err)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login", bytes.NewBuffer(rawRequest))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		request.Header.Set(authcookie.ModeHeader, authcookie.ModeCookie)
+
+		recorder := httptest.NewRecorder()
+		c := echo.New().NewContext(request, recorder)
+
+		err = authHandler.Login(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+		assert.JSONEq(t, `{"exp":123}`, recorder.Body.String())
+
+		cookies := cookiesByName(recorder)
+		require.Contains(t, cookies, authcookie.AccessTokenName)
+		require.Contains(t, cookies, authcookie.RefreshTokenName)
+		require.Contains(t, cookies, authcookie.CSRFTokenName)
+		assert.Equal(t, "access-token", cookies[authcookie.AccessTokenName].Value)
+		assert.Equal(t, "refresh-token", cookies[authcookie.RefreshTokenName].Value)
+	})
+
+	t.Run("It should return MFA token in the body", func(t *testing.T) {
+		authHandler, authService := newAuthHandler(t)
+
+		mfaRequest := &requests.LoginRequest{
+			BasicAuth: requests.BasicAuth{
+				Email:    "example@example.com",
+				Password: "some-pass",
+			},
+		}
+
+		authService.
+			EXPECT().
+			GenerateToken(gomock.Any(), mfaRequest, gomock.Any()).
+			Return(responses.NewMFAChallengeResponse("mfa-token"), nil)
+
+		rawRequest, err := json.Marshal(mfaRequest)
+		require.NoError(t, err)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login", bytes.NewBuffer(rawRequest))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		request.Header.Set(authcookie.ModeHeader, authcookie.ModeCookie)
+
+		recorder := httptest.NewRecorder()
+		c := echo.New().NewContext(request, recorder)
+
+		err = authHandler.Login(c)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"mfaToken":"mfa-token"}`, recorder.Body.String())
+		assert.Empty(t, recorder.Result().Cookies())
+	})
+
+	t.Run("It should refresh tokens from the cookie", func(t *testing.T) {
+		authHandler, authService := newAuthHandler(t)
+
+		authService.
+			EXPECT().
+			RefreshToken(gomock.Any(), &requests.RefreshRequest{Token: "old-refresh-token"}).
+			Return(response, nil)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/refresh", bytes.NewBufferString("{}"))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		request.Header.Set(authcookie.ModeHeader, authcookie.ModeCookie)
+		request.AddCookie(&http.Cookie{Name: authcookie.RefreshTokenName, Value: "old-refresh-token"})
+
+		recorder := httptest.NewRecorder()
+		c := echo.New().NewContext(request, recorder)
+
+		err := authHandler.RefreshToken(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+		assert.JSONEq(t, `{"exp":123}`, recorder.Body.String())
+		assert.Equal(t, "refresh-token", cookiesByName(recorder)[authcookie.RefreshTokenName].Value)
+	})
+
+	t.Run("It should clear cookies on logout", func(t *testing.T) {
+		authHandler, authService := newAuthHandler(t)
+
+		claims := &token.JwtCustomClaims{ID: 1, SessionID: "session-id"}
+
+		authService.
+			EXPECT().
+			Logout(gomock.Any(), claims).
+			Return(nil)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/logout", http.NoBody)
+		request.AddCookie(&http.Cookie{Name: authcookie.AccessTokenName, Value: "access-token"})
+
+		recorder := httptest.NewRecorder()
+		c := echo.New().NewContext(request, recorder)
+		c.Set("user", &jwt.Token{Claims: claims})
+
+		err := authHandler.Logout(c)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNoContent, recorder.Result().StatusCode)
+
+		cookies := cookiesByName(recorder)
+		require.Contains(t, cookies, authcookie.AccessTokenName)
+		assert.Negative(t, cookies[authcookie.AccessTokenName].MaxAge)
+	})
+}
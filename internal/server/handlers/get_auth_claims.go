@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -23,3 +25,18 @@ func getAuthClaims(c echo.Context) (*token.JwtCustomClaims, error) {
 
 	return claims, nil
 }
+
+func newActor(claims *token.JwtCustomClaims) domain.Actor {
+	return domain.Actor{
+		UserID: claims.ID,
+		Roles:  claims.Roles,
+	}
+}
+
+// newClient describes the client making the request, which is recorded with the session it logs in.
+func newClient(c echo.Context) models.Client {
+	return models.Client{
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}
+}
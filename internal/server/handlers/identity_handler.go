@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=identity_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type identityService interface {
+	LinkIdentity(ctx context.Context, userID uint, provider, idToken string) (models.OAuthProviders, error)
+	GetIdentities(ctx context.Context, userID uint) ([]models.OAuthProviders, error)
+	UnlinkIdentity(ctx context.Context, userID, id uint) error
+}
+
+type IdentityHandler struct {
+	identityService identityService
+}
+
+func NewIdentityHandler(identityService identityService) *IdentityHandler {
+	return &IdentityHandler{identityService: identityService}
+}
+
+// LinkIdentity godoc
+//
+//	@Summary		Link identity
+//	@Description	Link the identity from an ID token issued by a configured OpenID Connect provider to the user
+//	@ID				identities-link
+//	@Tags			Identities Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			provider	path		string					true	"Provider name"
+//	@Param			params		body		requests.OAuthRequest	true	"ID Token"
+//	@Success		201			{object}	responses.IdentityResponse
+//	@Failure		400			{object}	responses.ErrorResponse
+//	@Failure		401			{object}	responses.ErrorResponse
+//	@Failure		404			{object}	responses.ErrorResponse
+//	@Failure		409			{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/identities/{provider} [post]
+func (h *IdentityHandler) LinkIdentity(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	var request requests.OAuthRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	oAuthProvider, err := h.identityService.LinkIdentity(c.Request().Context(), claims.ID, c.Param("provider"), request.Token)
+	switch {
+	case errors.Is(err, models.ErrOAuthProviderNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("Unknown OAuth provider", http.StatusNotFound))
+	case errors.Is(err, models.ErrInvalidAuthToken):
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Invalid ID token", http.StatusUnauthorized))
+	case errors.Is(err, models.ErrOAuthIdentityLinked):
+		errorResponse := responses.NewErrorResponse("Identity is linked to another user", http.StatusConflict)
+		return c.JSON(http.StatusConflict, errorResponse)
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusCreated, responses.NewIdentityResponse(oAuthProvider))
+}
+
+// GetIdentities godoc
+//
+//	@Summary		Get identities
+//	@Description	Get the list of OpenID Connect provider identities linked to the user
+//	@ID				identities-get
+//	@Tags			Identities Actions
+//	@Produce		json
+//	@Success		200	{array}		responses.IdentityResponse
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/identities [get]
+func (h *IdentityHandler) GetIdentities(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	oAuthProviders, err := h.identityService.GetIdentities(c.Request().Context(), claims.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewIdentitiesResponse(oAuthProviders))
+}
+
+// UnlinkIdentity godoc
+//
+//	@Summary		Unlink identity
+//	@Description	Unlink an OpenID Connect provider identity from the user. The last login method can't be unlinked
+//	@ID				identities-unlink
+//	@Tags			Identities Actions
+//	@Param			id	path	int	true	"Identity ID"
+//	@Success		204	"No Content"
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		409	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/identities/{id} [delete]
+func (h *IdentityHandler) UnlinkIdentity(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	parsedID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to parse identity id: "+err.Error(), http.StatusBadRequest))
+	}
+
+	id, err := safecast.Convert[uint](parsedID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to parse identity id: "+err.Error(), http.StatusBadRequest))
+	}
+
+	err = h.identityService.UnlinkIdentity(c.Request().Context(), claims.ID, id)
+	switch {
+	case errors.Is(err, models.ErrOAuthIdentityNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("Identity not found", http.StatusNotFound))
+	case errors.Is(err, models.ErrLastLoginMethod):
+		errorResponse := responses.NewErrorResponse("The last login method can't be unlinked, set a password first", http.StatusConflict)
+		return c.JSON(http.StatusConflict, errorResponse)
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
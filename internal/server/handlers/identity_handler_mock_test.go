@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: identity_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=identity_handler.go -destination=identity_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockidentityService is a mock of identityService interface.
+type MockidentityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockidentityServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockidentityServiceMockRecorder is the mock recorder for MockidentityService.
+type MockidentityServiceMockRecorder struct {
+	mock *MockidentityService
+}
+
+// NewMockidentityService creates a new mock instance.
+func NewMockidentityService(ctrl *gomock.Controller) *MockidentityService {
+	mock := &MockidentityService{ctrl: ctrl}
+	mock.recorder = &MockidentityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockidentityService) EXPECT() *MockidentityServiceMockRecorder {
+	return m.recorder
+}
+
+// GetIdentities mocks base method.
+func (m *MockidentityService) GetIdentities(ctx context.Context, userID uint) ([]models.OAuthProviders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentities", ctx, userID)
+	ret0, _ := ret[0].([]models.OAuthProviders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentities indicates an expected call of GetIdentities.
+func (mr *MockidentityServiceMockRecorder) GetIdentities(ctx, userID any) *MockidentityServiceGetIdentitiesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentities", reflect.TypeOf((*MockidentityService)(nil).GetIdentities), ctx, userID)
+	return &MockidentityServiceGetIdentitiesCall{Call: call}
+}
+
+// MockidentityServiceGetIdentitiesCall wrap *gomock.Call
+type MockidentityServiceGetIdentitiesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockidentityServiceGetIdentitiesCall) Return(arg0 []models.OAuthProviders, arg1 error) *MockidentityServiceGetIdentitiesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockidentityServiceGetIdentitiesCall) Do(f func(context.Context, uint) ([]models.OAuthProviders, error)) *MockidentityServiceGetIdentitiesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockidentityServiceGetIdentitiesCall) DoAndReturn(f func(context.Context, uint) ([]models.OAuthProviders, error)) *MockidentityServiceGetIdentitiesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// LinkIdentity mocks base method.
+func (m *MockidentityService) LinkIdentity(ctx context.Context, userID uint, provider, idToken string) (models.OAuthProviders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkIdentity", ctx, userID, provider, idToken)
+	ret0, _ := ret[0].(models.OAuthProviders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkIdentity indicates an expected call of LinkIdentity.
+func (mr *MockidentityServiceMockRecorder) LinkIdentity(ctx, userID, provider, idToken any) *MockidentityServiceLinkIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkIdentity", reflect.TypeOf((*MockidentityService)(nil).LinkIdentity), ctx, userID, provider, idToken)
+	return &MockidentityServiceLinkIdentityCall{Call: call}
+}
+
+// MockidentityServiceLinkIdentityCall wrap *gomock.Call
+type MockidentityServiceLinkIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockidentityServiceLinkIdentityCall) Return(arg0 models.OAuthProviders, arg1 error) *MockidentityServiceLinkIdentityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockidentityServiceLinkIdentityCall) Do(f func(context.Context, uint, string, string) (models.OAuthProviders, error)) *MockidentityServiceLinkIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockidentityServiceLinkIdentityCall) DoAndReturn(f func(context.Context, uint, string, string) (models.OAuthProviders, error)) *MockidentityServiceLinkIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UnlinkIdentity mocks base method.
+func (m *MockidentityService) UnlinkIdentity(ctx context.Context, userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlinkIdentity", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlinkIdentity indicates an expected call of UnlinkIdentity.
+func (mr *MockidentityServiceMockRecorder) UnlinkIdentity(ctx, userID, id any) *MockidentityServiceUnlinkIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlinkIdentity", reflect.TypeOf((*MockidentityService)(nil).UnlinkIdentity), ctx, userID, id)
+	return &MockidentityServiceUnlinkIdentityCall{Call: call}
+}
+
+// MockidentityServiceUnlinkIdentityCall wrap *gomock.Call
+type MockidentityServiceUnlinkIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockidentityServiceUnlinkIdentityCall) Return(arg0 error) *MockidentityServiceUnlinkIdentityCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockidentityServiceUnlinkIdentityCall) Do(f func(context.Context, uint, uint) error) *MockidentityServiceUnlinkIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockidentityServiceUnlinkIdentityCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockidentityServiceUnlinkIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,250 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func newIdentityHandler(t *testing.T) (*handlers.IdentityHandler, *MockidentityService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	identityService := NewMockidentityService(ctrl)
+	identityHandler := handlers.NewIdentityHandler(identityService)
+
+	return identityHandler, identityService
+}
+
+func TestIdentityHandler_LinkIdentity(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	oAuthProvider := models.OAuthProviders{
+		Model:    gorm.Model{ID: 10, CreatedAt: createdAt},
+		UserID:   1,
+		Provider: "keycloak",
+		Subject:  "subject",
+	}
+
+	testCases := map[string]struct {
+		setExpectations func(identityService *MockidentityService)
+		request         requests.OAuthRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when token is empty": {
+			setExpectations: func(*MockidentityService) {},
+			request:         requests.OAuthRequest{},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should respond with a 404 status code when provider is unknown": {
+			setExpectations: func(identityService *MockidentityService) {
+				identityService.
+					EXPECT().
+					LinkIdentity(gomock.Any(), uint(1), "keycloak", "id-token").
+					Return(models.OAuthProviders{}, models.ErrOAuthProviderNotFound)
+			},
+			request:    requests.OAuthRequest{Token: "id-token"},
+			wantStatus: http.StatusNotFound,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusNotFound,
+				Error: "Unknown OAuth provider",
+			},
+		},
+		"It should respond with a 401 status code when ID token is invalid": {
+			setExpectations: func(identityService *MockidentityService) {
+				identityService.
+					EXPECT().
+					LinkIdentity(gomock.Any(), uint(1), "keycloak", "id-token").
+					Return(models.OAuthProviders{}, models.ErrInvalidAuthToken)
+			},
+			request:    requests.OAuthRequest{Token: "id-token"},
+			wantStatus: http.StatusUnauthorized,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusUnauthorized,
+				Error: "Invalid ID token",
+			},
+		},
+		"It should respond with a 409 status code when identity is linked to another user": {
+			setExpectations: func(identityService *MockidentityService) {
+				identityService.
+					EXPECT().
+					LinkIdentity(gomock.Any(), uint(1), "keycloak", "id-token").
+					Return(models.OAuthProviders{}, models.ErrOAuthIdentityLinked)
+			},
+			request:    requests.OAuthRequest{Token: "id-token"},
+			wantStatus: http.StatusConflict,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusConflict,
+				Error: "Identity is linked to another user",
+			},
+		},
+		"It should link identity": {
+			setExpectations: func(identityService *MockidentityService) {
+				identityService.
+					EXPECT().
+					LinkIdentity(gomock.Any(), uint(1), "keycloak", "id-token").
+					Return(oAuthProvider, nil)
+			},
+			request:    requests.OAuthRequest{Token: "id-token"},
+			wantStatus: http.StatusCreated,
+			wantResponse: responses.IdentityResponse{
+				ID:        10,
+				Provider:  "keycloak",
+				CreatedAt: createdAt,
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			identityHandler, identityService := newIdentityHandler(t)
+
+			testCase.setExpectations(identityService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodPost,
+				"/me/identities/keycloak",
+				bytes.NewBuffer(rawRequest),
+			)
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.SetPath("/me/identities/:provider")
+			c.SetParamNames("provider")
+			c.SetParamValues("keycloak")
+			c.Set("user", authClaims)
+
+			err = identityHandler.LinkIdentity(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestIdentityHandler_GetIdentities(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	oAuthProviders := []models.OAuthProviders{{
+		Model:    gorm.Model{ID: 10},
+		UserID:   1,
+		Provider: models.GOOGLE,
+		Subject:  "subject",
+		Token:    "id-token",
+	}}
+
+	identityHandler, identityService := newIdentityHandler(t)
+
+	identityService.
+		EXPECT().
+		GetIdentities(gomock.Any(), uint(1)).
+		Return(oAuthProviders, nil)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me/identities", http.NoBody)
+
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+	c.Set("user", authClaims)
+
+	err := identityHandler.GetIdentities(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+	wantResponse, err := json.Marshal(responses.NewIdentitiesResponse(oAuthProviders))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+	assert.NotContains(t, recorder.Body.String(), "id-token")
+}
+
+func TestIdentityHandler_UnlinkIdentity(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	testCases := map[string]struct {
+		setExpectations func(identityService *MockidentityService)
+		wantStatus      int
+	}{
+		"It should respond with a 404 status code when identity not found": {
+			setExpectations: func(identityService *MockidentityService) {
+				identityService.
+					EXPECT().
+					UnlinkIdentity(gomock.Any(), uint(1), uint(10)).
+					Return(models.ErrOAuthIdentityNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		"It should respond with a 409 status code when identity is the last login method": {
+			setExpectations: func(identityService *MockidentityService) {
+				identityService.
+					EXPECT().
+					UnlinkIdentity(gomock.Any(), uint(1), uint(10)).
+					Return(models.ErrLastLoginMethod)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		"It should unlink identity": {
+			setExpectations: func(identityService *MockidentityService) {
+				identityService.
+					EXPECT().
+					UnlinkIdentity(gomock.Any(), uint(1), uint(10)).
+					Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			identityHandler, identityService := newIdentityHandler(t)
+
+			testCase.setExpectations(identityService)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodDelete, "/me/identities/10", http.NoBody)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.SetPath("/me/identities/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("10")
+			c.Set("user", authClaims)
+
+			err := identityHandler.UnlinkIdentity(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+		})
+	}
+}
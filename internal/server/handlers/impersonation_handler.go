@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	safecast "github.com/ccoveille/go-safecast"
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=impersonation_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type impersonationService interface {
+	Impersonate(
+		ctx context.Context,
+		impersonatorID uint,
+		impersonatorScopes models.Scopes,
+		userID uint,
+	) (*responses.LoginResponse, error)
+}
+
+type ImpersonationHandler struct {
+	impersonationService impersonationService
+}
+
+func NewImpersonationHandler(impersonationService impersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{impersonationService: impersonationService}
+}
+
+// Impersonate godoc
+//
+//	@Summary		Impersonate user
+//	@Description	Issue a short-lived access token of the user for the admin to reproduce user issues.
+//	@Description	The token names the admin in the act claim, can't be refreshed and can't change credentials.
+//	@Description	It has the scopes of the admin token, except for the admin scope
+//	@ID				admin-users-impersonate
+//	@Tags			Admin Actions
+//	@Produce		json
+//	@Param			id	path		int	true	"User ID"
+//	@Success		200	{object}	responses.LoginResponse
+//	@Failure		400	{object}	responses.ErrorResponse
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Failure		403	{object}	responses.ErrorResponse
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/admin/users/{id}/impersonate [post]
+func (h *ImpersonationHandler) Impersonate(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	parsedID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to parse user id: "+err.Error(), http.StatusBadRequest))
+	}
+
+	id, err := safecast.Convert[uint](parsedID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to parse user id: "+err.Error(), http.StatusBadRequest))
+	}
+
+	response, err := h.impersonationService.Impersonate(c.Request().Context(), claims.ID, claims.Scopes(), id)
+	switch {
+	case errors.Is(err, models.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("User not found", http.StatusNotFound))
+	case errors.Is(err, models.ErrForbidden):
+		return c.JSON(http.StatusForbidden, responses.NewErrorResponse("User can't be impersonated", http.StatusForbidden))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
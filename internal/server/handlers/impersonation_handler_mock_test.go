@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: impersonation_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=impersonation_handler.go -destination=impersonation_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	responses "github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockimpersonationService is a mock of impersonationService interface.
+type MockimpersonationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockimpersonationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockimpersonationServiceMockRecorder is the mock recorder for MockimpersonationService.
+type MockimpersonationServiceMockRecorder struct {
+	mock *MockimpersonationService
+}
+
+// NewMockimpersonationService creates a new mock instance.
+func NewMockimpersonationService(ctrl *gomock.Controller) *MockimpersonationService {
+	mock := &MockimpersonationService{ctrl: ctrl}
+	mock.recorder = &MockimpersonationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockimpersonationService) EXPECT() *MockimpersonationServiceMockRecorder {
+	return m.recorder
+}
+
+// Impersonate mocks base method.
+func (m *MockimpersonationService) Impersonate(ctx context.Context, impersonatorID uint, impersonatorScopes models.Scopes, userID uint) (*responses.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Impersonate", ctx, impersonatorID, impersonatorScopes, userID)
+	ret0, _ := ret[0].(*responses.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Impersonate indicates an expected call of Impersonate.
+func (mr *MockimpersonationServiceMockRecorder) Impersonate(ctx, impersonatorID, impersonatorScopes, userID any) *MockimpersonationServiceImpersonateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Impersonate", reflect.TypeOf((*MockimpersonationService)(nil).Impersonate), ctx, impersonatorID, impersonatorScopes, userID)
+	return &MockimpersonationServiceImpersonateCall{Call: call}
+}
+
+// MockimpersonationServiceImpersonateCall wrap *gomock.Call
+type MockimpersonationServiceImpersonateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockimpersonationServiceImpersonateCall) Return(arg0 *responses.LoginResponse, arg1 error) *MockimpersonationServiceImpersonateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockimpersonationServiceImpersonateCall) Do(f func(context.Context, uint, models.Scopes, uint) (*responses.LoginResponse, error)) *MockimpersonationServiceImpersonateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockimpersonationServiceImpersonateCall) DoAndReturn(f func(context.Context, uint, models.Scopes, uint) (*responses.LoginResponse, error)) *MockimpersonationServiceImpersonateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
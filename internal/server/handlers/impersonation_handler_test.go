@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImpersonationHandler_Impersonate(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{
+		ID:    1,
+		Roles: models.Roles{models.RoleAdmin},
+		Scope: "posts:read admin",
+	}}
+
+	testCases := map[string]struct {
+		userID          string
+		setExpectations func(impersonationService *MockimpersonationService)
+		wantStatus      int
+		wantResponse    string
+	}{
+		"It should respond with a 400 status code when user id is invalid": {
+			userID:          "abc",
+			setExpectations: func(*MockimpersonationService) {},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse:    `{"code":400,"error":"Failed to parse user id: strconv.ParseUint: parsing \"abc\": invalid syntax"}`,
+		},
+		"It should respond with a 404 status code when user not found": {
+			userID: "2",
+			setExpectations: func(impersonationService *MockimpersonationService) {
+				impersonationService.
+					EXPECT().
+					Impersonate(gomock.Any(), uint(1), models.Scopes{models.ScopePostsRead, models.ScopeAdmin}, uint(2)).
+					Return(nil, models.ErrUserNotFound)
+			},
+			wantStatus:   http.StatusNotFound,
+			wantResponse: `{"code":404,"error":"User not found"}`,
+		},
+		"It should respond with a 403 status code when user can't be impersonated": {
+			userID: "2",
+			setExpectations: func(impersonationService *MockimpersonationService) {
+				impersonationService.
+					EXPECT().
+					Impersonate(gomock.Any(), uint(1), models.Scopes{models.ScopePostsRead, models.ScopeAdmin}, uint(2)).
+					Return(nil, fmt.Errorf("%w: impersonate admin", models.ErrForbidden))
+			},
+			wantStatus:   http.StatusForbidden,
+			wantResponse: `{"code":403,"error":"User can't be impersonated"}`,
+		},
+		"It should respond with a 500 status code when impersonation service fails": {
+			userID: "2",
+			setExpectations: func(impersonationService *MockimpersonationService) {
+				impersonationService.
+					EXPECT().
+					Impersonate(gomock.Any(), uint(1), models.Scopes{models.ScopePostsRead, models.ScopeAdmin}, uint(2)).
+					Return(nil, errors.New("impersonation service error"))
+			},
+			wantStatus:   http.StatusInternalServerError,
+			wantResponse: `{"code":500,"error":"Internal Server Error"}`,
+		},
+		"It should respond with the impersonation token": {
+			userID: "2",
+			setExpectations: func(impersonationService *MockimpersonationService) {
+				impersonationService.
+					EXPECT().
+					Impersonate(gomock.Any(), uint(1), models.Scopes{models.ScopePostsRead, models.ScopeAdmin}, uint(2)).
+					Return(responses.NewLoginResponse("access-token", "", 100), nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"accessToken":"access-token","exp":100}`,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			impersonationService := NewMockimpersonationService(ctrl)
+			impersonationHandler := handlers.NewImpersonationHandler(impersonationService)
+
+			testCase.setExpectations(impersonationService)
+
+			request := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodPost,
+				"/admin/users/"+testCase.userID+"/impersonate",
+				http.NoBody,
+			)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.SetPath("/admin/users/:id/impersonate")
+			c.SetParamNames("id")
+			c.SetParamValues(testCase.userID)
+			c.Set("user", authClaims)
+
+			err := impersonationHandler.Impersonate(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, testCase.wantResponse, recorder.Body.String())
+		})
+	}
+}
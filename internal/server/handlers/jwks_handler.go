@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=jwks_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type keySetProvider interface {
+	JWKS() token.JWKSet
+}
+
+type JWKSHandler struct {
+	keySetProvider keySetProvider
+}
+
+func NewJWKSHandler(keySetProvider keySetProvider) *JWKSHandler {
+	return &JWKSHandler{keySetProvider: keySetProvider}
+}
+
+// GetJWKS godoc
+//
+//	@Summary		Get JSON Web Key Set
+//	@Description	Get public keys to verify access tokens with
+//	@ID				jwks-get
+//	@Tags			Keys
+//	@Produce		json
+//	@Success		200	{object}	token.JWKSet
+//	@Router			/.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+
+	return c.JSON(http.StatusOK, h.keySetProvider.JWKS())
+}
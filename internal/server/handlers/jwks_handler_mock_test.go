@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: jwks_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=jwks_handler.go -destination=jwks_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	reflect "reflect"
+
+	token "github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockkeySetProvider is a mock of keySetProvider interface.
+type MockkeySetProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockkeySetProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockkeySetProviderMockRecorder is the mock recorder for MockkeySetProvider.
+type MockkeySetProviderMockRecorder struct {
+	mock *MockkeySetProvider
+}
+
+// NewMockkeySetProvider creates a new mock instance.
+func NewMockkeySetProvider(ctrl *gomock.Controller) *MockkeySetProvider {
+	mock := &MockkeySetProvider{ctrl: ctrl}
+	mock.recorder = &MockkeySetProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockkeySetProvider) EXPECT() *MockkeySetProviderMockRecorder {
+	return m.recorder
+}
+
+// JWKS mocks base method.
+func (m *MockkeySetProvider) JWKS() token.JWKSet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(token.JWKSet)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockkeySetProviderMockRecorder) JWKS() *MockkeySetProviderJWKSCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockkeySetProvider)(nil).JWKS))
+	return &MockkeySetProviderJWKSCall{Call: call}
+}
+
+// MockkeySetProviderJWKSCall wrap *gomock.Call
+type MockkeySetProviderJWKSCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockkeySetProviderJWKSCall) Return(arg0 token.JWKSet) *MockkeySetProviderJWKSCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockkeySetProviderJWKSCall) Do(f func() token.JWKSet) *MockkeySetProviderJWKSCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockkeySetProviderJWKSCall) DoAndReturn(f func() token.JWKSet) *MockkeySetProviderJWKSCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,51 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestJWKSHandler_GetJWKS(t *testing.T) {
+	keySet := token.JWKSet{Keys: []token.JWK{{
+		KeyType:   "OKP",
+		ID:        "key-id",
+		Use:       "sig",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         "public-key",
+	}}}
+
+	ctrl := gomock.NewController(t)
+	keySetProvider := NewMockkeySetProvider(ctrl)
+	jwksHandler := handlers.NewJWKSHandler(keySetProvider)
+
+	keySetProvider.
+		EXPECT().
+		JWKS().
+		Return(keySet)
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/.well-known/jwks.json", http.NoBody)
+
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(request, recorder)
+
+	err := jwksHandler.GetJWKS(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+	wantResponse, err := json.Marshal(keySet)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
+	"github.com/nix-united/golang-echo-boilerplate/internal/background"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=magic_link_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type magicLinkService interface {
+	SendLink(ctx context.Context, email string) error
+	Login(
+		ctx context.Context,
+		magicLinkToken string,
+		requestedScopes models.Scopes,
+		client models.Client,
+	) (*responses.LoginResponse, error)
+}
+
+type MagicLinkHandler struct {
+	magicLinkService magicLinkService
+	cookies          *authcookie.Cookies
+	tasks            *background.Runner
+}
+
+// NewMagicLinkHandler creates the handler. Login links are sent with the tasks off the request path.
+func NewMagicLinkHandler(
+	magicLinkService magicLinkService,
+	cookies *authcookie.Cookies,
+	tasks *background.Runner,
+) *MagicLinkHandler {
+	return &MagicLinkHandler{magicLinkService: magicLinkService, cookies: cookies, tasks: tasks}
+}
+
+// SendMagicLink godoc
+//
+//	@Summary		Request magic link
+//	@Description	Send a single-use login link to the email. It responds the same way whether the email is registered or not
+//	@ID				user-login-magic-link
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.MagicLinkRequest	true	"User's email"
+//	@Success		202		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		429		{object}	responses.ErrorResponse
+//	@Router			/login/magic-link [post]
+func (h *MagicLinkHandler) SendMagicLink(c echo.Context) error {
+	var request requests.MagicLinkRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	// The link is sent in the background, so that neither the response time nor a failure
+	// reveal whether the email is registered.
+	email := request.Email
+	h.tasks.Go(c.Request().Context(), "send magic link email", func(ctx context.Context) error {
+		return h.magicLinkService.SendLink(ctx, email)
+	})
+
+	return c.JSON(http.StatusAccepted, responses.NewMessageResponse("If the email is registered, a login link is sent"))
+}
+
+// LoginMagicLink godoc
+//
+//	@Summary		Log in with magic link
+//	@Description	Exchange the token from the login link for tokens. The token is single-use.
+//	@Description	When MFA is enabled, only mfaToken is returned and the login is completed at /login/mfa
+//	@ID				user-login-magic-link-verify
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			X-Auth-Mode	header		string							false	"Set to cookie to get the tokens as cookies"
+//	@Param			params		body		requests.MagicLinkLoginRequest	true	"Magic link token"
+//	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		401		{object}	responses.ErrorResponse
+//	@Router			/login/magic-link/verify [post]
+func (h *MagicLinkHandler) LoginMagicLink(c echo.Context) error {
+	var request requests.MagicLinkLoginRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	response, err := h.magicLinkService.Login(c.Request().Context(), request.Token, request.Scopes, newClient(c))
+	switch {
+	case errors.Is(err, models.ErrInvalidScope):
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid scope", http.StatusBadRequest))
+	case errors.Is(err, models.ErrInvalidActionToken):
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Invalid or expired login link", http.StatusUnauthorized))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return loginResponse(c, h.cookies, response)
+}
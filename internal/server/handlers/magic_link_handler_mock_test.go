@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: magic_link_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=magic_link_handler.go -destination=magic_link_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	responses "github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmagicLinkService is a mock of magicLinkService interface.
+type MockmagicLinkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockmagicLinkServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockmagicLinkServiceMockRecorder is the mock recorder for MockmagicLinkService.
+type MockmagicLinkServiceMockRecorder struct {
+	mock *MockmagicLinkService
+}
+
+// NewMockmagicLinkService creates a new mock instance.
+func NewMockmagicLinkService(ctrl *gomock.Controller) *MockmagicLinkService {
+	mock := &MockmagicLinkService{ctrl: ctrl}
+	mock.recorder = &MockmagicLinkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmagicLinkService) EXPECT() *MockmagicLinkServiceMockRecorder {
+	return m.recorder
+}
+
+// Login mocks base method.
+func (m *MockmagicLinkService) Login(ctx context.Context, magicLinkToken string, requestedScopes models.Scopes, client models.Client) (*responses.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", ctx, magicLinkToken, requestedScopes, client)
+	ret0, _ := ret[0].(*responses.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockmagicLinkServiceMockRecorder) Login(ctx, magicLinkToken, requestedScopes, client any) *MockmagicLinkServiceLoginCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockmagicLinkService)(nil).Login), ctx, magicLinkToken, requestedScopes, client)
+	return &MockmagicLinkServiceLoginCall{Call: call}
+}
+
+// MockmagicLinkServiceLoginCall wrap *gomock.Call
+type MockmagicLinkServiceLoginCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmagicLinkServiceLoginCall) Return(arg0 *responses.LoginResponse, arg1 error) *MockmagicLinkServiceLoginCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmagicLinkServiceLoginCall) Do(f func(context.Context, string, models.Scopes, models.Client) (*responses.LoginResponse, error)) *MockmagicLinkServiceLoginCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmagicLinkServiceLoginCall) DoAndReturn(f func(context.Context, string, models.Scopes, models.Client) (*responses.LoginResponse, error)) *MockmagicLinkServiceLoginCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SendLink mocks base method.
+func (m *MockmagicLinkService) SendLink(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendLink", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendLink indicates an expected call of SendLink.
+func (mr *MockmagicLinkServiceMockRecorder) SendLink(ctx, email any) *MockmagicLinkServiceSendLinkCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendLink", reflect.TypeOf((*MockmagicLinkService)(nil).SendLink), ctx, email)
+	return &MockmagicLinkServiceSendLinkCall{Call: call}
+}
+
+// MockmagicLinkServiceSendLinkCall wrap *gomock.Call
+type MockmagicLinkServiceSendLinkCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmagicLinkServiceSendLinkCall) Return(arg0 error) *MockmagicLinkServiceSendLinkCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmagicLinkServiceSendLinkCall) Do(f func(context.Context, string) error) *MockmagicLinkServiceSendLinkCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmagicLinkServiceSendLinkCall) DoAndReturn(f func(context.Context, string) error) *MockmagicLinkServiceSendLinkCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
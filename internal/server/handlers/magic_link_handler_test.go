@@ -0,0 +1,219 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/background"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newMagicLinkHandler(t *testing.T) (*handlers.MagicLinkHandler, *MockmagicLinkService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	magicLinkService := NewMockmagicLinkService(ctrl)
+	tasks := background.NewRunner()
+	magicLinkHandler := handlers.NewMagicLinkHandler(magicLinkService, newAuthCookies(), tasks)
+
+	// Tasks are finished before the mock controller checks the expectations.
+	// The test context is canceled by then already.
+	t.Cleanup(func() {
+		assert.NoError(t, tasks.Wait(context.Background()))
+	})
+
+	return magicLinkHandler, magicLinkService
+}
+
+func TestMagicLinkHandler_SendMagicLink(t *testing.T) {
+	testCases := map[string]struct {
+		setExpectations func(magicLinkService *MockmagicLinkService)
+		request         requests.MagicLinkRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when email is invalid": {
+			setExpectations: func(*MockmagicLinkService) {},
+			request:         requests.MagicLinkRequest{Email: "invalid_email"},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should accept magic link request even when sending fails": {
+			setExpectations: func(magicLinkService *MockmagicLinkService) {
+				magicLinkService.
+					EXPECT().
+					SendLink(gomock.Any(), "user@example.com").
+					Return(errors.New("test error"))
+			},
+			request:    requests.MagicLinkRequest{Email: "user@example.com"},
+			wantStatus: http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "If the email is registered, a login link is sent",
+			},
+		},
+		"It should accept magic link request": {
+			setExpectations: func(magicLinkService *MockmagicLinkService) {
+				magicLinkService.
+					EXPECT().
+					SendLink(gomock.Any(), "user@example.com").
+					Return(nil)
+			},
+			request:    requests.MagicLinkRequest{Email: "user@example.com"},
+			wantStatus: http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "If the email is registered, a login link is sent",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			magicLinkHandler, magicLinkService := newMagicLinkHandler(t)
+
+			testCase.setExpectations(magicLinkService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/login/magic-link", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = magicLinkHandler.SendMagicLink(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestMagicLinkHandler_LoginMagicLink(t *testing.T) {
+	loginRequest := requests.MagicLinkLoginRequest{Token: "magic-link-token"}
+	loginResponse := responses.NewLoginResponse("access-token", "refresh-token", 1735786800)
+
+	testCases := map[string]struct {
+		setExpectations func(magicLinkService *MockmagicLinkService)
+		request         requests.MagicLinkLoginRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when token is empty": {
+			setExpectations: func(*MockmagicLinkService) {},
+			request:         requests.MagicLinkLoginRequest{},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should respond with a 400 status code when scope is invalid": {
+			setExpectations: func(magicLinkService *MockmagicLinkService) {
+				magicLinkService.
+					EXPECT().
+					Login(gomock.Any(), "magic-link-token", models.Scopes{"unknown"}, client).
+					Return(nil, models.ErrInvalidScope)
+			},
+			request:    requests.MagicLinkLoginRequest{Token: "magic-link-token", Scopes: models.Scopes{"unknown"}},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Invalid scope",
+			},
+		},
+		"It should respond with a 401 status code when token is invalid": {
+			setExpectations: func(magicLinkService *MockmagicLinkService) {
+				magicLinkService.
+					EXPECT().
+					Login(gomock.Any(), "magic-link-token", gomock.Any(), client).
+					Return(nil, errors.Join(models.ErrInvalidActionToken, errors.New("test error")))
+			},
+			request:    loginRequest,
+			wantStatus: http.StatusUnauthorized,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusUnauthorized,
+				Error: "Invalid or expired login link",
+			},
+		},
+		"It should respond with a 500 status code when login fails": {
+			setExpectations: func(magicLinkService *MockmagicLinkService) {
+				magicLinkService.
+					EXPECT().
+					Login(gomock.Any(), "magic-link-token", gomock.Any(), client).
+					Return(nil, errors.New("test error"))
+			},
+			request:    loginRequest,
+			wantStatus: http.StatusInternalServerError,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusInternalServerError,
+				Error: "Internal Server Error",
+			},
+		},
+		"It should log in": {
+			setExpectations: func(magicLinkService *MockmagicLinkService) {
+				magicLinkService.
+					EXPECT().
+					Login(gomock.Any(), "magic-link-token", gomock.Any(), client).
+					Return(loginResponse, nil)
+			},
+			request:      loginRequest,
+			wantStatus:   http.StatusOK,
+			wantResponse: loginResponse,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			magicLinkHandler, magicLinkService := newMagicLinkHandler(t)
+
+			testCase.setExpectations(magicLinkService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodPost,
+				"/login/magic-link/verify",
+				bytes.NewBuffer(rawRequest),
+			)
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			request.Header.Set("User-Agent", client.UserAgent)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = magicLinkHandler.LoginMagicLink(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/mfa"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=mfa_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type mfaService interface {
+	Enroll(ctx context.Context, userID uint) (mfa.Enrollment, error)
+	Confirm(ctx context.Context, userID uint, code string) error
+}
+
+type MFAHandler struct {
+	mfaService mfaService
+}
+
+func NewMFAHandler(mfaService mfaService) *MFAHandler {
+	return &MFAHandler{mfaService: mfaService}
+}
+
+// EnrollTOTP godoc
+//
+//	@Summary		Enroll TOTP
+//	@Description	Start two-factor authentication setup with an authenticator app. Any pending enrollment is replaced.
+//	@Description	The secret and recovery codes are shown only once
+//	@ID				mfa-totp-enroll
+//	@Tags			MFA Actions
+//	@Produce		json
+//	@Success		201	{object}	responses.TOTPEnrollmentResponse
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Failure		409	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/mfa/totp [post]
+func (h *MFAHandler) EnrollTOTP(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	enrollment, err := h.mfaService.Enroll(c.Request().Context(), claims.ID)
+	switch {
+	case errors.Is(err, models.ErrMFAAlreadyEnabled):
+		return c.JSON(http.StatusConflict, responses.NewErrorResponse("MFA is already enabled", http.StatusConflict))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	response := responses.NewTOTPEnrollmentResponse(enrollment.Secret, enrollment.URI, enrollment.RecoveryCodes)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ConfirmTOTP godoc
+//
+//	@Summary		Confirm TOTP
+//	@Description	Enable two-factor authentication with a code from the authenticator app
+//	@ID				mfa-totp-confirm
+//	@Tags			MFA Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.MFACodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		401		{object}	responses.ErrorResponse
+//	@Failure		404		{object}	responses.ErrorResponse
+//	@Failure		409		{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/mfa/totp/confirm [post]
+func (h *MFAHandler) ConfirmTOTP(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	var request requests.MFACodeRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	err = h.mfaService.Confirm(c.Request().Context(), claims.ID, request.Code)
+	switch {
+	case errors.Is(err, models.ErrInvalidMFACode):
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid code", http.StatusBadRequest))
+	case errors.Is(err, models.ErrMFANotEnrolled):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("MFA enrollment not found", http.StatusNotFound))
+	case errors.Is(err, models.ErrMFAAlreadyEnabled):
+		return c.JSON(http.StatusConflict, responses.NewErrorResponse("MFA is already enabled", http.StatusConflict))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewMessageResponse("MFA successfully enabled"))
+}
@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mfa_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mfa_handler.go -destination=mfa_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	mfa "github.com/nix-united/golang-echo-boilerplate/internal/services/mfa"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmfaService is a mock of mfaService interface.
+type MockmfaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockmfaServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockmfaServiceMockRecorder is the mock recorder for MockmfaService.
+type MockmfaServiceMockRecorder struct {
+	mock *MockmfaService
+}
+
+// NewMockmfaService creates a new mock instance.
+func NewMockmfaService(ctrl *gomock.Controller) *MockmfaService {
+	mock := &MockmfaService{ctrl: ctrl}
+	mock.recorder = &MockmfaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmfaService) EXPECT() *MockmfaServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockmfaService) Confirm(ctx context.Context, userID uint, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockmfaServiceMockRecorder) Confirm(ctx, userID, code any) *MockmfaServiceConfirmCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockmfaService)(nil).Confirm), ctx, userID, code)
+	return &MockmfaServiceConfirmCall{Call: call}
+}
+
+// MockmfaServiceConfirmCall wrap *gomock.Call
+type MockmfaServiceConfirmCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaServiceConfirmCall) Return(arg0 error) *MockmfaServiceConfirmCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaServiceConfirmCall) Do(f func(context.Context, uint, string) error) *MockmfaServiceConfirmCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaServiceConfirmCall) DoAndReturn(f func(context.Context, uint, string) error) *MockmfaServiceConfirmCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Enroll mocks base method.
+func (m *MockmfaService) Enroll(ctx context.Context, userID uint) (mfa.Enrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, userID)
+	ret0, _ := ret[0].(mfa.Enrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockmfaServiceMockRecorder) Enroll(ctx, userID any) *MockmfaServiceEnrollCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockmfaService)(nil).Enroll), ctx, userID)
+	return &MockmfaServiceEnrollCall{Call: call}
+}
+
+// MockmfaServiceEnrollCall wrap *gomock.Call
+type MockmfaServiceEnrollCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaServiceEnrollCall) Return(arg0 mfa.Enrollment, arg1 error) *MockmfaServiceEnrollCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaServiceEnrollCall) Do(f func(context.Context, uint) (mfa.Enrollment, error)) *MockmfaServiceEnrollCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaServiceEnrollCall) DoAndReturn(f func(context.Context, uint) (mfa.Enrollment, error)) *MockmfaServiceEnrollCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,202 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/mfa"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newMFAHandler(t *testing.T) (*handlers.MFAHandler, *MockmfaService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mfaService := NewMockmfaService(ctrl)
+	mfaHandler := handlers.NewMFAHandler(mfaService)
+
+	return mfaHandler, mfaService
+}
+
+func TestMFAHandler_EnrollTOTP(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	testCases := map[string]struct {
+		setExpectations func(mfaService *MockmfaService)
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 409 status code when MFA is already enabled": {
+			setExpectations: func(mfaService *MockmfaService) {
+				mfaService.
+					EXPECT().
+					Enroll(gomock.Any(), uint(1)).
+					Return(mfa.Enrollment{}, models.ErrMFAAlreadyEnabled)
+			},
+			wantStatus: http.StatusConflict,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusConflict,
+				Error: "MFA is already enabled",
+			},
+		},
+		"It should enroll TOTP": {
+			setExpectations: func(mfaService *MockmfaService) {
+				mfaService.
+					EXPECT().
+					Enroll(gomock.Any(), uint(1)).
+					Return(mfa.Enrollment{
+						Secret:        "secret",
+						URI:           "otpauth://totp/Echo:user@example.com?issuer=Echo&secret=secret",
+						RecoveryCodes: []string{"recovery-code"},
+					}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantResponse: responses.TOTPEnrollmentResponse{
+				Secret:        "secret",
+				URI:           "otpauth://totp/Echo:user@example.com?issuer=Echo&secret=secret",
+				RecoveryCodes: []string{"recovery-code"},
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			mfaHandler, mfaService := newMFAHandler(t)
+
+			testCase.setExpectations(mfaService)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/me/mfa/totp", http.NoBody)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("user", authClaims)
+
+			err := mfaHandler.EnrollTOTP(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestMFAHandler_ConfirmTOTP(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	testCases := map[string]struct {
+		setExpectations func(mfaService *MockmfaService)
+		request         requests.MFACodeRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when code is empty": {
+			setExpectations: func(*MockmfaService) {},
+			request:         requests.MFACodeRequest{},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should respond with a 400 status code when code is invalid": {
+			setExpectations: func(mfaService *MockmfaService) {
+				mfaService.
+					EXPECT().
+					Confirm(gomock.Any(), uint(1), "123456").
+					Return(models.ErrInvalidMFACode)
+			},
+			request:    requests.MFACodeRequest{Code: "123456"},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Invalid code",
+			},
+		},
+		"It should respond with a 404 status code when MFA is not enrolled": {
+			setExpectations: func(mfaService *MockmfaService) {
+				mfaService.
+					EXPECT().
+					Confirm(gomock.Any(), uint(1), "123456").
+					Return(models.ErrMFANotEnrolled)
+			},
+			request:    requests.MFACodeRequest{Code: "123456"},
+			wantStatus: http.StatusNotFound,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusNotFound,
+				Error: "MFA enrollment not found",
+			},
+		},
+		"It should respond with a 409 status code when MFA is already enabled": {
+			setExpectations: func(mfaService *MockmfaService) {
+				mfaService.
+					EXPECT().
+					Confirm(gomock.Any(), uint(1), "123456").
+					Return(models.ErrMFAAlreadyEnabled)
+			},
+			request:    requests.MFACodeRequest{Code: "123456"},
+			wantStatus: http.StatusConflict,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusConflict,
+				Error: "MFA is already enabled",
+			},
+		},
+		"It should enable MFA": {
+			setExpectations: func(mfaService *MockmfaService) {
+				mfaService.
+					EXPECT().
+					Confirm(gomock.Any(), uint(1), "123456").
+					Return(nil)
+			},
+			request:    requests.MFACodeRequest{Code: "123456"},
+			wantStatus: http.StatusOK,
+			wantResponse: responses.MessageResponse{
+				Message: "MFA successfully enabled",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			mfaHandler, mfaService := newMFAHandler(t)
+
+			testCase.setExpectations(mfaService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/me/mfa/totp/confirm", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("user", authClaims)
+
+			err = mfaHandler.ConfirmTOTP(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
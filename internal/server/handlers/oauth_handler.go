@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
 	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
 
@@ -13,15 +17,33 @@ import (
 //go:generate go tool mockgen -source=$GOFILE -destination=oauth_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
 
 type userAuthenticator interface {
-	GoogleOAuth(ctx context.Context, token string) (accessToken string, refreshToken string, exp int64, err error)
+	Authenticate(
+		ctx context.Context,
+		provider string,
+		token string,
+		client models.Client,
+	) (*responses.LoginResponse, error)
+	StartAuthorization(ctx context.Context, provider string) (authURL string, state string, err error)
+	CompleteAuthorization(
+		ctx context.Context,
+		provider string,
+		state string,
+		code string,
+		client models.Client,
+	) (*responses.LoginResponse, error)
 }
 
+// oAuthStateCookieName is the cookie binding the authorization code flow to the browser that started it,
+// so that a callback URL crafted by an attacker can't log the victim into the attacker's account.
+const oAuthStateCookieName = "oauth_state"
+
 type OAuthHandler struct {
 	userService userAuthenticator
+	cookies     *authcookie.Cookies
 }
 
-func NewOAuthHandler(userService userAuthenticator) *OAuthHandler {
-	return &OAuthHandler{userService: userService}
+func NewOAuthHandler(userService userAuthenticator, cookies *authcookie.Cookies) *OAuthHandler {
+	return &OAuthHandler{userService: userService, cookies: cookies}
 }
 
 // GoogleOAuth godoc
@@ -32,11 +54,41 @@ func NewOAuthHandler(userService userAuthenticator) *OAuthHandler {
 //	@Tags			User Actions
 //	@Accept			json
 //	@Produce		json
-//	@Param			params	body		requests.OAuthRequest	true	"Google Token"
+//	@Param			X-Auth-Mode	header		string					false	"Set to cookie to get the tokens as cookies"
+//	@Param			params		body		requests.OAuthRequest	true	"Google Token"
 //	@Success		200		{object}	responses.LoginResponse
+//	@Failure		400		{object}	responses.ErrorResponse
 //	@Failure		401		{object}	responses.ErrorResponse
+//	@Failure		403		{object}	responses.ErrorResponse
+//	@Failure		409		{object}	responses.ErrorResponse
 //	@Router			/google-oauth [post]
 func (oa *OAuthHandler) GoogleOAuth(c echo.Context) error {
+	return oa.authenticate(c, string(models.GOOGLE))
+}
+
+// Authenticate godoc
+//
+//	@Summary		Authenticate user using OpenID Connect provider
+//	@Description	Perform user login with an ID token issued by a configured OpenID Connect provider
+//	@ID				user-auth-oauth
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			provider	path		string					true	"Provider name"
+//	@Param			X-Auth-Mode	header		string					false	"Set to cookie to get the tokens as cookies"
+//	@Param			params		body		requests.OAuthRequest	true	"ID Token"
+//	@Success		200			{object}	responses.LoginResponse
+//	@Failure		400			{object}	responses.ErrorResponse
+//	@Failure		401			{object}	responses.ErrorResponse
+//	@Failure		403			{object}	responses.ErrorResponse
+//	@Failure		404			{object}	responses.ErrorResponse
+//	@Failure		409			{object}	responses.ErrorResponse
+//	@Router			/oauth/{provider} [post]
+func (oa *OAuthHandler) Authenticate(c echo.Context) error {
+	return oa.authenticate(c, c.Param("provider"))
+}
+
+func (oa *OAuthHandler) authenticate(c echo.Context, provider string) error {
 	var oAuthRequest requests.OAuthRequest
 
 	if err := c.Bind(&oAuthRequest); err != nil {
@@ -47,12 +99,137 @@ func (oa *OAuthHandler) GoogleOAuth(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
 	}
 
-	accessToken, refreshToken, exp, err := oa.userService.GoogleOAuth(c.Request().Context(), oAuthRequest.Token)
-	if err != nil {
-		errorResponse := responses.NewErrorResponse("Failed to authenticate with Google: "+err.Error(), http.StatusBadRequest)
-		return c.JSON(http.StatusBadRequest, errorResponse)
+	response, err := oa.userService.Authenticate(
+		c.Request().Context(),
+		provider,
+		oAuthRequest.Token,
+		newClient(c),
+	)
+	switch {
+	case errors.Is(err, models.ErrOAuthProviderNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("Unknown OAuth provider", http.StatusNotFound))
+	case errors.Is(err, models.ErrInvalidAuthToken):
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Invalid ID token", http.StatusUnauthorized))
+	case errors.Is(err, models.ErrOAuthEmailNotVerified):
+		return c.JSON(http.StatusForbidden, responses.NewErrorResponse("Email is not verified by the provider", http.StatusForbidden))
+	case errors.Is(err, models.ErrUserAlreadyExists):
+		errorResponse := responses.NewErrorResponse("User already exists, link the provider to the account first", http.StatusConflict)
+		return c.JSON(http.StatusConflict, errorResponse)
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return loginResponse(c, oa.cookies, response)
+}
+
+// StartAuthorization godoc
+//
+//	@Summary		Start authorization code flow
+//	@Description	Redirect the user to the consent page of a configured OpenID Connect provider
+//	@ID				user-auth-oauth-start
+//	@Tags			User Actions
+//	@Param			provider	path	string	true	"Provider name"
+//	@Success		302			"Found"
+//	@Failure		404			{object}	responses.ErrorResponse
+//	@Failure		503			{object}	responses.ErrorResponse
+//	@Router			/oauth/{provider}/start [get]
+func (oa *OAuthHandler) StartAuthorization(c echo.Context) error {
+	provider := c.Param("provider")
+
+	authURL, state, err := oa.userService.StartAuthorization(c.Request().Context(), provider)
+	switch {
+	case errors.Is(err, models.ErrOAuthProviderNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("Unknown OAuth provider", http.StatusNotFound))
+	case errors.Is(err, models.ErrTooManyOAuthStates):
+		errorResponse := responses.NewErrorResponse("Too many pending authorizations, try again later", http.StatusServiceUnavailable)
+		return c.JSON(http.StatusServiceUnavailable, errorResponse)
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	c.SetCookie(newOAuthStateCookie(c, provider, state))
+
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// CompleteAuthorization godoc
+//
+//	@Summary		Complete authorization code flow
+//	@Description	Exchange the authorization code the provider redirected the user back with and perform user login
+//	@ID				user-auth-oauth-callback
+//	@Tags			User Actions
+//	@Produce		json
+//	@Param			provider	path		string	true	"Provider name"
+//	@Param			state		query		string	true	"State"
+//	@Param			code		query		string	true	"Authorization code"
+//	@Success		200			{object}	responses.LoginResponse
+//	@Failure		400			{object}	responses.ErrorResponse
+//	@Failure		401			{object}	responses.ErrorResponse
+//	@Failure		403			{object}	responses.ErrorResponse
+//	@Failure		404			{object}	responses.ErrorResponse
+//	@Failure		409			{object}	responses.ErrorResponse
+//	@Router			/oauth/{provider}/callback [get]
+func (oa *OAuthHandler) CompleteAuthorization(c echo.Context) error {
+	provider := c.Param("provider")
+	state := c.QueryParam("state")
+
+	stateCookie, err := c.Cookie(oAuthStateCookieName)
+	c.SetCookie(newOAuthStateCookie(c, provider, ""))
+
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(stateCookie.Value), []byte(state)) != 1 {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid OAuth state", http.StatusBadRequest))
+	}
+
+	if c.QueryParam("error") != "" {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Authorization denied", http.StatusUnauthorized))
+	}
+
+	code := c.QueryParam("code")
+	if code == "" {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	response, err := oa.userService.CompleteAuthorization(
+		c.Request().Context(),
+		provider,
+		state,
+		code,
+		newClient(c),
+	)
+	switch {
+	case errors.Is(err, models.ErrOAuthProviderNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("Unknown OAuth provider", http.StatusNotFound))
+	case errors.Is(err, models.ErrInvalidOAuthState):
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid OAuth state", http.StatusBadRequest))
+	case errors.Is(err, models.ErrInvalidAuthToken):
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Invalid authorization code", http.StatusUnauthorized))
+	case errors.Is(err, models.ErrOAuthEmailNotVerified):
+		return c.JSON(http.StatusForbidden, responses.NewErrorResponse("Email is not verified by the provider", http.StatusForbidden))
+	case errors.Is(err, models.ErrUserAlreadyExists):
+		errorResponse := responses.NewErrorResponse("User already exists, link the provider to the account first", http.StatusConflict)
+		return c.JSON(http.StatusConflict, errorResponse)
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// newOAuthStateCookie creates the state cookie scoped to the provider routes. An empty state removes the cookie.
+func newOAuthStateCookie(c echo.Context, provider, state string) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     oAuthStateCookieName,
+		Value:    state,
+		Path:     "/oauth/" + provider,
+		Secure:   c.Scheme() == "https",
+		HttpOnly: true,
+		// Lax is required for the cookie to be sent with the top-level redirect from the provider.
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	if state == "" {
+		cookie.MaxAge = -1
 	}
 
-	res := responses.NewLoginResponse(accessToken, refreshToken, exp)
-	return c.JSON(http.StatusOK, res)
+	return cookie
 }
@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	responses "github.com/nix-united/golang-echo-boilerplate/internal/responses"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,43 +42,120 @@ func (m *MockuserAuthenticator) EXPECT() *MockuserAuthenticatorMockRecorder {
 	return m.recorder
 }
 
-// GoogleOAuth mocks base method.
-func (m *MockuserAuthenticator) GoogleOAuth(ctx context.Context, token string) (string, string, int64, error) {
+// Authenticate mocks base method.
+func (m *MockuserAuthenticator) Authenticate(ctx context.Context, provider, token string, client models.Client) (*responses.LoginResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GoogleOAuth", ctx, token)
+	ret := m.ctrl.Call(m, "Authenticate", ctx, provider, token, client)
+	ret0, _ := ret[0].(*responses.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockuserAuthenticatorMockRecorder) Authenticate(ctx, provider, token, client any) *MockuserAuthenticatorAuthenticateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockuserAuthenticator)(nil).Authenticate), ctx, provider, token, client)
+	return &MockuserAuthenticatorAuthenticateCall{Call: call}
+}
+
+// MockuserAuthenticatorAuthenticateCall wrap *gomock.Call
+type MockuserAuthenticatorAuthenticateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserAuthenticatorAuthenticateCall) Return(arg0 *responses.LoginResponse, arg1 error) *MockuserAuthenticatorAuthenticateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserAuthenticatorAuthenticateCall) Do(f func(context.Context, string, string, models.Client) (*responses.LoginResponse, error)) *MockuserAuthenticatorAuthenticateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserAuthenticatorAuthenticateCall) DoAndReturn(f func(context.Context, string, string, models.Client) (*responses.LoginResponse, error)) *MockuserAuthenticatorAuthenticateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CompleteAuthorization mocks base method.
+func (m *MockuserAuthenticator) CompleteAuthorization(ctx context.Context, provider, state, code string, client models.Client) (*responses.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAuthorization", ctx, provider, state, code, client)
+	ret0, _ := ret[0].(*responses.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteAuthorization indicates an expected call of CompleteAuthorization.
+func (mr *MockuserAuthenticatorMockRecorder) CompleteAuthorization(ctx, provider, state, code, client any) *MockuserAuthenticatorCompleteAuthorizationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAuthorization", reflect.TypeOf((*MockuserAuthenticator)(nil).CompleteAuthorization), ctx, provider, state, code, client)
+	return &MockuserAuthenticatorCompleteAuthorizationCall{Call: call}
+}
+
+// MockuserAuthenticatorCompleteAuthorizationCall wrap *gomock.Call
+type MockuserAuthenticatorCompleteAuthorizationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserAuthenticatorCompleteAuthorizationCall) Return(arg0 *responses.LoginResponse, arg1 error) *MockuserAuthenticatorCompleteAuthorizationCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserAuthenticatorCompleteAuthorizationCall) Do(f func(context.Context, string, string, string, models.Client) (*responses.LoginResponse, error)) *MockuserAuthenticatorCompleteAuthorizationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserAuthenticatorCompleteAuthorizationCall) DoAndReturn(f func(context.Context, string, string, string, models.Client) (*responses.LoginResponse, error)) *MockuserAuthenticatorCompleteAuthorizationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// StartAuthorization mocks base method.
+func (m *MockuserAuthenticator) StartAuthorization(ctx context.Context, provider string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartAuthorization", ctx, provider)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(int64)
-	ret3, _ := ret[3].(error)
-	return ret0, ret1, ret2, ret3
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GoogleOAuth indicates an expected call of GoogleOAuth.
-func (mr *MockuserAuthenticatorMockRecorder) GoogleOAuth(ctx, token any) *MockuserAuthenticatorGoogleOAuthCall {
+// StartAuthorization indicates an expected call of StartAuthorization.
+func (mr *MockuserAuthenticatorMockRecorder) StartAuthorization(ctx, provider any) *MockuserAuthenticatorStartAuthorizationCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GoogleOAuth", reflect.TypeOf((*MockuserAuthenticator)(nil).GoogleOAuth), ctx, token)
-	return &MockuserAuthenticatorGoogleOAuthCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAuthorization", reflect.TypeOf((*MockuserAuthenticator)(nil).StartAuthorization), ctx, provider)
+	return &MockuserAuthenticatorStartAuthorizationCall{Call: call}
 }
 
-// MockuserAuthenticatorGoogleOAuthCall wrap *gomock.Call
-type MockuserAuthenticatorGoogleOAuthCall struct {
+// MockuserAuthenticatorStartAuthorizationCall wrap *gomock.Call
+type MockuserAuthenticatorStartAuthorizationCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockuserAuthenticatorGoogleOAuthCall) Return(accessToken, refreshToken string, exp int64, err error) *MockuserAuthenticatorGoogleOAuthCall {
-	c.Call = c.Call.Return(accessToken, refreshToken, exp, err)
+func (c *MockuserAuthenticatorStartAuthorizationCall) Return(authURL, state string, err error) *MockuserAuthenticatorStartAuthorizationCall {
+	c.Call = c.Call.Return(authURL, state, err)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockuserAuthenticatorGoogleOAuthCall) Do(f func(context.Context, string) (string, string, int64, error)) *MockuserAuthenticatorGoogleOAuthCall {
+func (c *MockuserAuthenticatorStartAuthorizationCall) Do(f func(context.Context, string) (string, string, error)) *MockuserAuthenticatorStartAuthorizationCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockuserAuthenticatorGoogleOAuthCall) DoAndReturn(f func(context.Context, string) (string, string, int64, error)) *MockuserAuthenticatorGoogleOAuthCall {
+func (c *MockuserAuthenticatorStartAuthorizationCall) DoAndReturn(f func(context.Context, string) (string, string, error)) *MockuserAuthenticatorStartAuthorizationCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -3,11 +3,16 @@ package handlers_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
 	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
 
 	"github.com/labstack/echo/v4"
@@ -21,10 +26,13 @@ func newOAuthHandler(t *testing.T) (*echo.Echo, *handlers.OAuthHandler, *Mockuse
 
 	ctrl := gomock.NewController(t)
 	userAuthenticator := NewMockuserAuthenticator(ctrl)
-	oAuthHandler := handlers.NewOAuthHandler(userAuthenticator)
+	oAuthHandler := handlers.NewOAuthHandler(userAuthenticator, newAuthCookies())
 	engine := echo.New()
 
 	engine.POST("/google-oauth", oAuthHandler.GoogleOAuth)
+	engine.POST("/oauth/:provider", oAuthHandler.Authenticate)
+	engine.GET("/oauth/:provider/start", oAuthHandler.StartAuthorization)
+	engine.GET("/oauth/:provider/callback", oAuthHandler.CompleteAuthorization)
 
 	return engine, oAuthHandler, userAuthenticator
 }
@@ -64,11 +72,12 @@ func TestOAuthHandler_GoogleOAuth(t *testing.T) {
 		require.NoError(t, err)
 
 		userAuthenticator.
-			EXPECT().GoogleOAuth(gomock.Any(), oAuthRequest.Token).
-			Return("access-token-123", "refresh-token-456", 3600, nil)
+			EXPECT().Authenticate(gomock.Any(), "google", oAuthRequest.Token, client).
+			Return(responses.NewLoginResponse("access-token-123", "refresh-token-456", 3600), nil)
 
 		request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/google-oauth", buffer)
 		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		request.Header.Set("User-Agent", client.UserAgent)
 
 		recorder := httptest.NewRecorder()
 		c := engine.NewContext(request, recorder)
@@ -87,3 +96,241 @@ func TestOAuthHandler_GoogleOAuth(t *testing.T) {
 		assert.JSONEq(t, wantResponse, recorder.Body.String())
 	})
 }
+
+func TestOAuthHandler_Authenticate(t *testing.T) {
+	testCases := map[string]struct {
+		authenticateErr error
+		wantStatus      int
+		wantResponse    string
+	}{
+		"It should authorize user": {
+			wantStatus: http.StatusOK,
+			wantResponse: `{
+				"accessToken": "access-token-123",
+				"refreshToken": "refresh-token-456",
+				"exp": 3600
+			}`,
+		},
+		"It should return not found for unknown provider": {
+			authenticateErr: models.ErrOAuthProviderNotFound,
+			wantStatus:      http.StatusNotFound,
+			wantResponse: `{
+				"code": 404,
+				"error": "Unknown OAuth provider"
+			}`,
+		},
+		"It should return unauthorized for invalid ID token": {
+			authenticateErr: models.ErrInvalidAuthToken,
+			wantStatus:      http.StatusUnauthorized,
+			wantResponse: `{
+				"code": 401,
+				"error": "Invalid ID token"
+			}`,
+		},
+		"It should return forbidden for email not verified by the provider": {
+			authenticateErr: models.ErrOAuthEmailNotVerified,
+			wantStatus:      http.StatusForbidden,
+			wantResponse: `{
+				"code": 403,
+				"error": "Email is not verified by the provider"
+			}`,
+		},
+		"It should return conflict for existing user without linked identity": {
+			authenticateErr: models.ErrUserAlreadyExists,
+			wantStatus:      http.StatusConflict,
+			wantResponse: `{
+				"code": 409,
+				"error": "User already exists, link the provider to the account first"
+			}`,
+		},
+		"It should return internal server error": {
+			authenticateErr: errors.New("test error"),
+			wantStatus:      http.StatusInternalServerError,
+			wantResponse: `{
+				"code": 500,
+				"error": "Internal Server Error"
+			}`,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			engine, _, userAuthenticator := newOAuthHandler(t)
+
+			userAuthenticator.
+				EXPECT().Authenticate(gomock.Any(), "keycloak", "test token", client).
+				Return(responses.NewLoginResponse("access-token-123", "refresh-token-456", 3600), testCase.authenticateErr)
+
+			body := `{"token": "test token"}`
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/oauth/keycloak", strings.NewReader(body))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			request.Header.Set("User-Agent", client.UserAgent)
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Code)
+			assert.JSONEq(t, testCase.wantResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestOAuthHandler_StartAuthorization(t *testing.T) {
+	t.Run("It should redirect to provider and set state cookie", func(t *testing.T) {
+		engine, _, userAuthenticator := newOAuthHandler(t)
+
+		userAuthenticator.
+			EXPECT().StartAuthorization(gomock.Any(), "keycloak").
+			Return("https://sso.example.com/authorize?state=state", "state", nil)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/oauth/keycloak/start", http.NoBody)
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusFound, recorder.Code)
+		assert.Equal(t, "https://sso.example.com/authorize?state=state", recorder.Header().Get(echo.HeaderLocation))
+
+		cookies := recorder.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, "oauth_state", cookies[0].Name)
+		assert.Equal(t, "state", cookies[0].Value)
+		assert.Equal(t, "/oauth/keycloak", cookies[0].Path)
+		assert.True(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+	})
+
+	t.Run("It should return not found for unknown provider", func(t *testing.T) {
+		engine, _, userAuthenticator := newOAuthHandler(t)
+
+		userAuthenticator.
+			EXPECT().StartAuthorization(gomock.Any(), "unknown").
+			Return("", "", models.ErrOAuthProviderNotFound)
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/oauth/unknown/start", http.NoBody)
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.JSONEq(t, `{"code": 404, "error": "Unknown OAuth provider"}`, recorder.Body.String())
+	})
+
+	t.Run("It should return service unavailable when too many authorizations are pending", func(t *testing.T) {
+		engine, _, userAuthenticator := newOAuthHandler(t)
+
+		userAuthenticator.
+			EXPECT().StartAuthorization(gomock.Any(), "keycloak").
+			Return("", "", fmt.Errorf("save oauth state: %w", models.ErrTooManyOAuthStates))
+
+		request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/oauth/keycloak/start", http.NoBody)
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.JSONEq(t, `{"code": 503, "error": "Too many pending authorizations, try again later"}`, recorder.Body.String())
+		assert.Empty(t, recorder.Result().Cookies())
+	})
+}
+
+func TestOAuthHandler_CompleteAuthorization(t *testing.T) {
+	testCases := map[string]struct {
+		query          string
+		stateCookie    string
+		expectComplete bool
+		completeErr    error
+		wantStatus     int
+		wantResponse   string
+	}{
+		"It should authorize user": {
+			query:          "?state=state&code=code",
+			stateCookie:    "state",
+			expectComplete: true,
+			wantStatus:     http.StatusOK,
+			wantResponse: `{
+				"accessToken": "access-token-123",
+				"refreshToken": "refresh-token-456",
+				"exp": 3600
+			}`,
+		},
+		"It should reject callback without state cookie": {
+			query:        "?state=state&code=code",
+			wantStatus:   http.StatusBadRequest,
+			wantResponse: `{"code": 400, "error": "Invalid OAuth state"}`,
+		},
+		"It should reject callback with state of another browser": {
+			query:        "?state=state&code=code",
+			stateCookie:  "another-state",
+			wantStatus:   http.StatusBadRequest,
+			wantResponse: `{"code": 400, "error": "Invalid OAuth state"}`,
+		},
+		"It should return unauthorized when user denied authorization": {
+			query:        "?state=state&error=access_denied",
+			stateCookie:  "state",
+			wantStatus:   http.StatusUnauthorized,
+			wantResponse: `{"code": 401, "error": "Authorization denied"}`,
+		},
+		"It should return an error for empty code": {
+			query:        "?state=state",
+			stateCookie:  "state",
+			wantStatus:   http.StatusBadRequest,
+			wantResponse: `{"code": 400, "error": "Required fields are empty or invalid"}`,
+		},
+		"It should reject expired or reused state": {
+			query:          "?state=state&code=code",
+			stateCookie:    "state",
+			expectComplete: true,
+			completeErr:    models.ErrInvalidOAuthState,
+			wantStatus:     http.StatusBadRequest,
+			wantResponse:   `{"code": 400, "error": "Invalid OAuth state"}`,
+		},
+		"It should return unauthorized for rejected code": {
+			query:          "?state=state&code=code",
+			stateCookie:    "state",
+			expectComplete: true,
+			completeErr:    models.ErrInvalidAuthToken,
+			wantStatus:     http.StatusUnauthorized,
+			wantResponse:   `{"code": 401, "error": "Invalid authorization code"}`,
+		},
+		"It should return internal server error": {
+			query:          "?state=state&code=code",
+			stateCookie:    "state",
+			expectComplete: true,
+			completeErr:    errors.New("test error"),
+			wantStatus:     http.StatusInternalServerError,
+			wantResponse:   `{"code": 500, "error": "Internal Server Error"}`,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			engine, _, userAuthenticator := newOAuthHandler(t)
+
+			if testCase.expectComplete {
+				userAuthenticator.
+					EXPECT().CompleteAuthorization(gomock.Any(), "keycloak", "state", "code", client).
+					Return(responses.NewLoginResponse("access-token-123", "refresh-token-456", 3600), testCase.completeErr)
+			}
+
+			request := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodGet,
+				"/oauth/keycloak/callback"+testCase.query,
+				http.NoBody,
+			)
+			request.Header.Set("User-Agent", client.UserAgent)
+			if testCase.stateCookie != "" {
+				request.AddCookie(&http.Cookie{Name: "oauth_state", Value: testCase.stateCookie})
+			}
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, request)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Code)
+			assert.JSONEq(t, testCase.wantResponse, recorder.Body.String())
+
+			cookies := recorder.Result().Cookies()
+			require.Len(t, cookies, 1)
+			assert.Equal(t, "oauth_state", cookies[0].Name)
+			assert.Negative(t, cookies[0].MaxAge)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/background"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=password_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type passwordService interface {
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, resetToken, newPassword string) error
+	ChangePassword(ctx context.Context, userID uint, sessionID, currentPassword, newPassword string) error
+}
+
+type PasswordHandler struct {
+	passwordService passwordService
+	tasks           *background.Runner
+}
+
+// NewPasswordHandler creates the handler. Password reset emails are sent with the tasks off the request path.
+func NewPasswordHandler(passwordService passwordService, tasks *background.Runner) *PasswordHandler {
+	return &PasswordHandler{passwordService: passwordService, tasks: tasks}
+}
+
+// ForgotPassword godoc
+//
+//	@Summary		Forgot password
+//	@Description	Send a password reset link to the email. It responds the same way whether the email is registered or not
+//	@ID				password-forgot
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ForgotPasswordRequest	true	"User's email"
+//	@Success		202		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		429		{object}	responses.ErrorResponse
+//	@Router			/password/forgot [post]
+func (h *PasswordHandler) ForgotPassword(c echo.Context) error {
+	var request requests.ForgotPasswordRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	// The email is sent in the background, so that neither the response time nor a failure
+	// reveal whether the email is registered.
+	email := request.Email
+	h.tasks.Go(c.Request().Context(), "send password reset email", func(ctx context.Context) error {
+		return h.passwordService.ForgotPassword(ctx, email)
+	})
+
+	return c.JSON(http.StatusAccepted, responses.NewMessageResponse("If the email is registered, a password reset link is sent"))
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset password
+//	@Description	Set a new password with the token from the password reset link. All sessions of the user are revoked.
+//	@Description	When the new password breaks the password policy, the token can be used again
+//	@ID				password-reset
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ResetPasswordRequest	true	"Reset token and new password"
+//	@Success		200		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Router			/password/reset [post]
+func (h *PasswordHandler) ResetPassword(c echo.Context) error {
+	var request requests.ResetPasswordRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, invalidRequestResponse(err))
+	}
+
+	err := h.passwordService.ResetPassword(c.Request().Context(), request.Token, request.Password)
+	switch {
+	case errors.Is(err, models.ErrInvalidResetToken):
+		errorResponse := responses.NewErrorResponse("Invalid or expired password reset token", http.StatusBadRequest)
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	case errors.Is(err, models.ErrWeakPassword):
+		return c.JSON(http.StatusBadRequest, weakPasswordResponse(err))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewMessageResponse("Password successfully reset"))
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change password
+//	@Description	Change the password of the user. All other sessions of the user are revoked
+//	@ID				password-change
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ChangePasswordRequest	true	"Current and new password"
+//	@Success		200		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		401		{object}	responses.ErrorResponse
+//	@Failure		403		{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/password [put]
+func (h *PasswordHandler) ChangePassword(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	var request requests.ChangePasswordRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, invalidRequestResponse(err))
+	}
+
+	err = h.passwordService.ChangePassword(
+		c.Request().Context(),
+		claims.ID,
+		claims.SessionID,
+		request.CurrentPassword,
+		request.Password,
+	)
+	switch {
+	case errors.Is(err, models.ErrInvalidPassword):
+		return c.JSON(http.StatusForbidden, responses.NewErrorResponse("Current password is invalid", http.StatusForbidden))
+	case errors.Is(err, models.ErrWeakPassword):
+		return c.JSON(http.StatusBadRequest, weakPasswordResponse(err))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewMessageResponse("Password successfully changed"))
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: password_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=password_handler.go -destination=password_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpasswordService is a mock of passwordService interface.
+type MockpasswordService struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockpasswordServiceMockRecorder is the mock recorder for MockpasswordService.
+type MockpasswordServiceMockRecorder struct {
+	mock *MockpasswordService
+}
+
+// NewMockpasswordService creates a new mock instance.
+func NewMockpasswordService(ctrl *gomock.Controller) *MockpasswordService {
+	mock := &MockpasswordService{ctrl: ctrl}
+	mock.recorder = &MockpasswordServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordService) EXPECT() *MockpasswordServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockpasswordService) ChangePassword(ctx context.Context, userID uint, sessionID, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, sessionID, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockpasswordServiceMockRecorder) ChangePassword(ctx, userID, sessionID, currentPassword, newPassword any) *MockpasswordServiceChangePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockpasswordService)(nil).ChangePassword), ctx, userID, sessionID, currentPassword, newPassword)
+	return &MockpasswordServiceChangePasswordCall{Call: call}
+}
+
+// MockpasswordServiceChangePasswordCall wrap *gomock.Call
+type MockpasswordServiceChangePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordServiceChangePasswordCall) Return(arg0 error) *MockpasswordServiceChangePasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordServiceChangePasswordCall) Do(f func(context.Context, uint, string, string, string) error) *MockpasswordServiceChangePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordServiceChangePasswordCall) DoAndReturn(f func(context.Context, uint, string, string, string) error) *MockpasswordServiceChangePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ForgotPassword mocks base method.
+func (m *MockpasswordService) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockpasswordServiceMockRecorder) ForgotPassword(ctx, email any) *MockpasswordServiceForgotPasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockpasswordService)(nil).ForgotPassword), ctx, email)
+	return &MockpasswordServiceForgotPasswordCall{Call: call}
+}
+
+// MockpasswordServiceForgotPasswordCall wrap *gomock.Call
+type MockpasswordServiceForgotPasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordServiceForgotPasswordCall) Return(arg0 error) *MockpasswordServiceForgotPasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordServiceForgotPasswordCall) Do(f func(context.Context, string) error) *MockpasswordServiceForgotPasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordServiceForgotPasswordCall) DoAndReturn(f func(context.Context, string) error) *MockpasswordServiceForgotPasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetPassword mocks base method.
+func (m *MockpasswordService) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, resetToken, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockpasswordServiceMockRecorder) ResetPassword(ctx, resetToken, newPassword any) *MockpasswordServiceResetPasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockpasswordService)(nil).ResetPassword), ctx, resetToken, newPassword)
+	return &MockpasswordServiceResetPasswordCall{Call: call}
+}
+
+// MockpasswordServiceResetPasswordCall wrap *gomock.Call
+type MockpasswordServiceResetPasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordServiceResetPasswordCall) Return(arg0 error) *MockpasswordServiceResetPasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordServiceResetPasswordCall) Do(f func(context.Context, string, string) error) *MockpasswordServiceResetPasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordServiceResetPasswordCall) DoAndReturn(f func(context.Context, string, string) error) *MockpasswordServiceResetPasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
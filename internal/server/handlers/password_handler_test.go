@@ -0,0 +1,313 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/background"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newPasswordHandler(t *testing.T) (*handlers.PasswordHandler, *MockpasswordService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	passwordService := NewMockpasswordService(ctrl)
+	tasks := background.NewRunner()
+	passwordHandler := handlers.NewPasswordHandler(passwordService, tasks)
+
+	// Tasks are finished before the mock controller checks the expectations.
+	// The test context is canceled by then already.
+	t.Cleanup(func() {
+		assert.NoError(t, tasks.Wait(context.Background()))
+	})
+
+	return passwordHandler, passwordService
+}
+
+func TestPasswordHandler_ForgotPassword(t *testing.T) {
+	testCases := map[string]struct {
+		setExpectations func(passwordService *MockpasswordService)
+		request         requests.ForgotPasswordRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when email is invalid": {
+			setExpectations: func(*MockpasswordService) {},
+			request:         requests.ForgotPasswordRequest{Email: "invalid_email"},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should accept forgot password request even when sending fails": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ForgotPassword(gomock.Any(), "example@email.com").
+					Return(errors.New("test error"))
+			},
+			request:    requests.ForgotPasswordRequest{Email: "example@email.com"},
+			wantStatus: http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "If the email is registered, a password reset link is sent",
+			},
+		},
+		"It should accept forgot password request": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ForgotPassword(gomock.Any(), "example@email.com").
+					Return(nil)
+			},
+			request:    requests.ForgotPasswordRequest{Email: "example@email.com"},
+			wantStatus: http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "If the email is registered, a password reset link is sent",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			passwordHandler, passwordService := newPasswordHandler(t)
+
+			testCase.setExpectations(passwordService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/password/forgot", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = passwordHandler.ForgotPassword(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestPasswordHandler_ResetPassword(t *testing.T) {
+	resetRequest := requests.ResetPasswordRequest{Token: "reset-token", Password: "new-password"}
+
+	testCases := map[string]struct {
+		setExpectations func(passwordService *MockpasswordService)
+		request         requests.ResetPasswordRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when password is empty": {
+			setExpectations: func(*MockpasswordService) {},
+			request:         requests.ResetPasswordRequest{Token: "reset-token"},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:   http.StatusBadRequest,
+				Error:  "Required fields are empty or invalid",
+				Fields: map[string]string{"password": "cannot be blank"},
+			},
+		},
+		"It should respond with a 400 status code when password breaks the policy": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ResetPassword(gomock.Any(), "reset-token", "new-password").
+					Return(&models.PasswordPolicyError{Violations: []string{"must contain a digit", "must contain a symbol"}})
+			},
+			request:    resetRequest,
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:   http.StatusBadRequest,
+				Error:  "Password doesn't meet the password policy",
+				Fields: map[string]string{"password": "must contain a digit; must contain a symbol"},
+			},
+		},
+		"It should respond with a 400 status code when token is invalid": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ResetPassword(gomock.Any(), "reset-token", "new-password").
+					Return(errors.Join(models.ErrInvalidResetToken, errors.New("test error")))
+			},
+			request:    resetRequest,
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Invalid or expired password reset token",
+			},
+		},
+		"It should respond with a 500 status code when reset fails": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ResetPassword(gomock.Any(), "reset-token", "new-password").
+					Return(errors.New("test error"))
+			},
+			request:    resetRequest,
+			wantStatus: http.StatusInternalServerError,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusInternalServerError,
+				Error: "Internal Server Error",
+			},
+		},
+		"It should reset password": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ResetPassword(gomock.Any(), "reset-token", "new-password").
+					Return(nil)
+			},
+			request:    resetRequest,
+			wantStatus: http.StatusOK,
+			wantResponse: responses.MessageResponse{
+				Message: "Password successfully reset",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			passwordHandler, passwordService := newPasswordHandler(t)
+
+			testCase.setExpectations(passwordService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/password/reset", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = passwordHandler.ResetPassword(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestPasswordHandler_ChangePassword(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1, SessionID: "session-id"}}
+
+	changeRequest := requests.ChangePasswordRequest{CurrentPassword: "current-password", Password: "new-password"}
+
+	testCases := map[string]struct {
+		setExpectations func(passwordService *MockpasswordService)
+		request         requests.ChangePasswordRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when new password is empty": {
+			setExpectations: func(*MockpasswordService) {},
+			request:         requests.ChangePasswordRequest{CurrentPassword: "current-password"},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:   http.StatusBadRequest,
+				Error:  "Required fields are empty or invalid",
+				Fields: map[string]string{"password": "cannot be blank"},
+			},
+		},
+		"It should respond with a 400 status code when new password breaks the policy": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ChangePassword(gomock.Any(), uint(1), "session-id", "current-password", "new-password").
+					Return(fmt.Errorf("check new password: %w", &models.PasswordPolicyError{Violations: []string{"must contain a digit"}}))
+			},
+			request:    changeRequest,
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:   http.StatusBadRequest,
+				Error:  "Password doesn't meet the password policy",
+				Fields: map[string]string{"password": "must contain a digit"},
+			},
+		},
+		"It should respond with a 403 status code when current password is invalid": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ChangePassword(gomock.Any(), uint(1), "session-id", "current-password", "new-password").
+					Return(models.ErrInvalidPassword)
+			},
+			request:    changeRequest,
+			wantStatus: http.StatusForbidden,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusForbidden,
+				Error: "Current password is invalid",
+			},
+		},
+		"It should change password": {
+			setExpectations: func(passwordService *MockpasswordService) {
+				passwordService.
+					EXPECT().
+					ChangePassword(gomock.Any(), uint(1), "session-id", "current-password", "new-password").
+					Return(nil)
+			},
+			request:    changeRequest,
+			wantStatus: http.StatusOK,
+			wantResponse: responses.MessageResponse{
+				Message: "Password successfully changed",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			passwordHandler, passwordService := newPasswordHandler(t)
+
+			testCase.setExpectations(passwordService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPut, "/me/password", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("user", authClaims)
+
+			err = passwordHandler.ChangePassword(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -19,7 +20,7 @@ import (
 
 type postService interface {
 	Create(ctx context.Context, post *models.Post) error
-	GetPosts(ctx context.Context) ([]models.Post, error)
+	GetPosts(ctx context.Context, request domain.GetPostsRequest) (domain.PostsPage, error)
 	GetPost(ctx context.Context, id uint) (models.Post, error)
 	UpdateByUser(ctx context.Context, request domain.UpdatePostRequest) (*models.Post, error)
 	DeleteByUser(ctx context.Context, request domain.DeletePostRequest) error
@@ -77,21 +78,57 @@ func (p *PostHandlers) CreatePost(c echo.Context) error {
 // GetPosts godoc
 //
 //	@Summary		Get posts
-//	@Description	Get the list of all posts
+//	@Description	Get a page of posts. Pass next_cursor of the response as the cursor to get the next page.
+//	@Description	At most 100 posts are returned per page regardless of the limit
 //	@ID				posts-get
 //	@Tags			Posts Actions
 //	@Produce		json
-//	@Success		200	{array}	responses.PostResponse
+//	@Param			limit			query		int		false	"Page size, 20 by default"
+//	@Param			cursor			query		string	false	"Cursor of the page"
+//	@Param			sort			query		string	false	"Sort field: created_at, updated_at or title"
+//	@Param			order			query		string	false	"Sort order: asc or desc"
+//	@Param			author			query		int		false	"Author ID"
+//	@Param			created_from	query		string	false	"Minimal creation time in RFC 3339 format"
+//	@Param			created_to		query		string	false	"Maximal creation time in RFC 3339 format"
+//	@Success		200				{object}	responses.PostsPageResponse
+//	@Failure		400				{object}	responses.ErrorResponse
+//	@Failure		500				{object}	responses.ErrorResponse
 //	@Security		ApiKeyAuth
 //	@Router			/posts [get]
 func (p *PostHandlers) GetPosts(c echo.Context) error {
-	posts, err := p.postService.GetPosts(c.Request().Context())
-	if err != nil {
-		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("Failed to get all posts: "+err.Error(), http.StatusNotFound))
+	var getPostsRequest requests.GetPostsRequest
+	if err := c.Bind(&getPostsRequest); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request: "+err.Error(), http.StatusBadRequest))
+	}
+
+	if err := getPostsRequest.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Query parameters are not valid", http.StatusBadRequest))
+	}
+
+	request := domain.GetPostsRequest{
+		Filter: domain.PostFilter{
+			AuthorID:    getPostsRequest.Author,
+			CreatedFrom: getPostsRequest.CreatedFrom,
+			CreatedTo:   getPostsRequest.CreatedTo,
+		},
+		Sort: domain.PostSort{
+			Field:      getPostsRequest.Sort,
+			Descending: getPostsRequest.Order != "asc",
+		},
+		Limit:  getPostsRequest.Limit,
+		Cursor: getPostsRequest.Cursor,
+	}
+
+	page, err := p.postService.GetPosts(c.Request().Context(), request)
+	switch {
+	case errors.Is(err, models.ErrInvalidCursor):
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Invalid cursor", http.StatusBadRequest))
+	case err != nil:
+		slog.ErrorContext(c.Request().Context(), "Failed to get posts", "err", err)
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
 	}
 
-	response := responses.NewPostResponse(posts)
-	return c.JSON(http.StatusOK, response)
+	return c.JSON(http.StatusOK, responses.NewPostsPageResponse(page.Posts, page.NextCursor))
 }
 
 // UpdatePost godoc
@@ -135,7 +172,7 @@ func (p *PostHandlers) UpdatePost(c echo.Context) error {
 	}
 
 	_, err = p.postService.UpdateByUser(c.Request().Context(), domain.UpdatePostRequest{
-		UserID:  auth.ID,
+		Actor:   newActor(auth),
 		PostID:  postID,
 		Title:   updatePostRequest.Title,
 		Content: updatePostRequest.Content,
@@ -183,7 +220,7 @@ func (p *PostHandlers) DeletePost(c echo.Context) error {
 	}
 
 	err = p.postService.DeleteByUser(c.Request().Context(), domain.DeletePostRequest{
-		UserID: auth.ID,
+		Actor:  newActor(auth),
 		PostID: postID,
 	})
 	if err != nil {
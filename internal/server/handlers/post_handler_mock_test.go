@@ -158,18 +158,18 @@ func (c *MockpostServiceGetPostCall) DoAndReturn(f func(context.Context, uint) (
 }
 
 // GetPosts mocks base method.
-func (m *MockpostService) GetPosts(ctx context.Context) ([]models.Post, error) {
+func (m *MockpostService) GetPosts(ctx context.Context, request domain.GetPostsRequest) (domain.PostsPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPosts", ctx)
-	ret0, _ := ret[0].([]models.Post)
+	ret := m.ctrl.Call(m, "GetPosts", ctx, request)
+	ret0, _ := ret[0].(domain.PostsPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPosts indicates an expected call of GetPosts.
-func (mr *MockpostServiceMockRecorder) GetPosts(ctx any) *MockpostServiceGetPostsCall {
+func (mr *MockpostServiceMockRecorder) GetPosts(ctx, request any) *MockpostServiceGetPostsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*MockpostService)(nil).GetPosts), ctx)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*MockpostService)(nil).GetPosts), ctx, request)
 	return &MockpostServiceGetPostsCall{Call: call}
 }
 
@@ -179,19 +179,19 @@ type MockpostServiceGetPostsCall struct {
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockpostServiceGetPostsCall) Return(arg0 []models.Post, arg1 error) *MockpostServiceGetPostsCall {
+func (c *MockpostServiceGetPostsCall) Return(arg0 domain.PostsPage, arg1 error) *MockpostServiceGetPostsCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockpostServiceGetPostsCall) Do(f func(context.Context) ([]models.Post, error)) *MockpostServiceGetPostsCall {
+func (c *MockpostServiceGetPostsCall) Do(f func(context.Context, domain.GetPostsRequest) (domain.PostsPage, error)) *MockpostServiceGetPostsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockpostServiceGetPostsCall) DoAndReturn(f func(context.Context) ([]models.Post, error)) *MockpostServiceGetPostsCall {
+func (c *MockpostServiceGetPostsCall) DoAndReturn(f func(context.Context, domain.GetPostsRequest) (domain.PostsPage, error)) *MockpostServiceGetPostsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
@@ -132,8 +134,6 @@ func TestPostHandler_CreatePost(t *testing.T) {
 }
 
 func TestPostHandler_GetPosts(t *testing.T) {
-	postHandler, postService := newPostHandler(t)
-
 	posts := []models.Post{{
 		Model: gorm.Model{
 			ID: 100,
@@ -145,54 +145,121 @@ func TestPostHandler_GetPosts(t *testing.T) {
 			Model: gorm.Model{
 				ID: 200,
 			},
-			Email:    "example@email.com",
+			Email:    "example@example.com",
 			Name:     "example-name",
 			Password: "password",
 		},
 	}}
 
-	wantResponse := []responses.PostResponse{{
-		ID:       100,
-		Title:    "post-title",
-		Content:  "post-content",
-		Username: "example-name",
-	}}
+	authorID := uint(200)
+	createdFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
 
-	postService.
-		EXPECT().
-		GetPosts(gomock.Any()).
-		Return(posts, nil)
+	testCases := map[string]struct {
+		query           string
+		setExpectations func(postService *MockpostService)
+		wantStatus      int
+		wantResponse    string
+	}{
+		"It should respond with the first page of newest posts by default": {
+			setExpectations: func(postService *MockpostService) {
+				postService.
+					EXPECT().
+					GetPosts(gomock.Any(), domain.GetPostsRequest{Sort: domain.PostSort{Descending: true}}).
+					Return(domain.PostsPage{Posts: posts, NextCursor: "next-cursor"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: `{
+				"posts": [{"id":100,"title":"post-title","content":"post-content","username":"example-name"}],
+				"next_cursor": "next-cursor"
+			}`,
+		},
+		"It should pass pagination, sort and filters to the service": {
+			query: "?limit=10&cursor=some-cursor&sort=title&order=asc&author=200" +
+				"&created_from=2025-01-01T00:00:00Z&created_to=2025-12-31T00:00:00Z",
+			setExpectations: func(postService *MockpostService) {
+				postService.
+					EXPECT().
+					GetPosts(gomock.Any(), domain.GetPostsRequest{
+						Filter: domain.PostFilter{AuthorID: &authorID, CreatedFrom: &createdFrom, CreatedTo: &createdTo},
+						Sort:   domain.PostSort{Field: domain.PostSortTitle},
+						Limit:  10,
+						Cursor: "some-cursor",
+					}).
+					Return(domain.PostsPage{}, nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"posts":[]}`,
+		},
+		"It should respond with a 400 status code when sort field is unknown": {
+			query:           "?sort=content",
+			setExpectations: func(*MockpostService) {},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse:    `{"code":400,"error":"Query parameters are not valid"}`,
+		},
+		"It should respond with a 400 status code when created date range is inverted": {
+			query:           "?created_from=2025-12-31T00:00:00Z&created_to=2025-01-01T00:00:00Z",
+			setExpectations: func(*MockpostService) {},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse:    `{"code":400,"error":"Query parameters are not valid"}`,
+		},
+		"It should respond with a 400 status code when cursor is invalid": {
+			query: "?cursor=some-cursor",
+			setExpectations: func(postService *MockpostService) {
+				postService.
+					EXPECT().
+					GetPosts(gomock.Any(), gomock.Any()).
+					Return(domain.PostsPage{}, models.ErrInvalidCursor)
+			},
+			wantStatus:   http.StatusBadRequest,
+			wantResponse: `{"code":400,"error":"Invalid cursor"}`,
+		},
+		"It should respond with a 500 status code without error details when posts can't be fetched": {
+			setExpectations: func(postService *MockpostService) {
+				postService.
+					EXPECT().
+					GetPosts(gomock.Any(), gomock.Any()).
+					Return(domain.PostsPage{}, errors.New("execute select posts query: unknown column"))
+			},
+			wantStatus:   http.StatusInternalServerError,
+			wantResponse: `{"code":500,"error":"Internal Server Error"}`,
+		},
+	}
 
-	request := httptest.NewRequestWithContext(
-		t.Context(),
-		http.MethodGet,
-		"/posts",
-		http.NoBody,
-	)
-	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			postHandler, postService := newPostHandler(t)
 
-	recorder := httptest.NewRecorder()
-	c := echo.New().NewContext(request, recorder)
+			testCase.setExpectations(postService)
 
-	err := postHandler.GetPosts(c)
-	require.NoError(t, err)
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/posts"+testCase.query, http.NoBody)
 
-	assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
 
-	rawWantResponse, err := json.Marshal(wantResponse)
-	require.NoError(t, err)
+			err := postHandler.GetPosts(c)
+			require.NoError(t, err)
 
-	assert.JSONEq(t, string(rawWantResponse), recorder.Body.String())
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, testCase.wantResponse, recorder.Body.String())
+		})
+	}
 }
 
 func TestPostHandler_UpdatePost(t *testing.T) {
 	const postOwnerID = 200
 
 	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{
-		ID:   postOwnerID,
-		Name: "user_name",
+		ID:    postOwnerID,
+		Name:  "user_name",
+		Roles: models.Roles{models.RoleUser},
 	}}
 
+	actor := domain.Actor{
+		UserID: postOwnerID,
+		Roles:  models.Roles{models.RoleUser},
+	}
+
 	post := models.Post{
 		Model: gorm.Model{
 			ID: 100,
@@ -222,7 +289,7 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 				postService.
 					EXPECT().
 					UpdateByUser(gomock.Any(), domain.UpdatePostRequest{
-						UserID:  postOwnerID,
+						Actor:   actor,
 						PostID:  post.ID,
 						Title:   request.Title,
 						Content: request.Content,
@@ -240,7 +307,7 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 				postService.
 					EXPECT().
 					UpdateByUser(gomock.Any(), domain.UpdatePostRequest{
-						UserID:  postOwnerID,
+						Actor:   actor,
 						PostID:  post.ID,
 						Title:   request.Title,
 						Content: request.Content,
@@ -258,7 +325,7 @@ func TestPostHandler_UpdatePost(t *testing.T) {
 				postService.
 					EXPECT().
 					UpdateByUser(gomock.Any(), domain.UpdatePostRequest{
-						UserID:  postOwnerID,
+						Actor:   actor,
 						PostID:  post.ID,
 						Title:   request.Title,
 						Content: request.Content,
@@ -311,10 +378,16 @@ func TestPostHandler_DeletePost(t *testing.T) {
 	const postOwnerID = 200
 
 	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{
-		ID:   postOwnerID,
-		Name: "user_name",
+		ID:    postOwnerID,
+		Name:  "user_name",
+		Roles: models.Roles{models.RoleUser},
 	}}
 
+	actor := domain.Actor{
+		UserID: postOwnerID,
+		Roles:  models.Roles{models.RoleUser},
+	}
+
 	post := models.Post{
 		Model: gorm.Model{
 			ID: 100,
@@ -334,7 +407,7 @@ func TestPostHandler_DeletePost(t *testing.T) {
 				postService.
 					EXPECT().
 					DeleteByUser(gomock.Any(), domain.DeletePostRequest{
-						UserID: postOwnerID,
+						Actor:  actor,
 						PostID: post.ID,
 					}).
 					Return(models.ErrPostNotFound)
@@ -350,7 +423,7 @@ func TestPostHandler_DeletePost(t *testing.T) {
 				postService.
 					EXPECT().
 					DeleteByUser(gomock.Any(), domain.DeletePostRequest{
-						UserID: postOwnerID,
+						Actor:  actor,
 						PostID: post.ID,
 					}).
 					Return(models.ErrForbidden)
@@ -366,7 +439,7 @@ func TestPostHandler_DeletePost(t *testing.T) {
 				postService.
 					EXPECT().
 					DeleteByUser(gomock.Any(), domain.DeletePostRequest{
-						UserID: postOwnerID,
+						Actor:  actor,
 						PostID: post.ID,
 					}).
 					Return(nil)
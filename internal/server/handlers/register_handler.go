@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
@@ -14,30 +15,51 @@ import (
 
 //go:generate go tool mockgen -source=$GOFILE -destination=register_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
 
+const concealedRegistrationMessage = "Check your email to complete the registration"
+
 type userRegisterer interface {
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
-	Register(ctx context.Context, request *requests.RegisterRequest) error
+	Register(ctx context.Context, request *requests.RegisterRequest) (models.User, error)
+	CheckPasswordPolicy(user *models.User, password string) error
+	ComparePassword(ctx context.Context, user *models.User, password string) error
+}
+
+type emailVerifier interface {
+	SendVerification(ctx context.Context, user *models.User) error
+	SendAccountExists(ctx context.Context, user *models.User) error
 }
 
 type RegisterHandler struct {
-	userRegisterer userRegisterer
+	userRegisterer        userRegisterer
+	emailVerifier         emailVerifier
+	concealExistingEmails bool
 }
 
-func NewRegisterHandler(userRegisterer userRegisterer) *RegisterHandler {
-	return &RegisterHandler{userRegisterer: userRegisterer}
+// NewRegisterHandler creates the handler. With concealExistingEmails registration responds the same way
+// for new and registered emails, and the owner of a registered email is notified by email instead.
+func NewRegisterHandler(userRegisterer userRegisterer, emailVerifier emailVerifier, concealExistingEmails bool) *RegisterHandler {
+	return &RegisterHandler{
+		userRegisterer:        userRegisterer,
+		emailVerifier:         emailVerifier,
+		concealExistingEmails: concealExistingEmails,
+	}
 }
 
 // Register godoc
 //
 //	@Summary		Register
-//	@Description	New user registration
+//	@Description	New user registration, a verification link is sent to the user's email.
+//	@Description	When registered emails are concealed, it responds with 202 for both new and registered emails.
+//	@Description	Invalid fields and broken password policy rules are explained in the fields of the error
 //	@ID				user-register
 //	@Tags			User Actions
 //	@Accept			json
 //	@Produce		json
 //	@Param			params	body		requests.RegisterRequest	true	"User's email, user's password"
 //	@Success		201		{object}	responses.MessageResponse
+//	@Success		202		{object}	responses.MessageResponse
 //	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		409		{object}	responses.ErrorResponse
 //	@Router			/register [post]
 func (h *RegisterHandler) Register(c echo.Context) error {
 	var registerRequest requests.RegisterRequest
@@ -46,20 +68,48 @@ func (h *RegisterHandler) Register(c echo.Context) error {
 	}
 
 	if err := registerRequest.Validate(); err != nil {
-		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+		return c.JSON(http.StatusBadRequest, invalidRequestResponse(err))
+	}
+
+	// The password is checked before the email, so that weak passwords are rejected the same way for new and registered emails.
+	newUser := &models.User{Email: registerRequest.Email, Name: registerRequest.Name}
+	if err := h.userRegisterer.CheckPasswordPolicy(newUser, registerRequest.Password); errors.Is(err, models.ErrWeakPassword) {
+		return c.JSON(http.StatusBadRequest, weakPasswordResponse(err))
+	} else if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
 	}
 
-	_, err := h.userRegisterer.GetUserByEmail(c.Request().Context(), registerRequest.Email)
-	if err == nil {
+	existingUser, err := h.userRegisterer.GetUserByEmail(c.Request().Context(), registerRequest.Email)
+	if err == nil && h.concealExistingEmails {
+		// A password is compared against a dummy hash, as long as hashing it for a new user takes,
+		// so that the response time doesn't reveal which emails are registered.
+		_ = h.userRegisterer.ComparePassword(c.Request().Context(), &models.User{}, registerRequest.Password)
+
+		if err := h.emailVerifier.SendAccountExists(c.Request().Context(), &existingUser); err != nil {
+			slog.ErrorContext(c.Request().Context(), "Failed to send account exists email", "err", err, "user_id", existingUser.ID)
+		}
+
+		return c.JSON(http.StatusAccepted, responses.NewMessageResponse(concealedRegistrationMessage))
+	} else if err == nil {
 		return c.JSON(http.StatusConflict, responses.NewErrorResponse("User already exists", http.StatusConflict))
 	} else if !errors.Is(err, models.ErrUserNotFound) {
 		errorResponse := responses.NewErrorResponse("Failed to check if user exists", http.StatusInternalServerError)
 		return c.JSON(http.StatusInternalServerError, errorResponse)
 	}
 
-	if err := h.userRegisterer.Register(c.Request().Context(), &registerRequest); err != nil {
+	user, err := h.userRegisterer.Register(c.Request().Context(), &registerRequest)
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Failed to register user", http.StatusInternalServerError))
 	}
 
+	// The user is registered already, a failed email can be sent again with the resend endpoint.
+	if err := h.emailVerifier.SendVerification(c.Request().Context(), &user); err != nil {
+		slog.ErrorContext(c.Request().Context(), "Failed to send verification email", "err", err, "user_id", user.ID)
+	}
+
+	if h.concealExistingEmails {
+		return c.JSON(http.StatusAccepted, responses.NewMessageResponse(concealedRegistrationMessage))
+	}
+
 	return c.JSON(http.StatusCreated, responses.NewMessageResponse("User successfully created"))
 }
@@ -42,6 +42,82 @@ func (m *MockuserRegisterer) EXPECT() *MockuserRegistererMockRecorder {
 	return m.recorder
 }
 
+// CheckPasswordPolicy mocks base method.
+func (m *MockuserRegisterer) CheckPasswordPolicy(user *models.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPasswordPolicy", user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPasswordPolicy indicates an expected call of CheckPasswordPolicy.
+func (mr *MockuserRegistererMockRecorder) CheckPasswordPolicy(user, password any) *MockuserRegistererCheckPasswordPolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPasswordPolicy", reflect.TypeOf((*MockuserRegisterer)(nil).CheckPasswordPolicy), user, password)
+	return &MockuserRegistererCheckPasswordPolicyCall{Call: call}
+}
+
+// MockuserRegistererCheckPasswordPolicyCall wrap *gomock.Call
+type MockuserRegistererCheckPasswordPolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRegistererCheckPasswordPolicyCall) Return(arg0 error) *MockuserRegistererCheckPasswordPolicyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRegistererCheckPasswordPolicyCall) Do(f func(*models.User, string) error) *MockuserRegistererCheckPasswordPolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRegistererCheckPasswordPolicyCall) DoAndReturn(f func(*models.User, string) error) *MockuserRegistererCheckPasswordPolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ComparePassword mocks base method.
+func (m *MockuserRegisterer) ComparePassword(ctx context.Context, user *models.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComparePassword", ctx, user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ComparePassword indicates an expected call of ComparePassword.
+func (mr *MockuserRegistererMockRecorder) ComparePassword(ctx, user, password any) *MockuserRegistererComparePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComparePassword", reflect.TypeOf((*MockuserRegisterer)(nil).ComparePassword), ctx, user, password)
+	return &MockuserRegistererComparePasswordCall{Call: call}
+}
+
+// MockuserRegistererComparePasswordCall wrap *gomock.Call
+type MockuserRegistererComparePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRegistererComparePasswordCall) Return(arg0 error) *MockuserRegistererComparePasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRegistererComparePasswordCall) Do(f func(context.Context, *models.User, string) error) *MockuserRegistererComparePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRegistererComparePasswordCall) DoAndReturn(f func(context.Context, *models.User, string) error) *MockuserRegistererComparePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockuserRegisterer) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -82,11 +158,12 @@ func (c *MockuserRegistererGetUserByEmailCall) DoAndReturn(f func(context.Contex
 }
 
 // Register mocks base method.
-func (m *MockuserRegisterer) Register(ctx context.Context, request *requests.RegisterRequest) error {
+func (m *MockuserRegisterer) Register(ctx context.Context, request *requests.RegisterRequest) (models.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Register", ctx, request)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Register indicates an expected call of Register.
@@ -102,19 +179,119 @@ type MockuserRegistererRegisterCall struct {
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockuserRegistererRegisterCall) Return(arg0 error) *MockuserRegistererRegisterCall {
+func (c *MockuserRegistererRegisterCall) Return(arg0 models.User, arg1 error) *MockuserRegistererRegisterCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRegistererRegisterCall) Do(f func(context.Context, *requests.RegisterRequest) (models.User, error)) *MockuserRegistererRegisterCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRegistererRegisterCall) DoAndReturn(f func(context.Context, *requests.RegisterRequest) (models.User, error)) *MockuserRegistererRegisterCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockemailVerifier is a mock of emailVerifier interface.
+type MockemailVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockemailVerifierMockRecorder
+	isgomock struct{}
+}
+
+// MockemailVerifierMockRecorder is the mock recorder for MockemailVerifier.
+type MockemailVerifierMockRecorder struct {
+	mock *MockemailVerifier
+}
+
+// NewMockemailVerifier creates a new mock instance.
+func NewMockemailVerifier(ctrl *gomock.Controller) *MockemailVerifier {
+	mock := &MockemailVerifier{ctrl: ctrl}
+	mock.recorder = &MockemailVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockemailVerifier) EXPECT() *MockemailVerifierMockRecorder {
+	return m.recorder
+}
+
+// SendAccountExists mocks base method.
+func (m *MockemailVerifier) SendAccountExists(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAccountExists", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAccountExists indicates an expected call of SendAccountExists.
+func (mr *MockemailVerifierMockRecorder) SendAccountExists(ctx, user any) *MockemailVerifierSendAccountExistsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAccountExists", reflect.TypeOf((*MockemailVerifier)(nil).SendAccountExists), ctx, user)
+	return &MockemailVerifierSendAccountExistsCall{Call: call}
+}
+
+// MockemailVerifierSendAccountExistsCall wrap *gomock.Call
+type MockemailVerifierSendAccountExistsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockemailVerifierSendAccountExistsCall) Return(arg0 error) *MockemailVerifierSendAccountExistsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockemailVerifierSendAccountExistsCall) Do(f func(context.Context, *models.User) error) *MockemailVerifierSendAccountExistsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockemailVerifierSendAccountExistsCall) DoAndReturn(f func(context.Context, *models.User) error) *MockemailVerifierSendAccountExistsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SendVerification mocks base method.
+func (m *MockemailVerifier) SendVerification(ctx context.Context, user *models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockemailVerifierMockRecorder) SendVerification(ctx, user any) *MockemailVerifierSendVerificationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockemailVerifier)(nil).SendVerification), ctx, user)
+	return &MockemailVerifierSendVerificationCall{Call: call}
+}
+
+// MockemailVerifierSendVerificationCall wrap *gomock.Call
+type MockemailVerifierSendVerificationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockemailVerifierSendVerificationCall) Return(arg0 error) *MockemailVerifierSendVerificationCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockuserRegistererRegisterCall) Do(f func(context.Context, *requests.RegisterRequest) error) *MockuserRegistererRegisterCall {
+func (c *MockemailVerifierSendVerificationCall) Do(f func(context.Context, *models.User) error) *MockemailVerifierSendVerificationCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockuserRegistererRegisterCall) DoAndReturn(f func(context.Context, *requests.RegisterRequest) error) *MockuserRegistererRegisterCall {
+func (c *MockemailVerifierSendVerificationCall) DoAndReturn(f func(context.Context, *models.User) error) *MockemailVerifierSendVerificationCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -28,23 +29,27 @@ func TestRegisterHandler_Register(t *testing.T) {
 		Name: "test name",
 	}
 
+	user := models.User{Email: "example@email.com", Name: "test name"}
+
 	testCases := map[string]struct {
-		setExpectations func(userRegisterer *MockuserRegisterer)
-		request         any
-		wantStatus      int
-		wantResponse    any
+		setExpectations       func(userRegisterer *MockuserRegisterer, emailVerifier *MockemailVerifier)
+		concealExistingEmails bool
+		request               any
+		wantStatus            int
+		wantResponse          any
 	}{
 		"It should return a 400 status code when received empty request": {
-			setExpectations: func(userRegisterer *MockuserRegisterer) {},
+			setExpectations: func(*MockuserRegisterer, *MockemailVerifier) {},
 			request:         map[string]any{},
 			wantStatus:      http.StatusBadRequest,
 			wantResponse: responses.ErrorResponse{
-				Code:  http.StatusBadRequest,
-				Error: "Required fields are empty or invalid",
+				Code:   http.StatusBadRequest,
+				Error:  "Required fields are empty or invalid",
+				Fields: map[string]string{"password": "cannot be blank"},
 			},
 		},
 		"It should return a 400 status code when received invalid request": {
-			setExpectations: func(userRegisterer *MockuserRegisterer) {},
+			setExpectations: func(*MockuserRegisterer, *MockemailVerifier) {},
 			request: requests.RegisterRequest{
 				BasicAuth: requests.BasicAuth{
 					Email:    "invalid_email",
@@ -54,12 +59,31 @@ func TestRegisterHandler_Register(t *testing.T) {
 			},
 			wantStatus: http.StatusBadRequest,
 			wantResponse: responses.ErrorResponse{
-				Code:  http.StatusBadRequest,
-				Error: "Required fields are empty or invalid",
+				Code:   http.StatusBadRequest,
+				Error:  "Required fields are empty or invalid",
+				Fields: map[string]string{"email": "must be a valid email address"},
+			},
+		},
+		"It should return a 400 status code when password breaks the policy": {
+			setExpectations: func(userRegisterer *MockuserRegisterer, emailVerifier *MockemailVerifier) {
+				userRegisterer.
+					EXPECT().
+					CheckPasswordPolicy(&user, "some-pass").
+					Return(&models.PasswordPolicyError{Violations: []string{"must contain a digit"}})
+			},
+			concealExistingEmails: true,
+			request:               registerRequest,
+			wantStatus:            http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:   http.StatusBadRequest,
+				Error:  "Password doesn't meet the password policy",
+				Fields: map[string]string{"password": "must contain a digit"},
 			},
 		},
 		"It should return an error if user exists": {
-			setExpectations: func(userRegisterer *MockuserRegisterer) {
+			setExpectations: func(userRegisterer *MockuserRegisterer, emailVerifier *MockemailVerifier) {
+				userRegisterer.EXPECT().CheckPasswordPolicy(&user, "some-pass").Return(nil)
+
 				userRegisterer.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "example@email.com").
@@ -72,8 +96,84 @@ func TestRegisterHandler_Register(t *testing.T) {
 				Error: "User already exists",
 			},
 		},
+		"It should notify the owner when user exists and registered emails are concealed": {
+			setExpectations: func(userRegisterer *MockuserRegisterer, emailVerifier *MockemailVerifier) {
+				userRegisterer.EXPECT().CheckPasswordPolicy(&user, "some-pass").Return(nil)
+
+				userRegisterer.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "example@email.com").
+					Return(user, nil)
+
+				userRegisterer.EXPECT().ComparePassword(gomock.Any(), &models.User{}, "some-pass").Return(models.ErrInvalidPassword)
+
+				emailVerifier.
+					EXPECT().
+					SendAccountExists(gomock.Any(), &user).
+					Return(nil)
+			},
+			concealExistingEmails: true,
+			request:               registerRequest,
+			wantStatus:            http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "Check your email to complete the registration",
+			},
+		},
+		"It should register an user with the same response when registered emails are concealed": {
+			setExpectations: func(userRegisterer *MockuserRegisterer, emailVerifier *MockemailVerifier) {
+				userRegisterer.EXPECT().CheckPasswordPolicy(&user, "some-pass").Return(nil)
+
+				userRegisterer.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "example@email.com").
+					Return(models.User{}, models.ErrUserNotFound)
+
+				userRegisterer.
+					EXPECT().
+					Register(gomock.Any(), gomock.Any()).
+					Return(user, nil)
+
+				emailVerifier.
+					EXPECT().
+					SendVerification(gomock.Any(), &user).
+					Return(nil)
+			},
+			concealExistingEmails: true,
+			request:               registerRequest,
+			wantStatus:            http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "Check your email to complete the registration",
+			},
+		},
+		"It should register an user when verification email fails": {
+			setExpectations: func(userRegisterer *MockuserRegisterer, emailVerifier *MockemailVerifier) {
+				userRegisterer.EXPECT().CheckPasswordPolicy(&user, "some-pass").Return(nil)
+
+				userRegisterer.
+					EXPECT().
+					GetUserByEmail(gomock.Any(), "example@email.com").
+					Return(models.User{}, models.ErrUserNotFound)
+
+				userRegisterer.
+					EXPECT().
+					Register(gomock.Any(), gomock.Any()).
+					Return(user, nil)
+
+				emailVerifier.
+					EXPECT().
+					SendVerification(gomock.Any(), &user).
+					Return(errors.New("test error"))
+			},
+			request:    registerRequest,
+			wantStatus: http.StatusCreated,
+			wantResponse: responses.MessageResponse{
+				Message: "User successfully created",
+			},
+		},
 		"It should register an user": {
-			setExpectations: func(userRegisterer *MockuserRegisterer) {
+			setExpectations: func(userRegisterer *MockuserRegisterer, emailVerifier *MockemailVerifier) {
+				userRegisterer.EXPECT().CheckPasswordPolicy(&user, "some-pass").Return(nil)
+
 				userRegisterer.
 					EXPECT().
 					GetUserByEmail(gomock.Any(), "example@email.com").
@@ -82,11 +182,16 @@ func TestRegisterHandler_Register(t *testing.T) {
 				userRegisterer.
 					EXPECT().
 					Register(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(_ context.Context, gotRegisterRequest *requests.RegisterRequest) error {
+					DoAndReturn(func(_ context.Context, gotRegisterRequest *requests.RegisterRequest) (models.User, error) {
 						assert.Equal(t, &registerRequest, gotRegisterRequest)
 
-						return nil
+						return user, nil
 					})
+
+				emailVerifier.
+					EXPECT().
+					SendVerification(gomock.Any(), &user).
+					Return(nil)
 			},
 			request:    registerRequest,
 			wantStatus: http.StatusCreated,
@@ -100,9 +205,10 @@ func TestRegisterHandler_Register(t *testing.T) {
 		t.Run(testName, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			userRegisterer := NewMockuserRegisterer(ctrl)
-			registerHandler := handlers.NewRegisterHandler(userRegisterer)
+			emailVerifier := NewMockemailVerifier(ctrl)
+			registerHandler := handlers.NewRegisterHandler(userRegisterer, emailVerifier, testCase.concealExistingEmails)
 
-			testCase.setExpectations(userRegisterer)
+			testCase.setExpectations(userRegisterer, emailVerifier)
 
 			rawRequest, err := json.Marshal(testCase.request)
 			require.NoError(t, err)
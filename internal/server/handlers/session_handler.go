@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=session_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type sessionService interface {
+	List(ctx context.Context, userID uint) ([]models.Session, error)
+	Revoke(ctx context.Context, userID uint, sessionID string) error
+}
+
+type SessionHandler struct {
+	sessionService sessionService
+}
+
+func NewSessionHandler(sessionService sessionService) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+// GetSessions godoc
+//
+//	@Summary		Get sessions
+//	@Description	Get the list of active sessions of the user, most recently used first
+//	@ID				sessions-get
+//	@Tags			Sessions Actions
+//	@Produce		json
+//	@Success		200	{array}		responses.SessionResponse
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/sessions [get]
+func (h *SessionHandler) GetSessions(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	sessions, err := h.sessionService.List(c.Request().Context(), claims.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewSessionsResponse(sessions, claims.SessionID))
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke session
+//	@Description	Revoke a session of the user, its refresh token and access tokens can't be used anymore
+//	@ID				sessions-revoke
+//	@Tags			Sessions Actions
+//	@Param			id	path	string	true	"Session ID"
+//	@Success		204	"No Content"
+//	@Failure		401	{object}	responses.ErrorResponse
+//	@Failure		403	{object}	responses.ErrorResponse
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeSession(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	err = h.sessionService.Revoke(c.Request().Context(), claims.ID, c.Param("id"))
+	switch {
+	case errors.Is(err, models.ErrSessionNotFound):
+		return c.JSON(http.StatusNotFound, responses.NewErrorResponse("Session not found", http.StatusNotFound))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
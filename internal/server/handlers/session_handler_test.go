@@ -0,0 +1,180 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newSessionHandler(t *testing.T) (*handlers.SessionHandler, *MocksessionService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	sessionService := NewMocksessionService(ctrl)
+	sessionHandler := handlers.NewSessionHandler(sessionService)
+
+	return sessionHandler, sessionService
+}
+
+func TestSessionHandler_GetSessions(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1, SessionID: "current-session-id"}}
+
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	sessions := []models.Session{
+		{
+			ID:         "current-session-id",
+			UserID:     1,
+			UserAgent:  "Mozilla/5.0",
+			IPAddress:  "192.0.2.1",
+			Provider:   models.LoginProviderPassword,
+			CreatedAt:  createdAt,
+			LastUsedAt: createdAt.Add(time.Hour),
+		},
+		{
+			ID:         "other-session-id",
+			UserID:     1,
+			UserAgent:  "curl/8.0",
+			IPAddress:  "198.51.100.7",
+			Provider:   models.LoginProviderMagicLink,
+			CreatedAt:  createdAt,
+			LastUsedAt: createdAt,
+		},
+	}
+
+	testCases := map[string]struct {
+		setExpectations func(sessionService *MocksessionService)
+		wantStatus      int
+		wantResponse    string
+	}{
+		"It should respond with a 500 status code when session service fails": {
+			setExpectations: func(sessionService *MocksessionService) {
+				sessionService.
+					EXPECT().
+					List(gomock.Any(), uint(1)).
+					Return(nil, errors.New("session service error"))
+			},
+			wantStatus:   http.StatusInternalServerError,
+			wantResponse: `{"code":500,"error":"Internal Server Error"}`,
+		},
+		"It should list sessions and mark the current one": {
+			setExpectations: func(sessionService *MocksessionService) {
+				sessionService.
+					EXPECT().
+					List(gomock.Any(), uint(1)).
+					Return(sessions, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: `[
+				{
+					"id": "current-session-id",
+					"userAgent": "Mozilla/5.0",
+					"ipAddress": "192.0.2.1",
+					"provider": "password",
+					"createdAt": "2025-01-02T03:04:05Z",
+					"lastUsedAt": "2025-01-02T04:04:05Z",
+					"current": true
+				},
+				{
+					"id": "other-session-id",
+					"userAgent": "curl/8.0",
+					"ipAddress": "198.51.100.7",
+					"provider": "magic_link",
+					"createdAt": "2025-01-02T03:04:05Z",
+					"lastUsedAt": "2025-01-02T03:04:05Z",
+					"current": false
+				}
+			]`,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			sessionHandler, sessionService := newSessionHandler(t)
+
+			testCase.setExpectations(sessionService)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/me/sessions", http.NoBody)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("user", authClaims)
+
+			err := sessionHandler.GetSessions(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, testCase.wantResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestSessionHandler_RevokeSession(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1, SessionID: "current-session-id"}}
+
+	testCases := map[string]struct {
+		setExpectations func(sessionService *MocksessionService)
+		wantStatus      int
+	}{
+		"It should respond with a 404 status code when session not found": {
+			setExpectations: func(sessionService *MocksessionService) {
+				sessionService.
+					EXPECT().
+					Revoke(gomock.Any(), uint(1), "other-session-id").
+					Return(models.ErrSessionNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		"It should respond with a 500 status code when session service fails": {
+			setExpectations: func(sessionService *MocksessionService) {
+				sessionService.
+					EXPECT().
+					Revoke(gomock.Any(), uint(1), "other-session-id").
+					Return(errors.New("session service error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		"It should revoke session": {
+			setExpectations: func(sessionService *MocksessionService) {
+				sessionService.
+					EXPECT().
+					Revoke(gomock.Any(), uint(1), "other-session-id").
+					Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			sessionHandler, sessionService := newSessionHandler(t)
+
+			testCase.setExpectations(sessionService)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodDelete, "/me/sessions/other-session-id", http.NoBody)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.SetPath("/me/sessions/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("other-session-id")
+			c.Set("user", authClaims)
+
+			err := sessionHandler.RevokeSession(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=token_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type introspectionService interface {
+	Introspect(ctx context.Context, token, hint string) (*responses.IntrospectionResponse, error)
+	Revoke(ctx context.Context, token, hint string) error
+}
+
+// TokenHandler lets other services validate and revoke tokens without sharing the signing keys.
+type TokenHandler struct {
+	introspectionService introspectionService
+}
+
+func NewTokenHandler(introspectionService introspectionService) *TokenHandler {
+	return &TokenHandler{introspectionService: introspectionService}
+}
+
+// Introspect godoc
+//
+//	@Summary		Introspect token
+//	@Description	Describe an access or refresh token as defined by RFC 7662. Invalid, expired and revoked tokens are inactive.
+//	@Description	Requires client credentials with HTTP Basic authentication
+//	@ID				oauth-introspect
+//	@Tags			Token Actions
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			token			formData	string	true	"Token to introspect"
+//	@Param			token_type_hint	formData	string	false	"access_token or refresh_token"
+//	@Success		200				{object}	responses.IntrospectionResponse
+//	@Failure		400				{object}	responses.ErrorResponse
+//	@Failure		401				{object}	responses.ErrorResponse
+//	@Security		BasicAuth
+//	@Router			/oauth/introspect [post]
+func (h *TokenHandler) Introspect(c echo.Context) error {
+	var request requests.TokenRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or not valid", http.StatusBadRequest))
+	}
+
+	response, err := h.introspectionService.Introspect(c.Request().Context(), request.Token, request.TokenTypeHint)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Revoke godoc
+//
+//	@Summary		Revoke token
+//	@Description	Revoke an access or refresh token as defined by RFC 7009. Revoking a refresh token revokes its session.
+//	@Description	Invalid tokens are ignored. Requires client credentials with HTTP Basic authentication
+//	@ID				oauth-revoke
+//	@Tags			Token Actions
+//	@Accept			x-www-form-urlencoded
+//	@Param			token			formData	string	true	"Token to revoke"
+//	@Param			token_type_hint	formData	string	false	"access_token or refresh_token"
+//	@Success		200				"OK"
+//	@Failure		400				{object}	responses.ErrorResponse
+//	@Failure		401				{object}	responses.ErrorResponse
+//	@Security		BasicAuth
+//	@Router			/oauth/revoke [post]
+func (h *TokenHandler) Revoke(c echo.Context) error {
+	var request requests.TokenRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or not valid", http.StatusBadRequest))
+	}
+
+	if err := h.introspectionService.Revoke(c.Request().Context(), request.Token, request.TokenTypeHint); err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.NoContent(http.StatusOK)
+}
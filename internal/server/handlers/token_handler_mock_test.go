@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: token_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=token_handler.go -destination=token_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	responses "github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockintrospectionService is a mock of introspectionService interface.
+type MockintrospectionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockintrospectionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockintrospectionServiceMockRecorder is the mock recorder for MockintrospectionService.
+type MockintrospectionServiceMockRecorder struct {
+	mock *MockintrospectionService
+}
+
+// NewMockintrospectionService creates a new mock instance.
+func NewMockintrospectionService(ctrl *gomock.Controller) *MockintrospectionService {
+	mock := &MockintrospectionService{ctrl: ctrl}
+	mock.recorder = &MockintrospectionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockintrospectionService) EXPECT() *MockintrospectionServiceMockRecorder {
+	return m.recorder
+}
+
+// Introspect mocks base method.
+func (m *MockintrospectionService) Introspect(ctx context.Context, token, hint string) (*responses.IntrospectionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Introspect", ctx, token, hint)
+	ret0, _ := ret[0].(*responses.IntrospectionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockintrospectionServiceMockRecorder) Introspect(ctx, token, hint any) *MockintrospectionServiceIntrospectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockintrospectionService)(nil).Introspect), ctx, token, hint)
+	return &MockintrospectionServiceIntrospectCall{Call: call}
+}
+
+// MockintrospectionServiceIntrospectCall wrap *gomock.Call
+type MockintrospectionServiceIntrospectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockintrospectionServiceIntrospectCall) Return(arg0 *responses.IntrospectionResponse, arg1 error) *MockintrospectionServiceIntrospectCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockintrospectionServiceIntrospectCall) Do(f func(context.Context, string, string) (*responses.IntrospectionResponse, error)) *MockintrospectionServiceIntrospectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockintrospectionServiceIntrospectCall) DoAndReturn(f func(context.Context, string, string) (*responses.IntrospectionResponse, error)) *MockintrospectionServiceIntrospectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockintrospectionService) Revoke(ctx context.Context, token, hint string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, token, hint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockintrospectionServiceMockRecorder) Revoke(ctx, token, hint any) *MockintrospectionServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockintrospectionService)(nil).Revoke), ctx, token, hint)
+	return &MockintrospectionServiceRevokeCall{Call: call}
+}
+
+// MockintrospectionServiceRevokeCall wrap *gomock.Call
+type MockintrospectionServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockintrospectionServiceRevokeCall) Return(arg0 error) *MockintrospectionServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockintrospectionServiceRevokeCall) Do(f func(context.Context, string, string) error) *MockintrospectionServiceRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockintrospectionServiceRevokeCall) DoAndReturn(f func(context.Context, string, string) error) *MockintrospectionServiceRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,153 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTokenRequest(t *testing.T, target string, form url.Values) *http.Request {
+	t.Helper()
+
+	request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, target, strings.NewReader(form.Encode()))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+
+	return request
+}
+
+func TestTokenHandler_Introspect(t *testing.T) {
+	testCases := map[string]struct {
+		form            url.Values
+		setExpectations func(introspectionService *MockintrospectionService)
+		wantStatus      int
+		wantResponse    string
+	}{
+		"It should respond with a 400 status code when token is missing": {
+			form:            url.Values{"token_type_hint": {"access_token"}},
+			setExpectations: func(*MockintrospectionService) {},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse:    `{"code":400,"error":"Required fields are empty or not valid"}`,
+		},
+		"It should respond with a 500 status code when introspection service fails": {
+			form: url.Values{"token": {"some-token"}},
+			setExpectations: func(introspectionService *MockintrospectionService) {
+				introspectionService.
+					EXPECT().
+					Introspect(gomock.Any(), "some-token", "").
+					Return(nil, errors.New("introspection service error"))
+			},
+			wantStatus:   http.StatusInternalServerError,
+			wantResponse: `{"code":500,"error":"Internal Server Error"}`,
+		},
+		"It should describe the token": {
+			form: url.Values{"token": {"some-token"}, "token_type_hint": {"access_token"}},
+			setExpectations: func(introspectionService *MockintrospectionService) {
+				introspectionService.
+					EXPECT().
+					Introspect(gomock.Any(), "some-token", "access_token").
+					Return(&responses.IntrospectionResponse{
+						Active:    true,
+						Subject:   "1",
+						Scope:     "posts:read",
+						Exp:       100,
+						TokenType: "access_token",
+					}, nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"active":true,"sub":"1","scope":"posts:read","exp":100,"token_type":"access_token"}`,
+		},
+		"It should describe inactive token": {
+			form: url.Values{"token": {"some-token"}},
+			setExpectations: func(introspectionService *MockintrospectionService) {
+				introspectionService.
+					EXPECT().
+					Introspect(gomock.Any(), "some-token", "").
+					Return(responses.NewInactiveIntrospectionResponse(), nil)
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"active":false}`,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			introspectionService := NewMockintrospectionService(ctrl)
+			tokenHandler := handlers.NewTokenHandler(introspectionService)
+
+			testCase.setExpectations(introspectionService)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(newTokenRequest(t, "/oauth/introspect", testCase.form), recorder)
+
+			err := tokenHandler.Introspect(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+			assert.JSONEq(t, testCase.wantResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestTokenHandler_Revoke(t *testing.T) {
+	testCases := map[string]struct {
+		form            url.Values
+		setExpectations func(introspectionService *MockintrospectionService)
+		wantStatus      int
+	}{
+		"It should respond with a 400 status code when token is missing": {
+			form:            url.Values{},
+			setExpectations: func(*MockintrospectionService) {},
+			wantStatus:      http.StatusBadRequest,
+		},
+		"It should respond with a 500 status code when introspection service fails": {
+			form: url.Values{"token": {"some-token"}},
+			setExpectations: func(introspectionService *MockintrospectionService) {
+				introspectionService.
+					EXPECT().
+					Revoke(gomock.Any(), "some-token", "").
+					Return(errors.New("introspection service error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		"It should revoke the token": {
+			form: url.Values{"token": {"some-token"}, "token_type_hint": {"refresh_token"}},
+			setExpectations: func(introspectionService *MockintrospectionService) {
+				introspectionService.
+					EXPECT().
+					Revoke(gomock.Any(), "some-token", "refresh_token").
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			introspectionService := NewMockintrospectionService(ctrl)
+			tokenHandler := handlers.NewTokenHandler(introspectionService)
+
+			testCase.setExpectations(introspectionService)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(newTokenRequest(t, "/oauth/revoke", testCase.form), recorder)
+
+			err := tokenHandler.Revoke(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// invalidRequestResponse explains why each invalid field of the request is invalid.
+func invalidRequestResponse(err error) responses.ErrorResponse {
+	var fields map[string]string
+
+	var validationErrors validation.Errors
+	if errors.As(err, &validationErrors) {
+		fields = make(map[string]string, len(validationErrors))
+		for field, fieldErr := range validationErrors {
+			fields[field] = fieldErr.Error()
+		}
+	}
+
+	return responses.NewFieldsErrorResponse("Required fields are empty or invalid", http.StatusBadRequest, fields)
+}
+
+// weakPasswordResponse explains which rules of the password policy the new password breaks.
+func weakPasswordResponse(err error) responses.ErrorResponse {
+	var fields map[string]string
+
+	var policyErr *models.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		fields = map[string]string{"password": strings.Join(policyErr.Violations, "; ")}
+	}
+
+	return responses.NewFieldsErrorResponse("Password doesn't meet the password policy", http.StatusBadRequest, fields)
+}
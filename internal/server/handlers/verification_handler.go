@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=verification_handler_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type verificationService interface {
+	VerifyEmail(ctx context.Context, verificationToken string) error
+	ResendVerification(ctx context.Context, email string) error
+	RequestEmailChange(ctx context.Context, userID uint, newEmail, password string) error
+	ConfirmEmailChange(ctx context.Context, changeToken string) error
+}
+
+type VerificationHandler struct {
+	verificationService verificationService
+}
+
+func NewVerificationHandler(verificationService verificationService) *VerificationHandler {
+	return &VerificationHandler{verificationService: verificationService}
+}
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify email
+//	@Description	Verify the user's email with the token from the verification link
+//	@ID				user-verify-email
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.VerifyEmailRequest	true	"Verification token"
+//	@Success		200		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Router			/verify-email [post]
+func (h *VerificationHandler) VerifyEmail(c echo.Context) error {
+	var request requests.VerifyEmailRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	err := h.verificationService.VerifyEmail(c.Request().Context(), request.Token)
+	switch {
+	case errors.Is(err, models.ErrInvalidActionToken):
+		errorResponse := responses.NewErrorResponse("Invalid or expired verification token", http.StatusBadRequest)
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewMessageResponse("Email successfully verified"))
+}
+
+// ResendVerification godoc
+//
+//	@Summary		Resend verification
+//	@Description	Send a new verification link to the email. It responds the same way whether the email is registered or not
+//	@ID				user-resend-verification
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ResendVerificationRequest	true	"User's email"
+//	@Success		202		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Router			/verify-email/resend [post]
+func (h *VerificationHandler) ResendVerification(c echo.Context) error {
+	var request requests.ResendVerificationRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	if err := h.verificationService.ResendVerification(c.Request().Context(), request.Email); err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusAccepted, responses.NewMessageResponse("If the email is registered and not verified, a verification link is sent"))
+}
+
+// ChangeEmail godoc
+//
+//	@Summary		Change email
+//	@Description	Send a link confirming the change to the new email. The email is switched once the link is followed
+//	@ID				user-change-email
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ChangeEmailRequest	true	"New email and current password"
+//	@Success		202		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		401		{object}	responses.ErrorResponse
+//	@Failure		403		{object}	responses.ErrorResponse
+//	@Failure		409		{object}	responses.ErrorResponse
+//	@Security		ApiKeyAuth
+//	@Router			/me/email [put]
+func (h *VerificationHandler) ChangeEmail(c echo.Context) error {
+	claims, err := getAuthClaims(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, responses.NewErrorResponse("Unauthorized", http.StatusUnauthorized))
+	}
+
+	var request requests.ChangeEmailRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	err = h.verificationService.RequestEmailChange(c.Request().Context(), claims.ID, request.Email, request.Password)
+	switch {
+	case errors.Is(err, models.ErrInvalidPassword):
+		return c.JSON(http.StatusForbidden, responses.NewErrorResponse("Password is invalid", http.StatusForbidden))
+	case errors.Is(err, models.ErrUserAlreadyExists):
+		return c.JSON(http.StatusConflict, responses.NewErrorResponse("User already exists", http.StatusConflict))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusAccepted, responses.NewMessageResponse("A confirmation link is sent to the new email"))
+}
+
+// ConfirmEmailChange godoc
+//
+//	@Summary		Confirm email change
+//	@Description	Switch the user's email to the new one with the token from the confirmation link
+//	@ID				user-confirm-email-change
+//	@Tags			User Actions
+//	@Accept			json
+//	@Produce		json
+//	@Param			params	body		requests.ConfirmEmailChangeRequest	true	"Confirmation token"
+//	@Success		200		{object}	responses.MessageResponse
+//	@Failure		400		{object}	responses.ErrorResponse
+//	@Failure		409		{object}	responses.ErrorResponse
+//	@Router			/email/confirm [post]
+func (h *VerificationHandler) ConfirmEmailChange(c echo.Context) error {
+	var request requests.ConfirmEmailChangeRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Failed to bind request", http.StatusBadRequest))
+	}
+
+	if err := request.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, responses.NewErrorResponse("Required fields are empty or invalid", http.StatusBadRequest))
+	}
+
+	err := h.verificationService.ConfirmEmailChange(c.Request().Context(), request.Token)
+	switch {
+	case errors.Is(err, models.ErrInvalidActionToken):
+		errorResponse := responses.NewErrorResponse("Invalid or expired confirmation token", http.StatusBadRequest)
+		return c.JSON(http.StatusBadRequest, errorResponse)
+	case errors.Is(err, models.ErrUserAlreadyExists):
+		return c.JSON(http.StatusConflict, responses.NewErrorResponse("User already exists", http.StatusConflict))
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, responses.NewErrorResponse("Internal Server Error", http.StatusInternalServerError))
+	}
+
+	return c.JSON(http.StatusOK, responses.NewMessageResponse("Email successfully changed"))
+}
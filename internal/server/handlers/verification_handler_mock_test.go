@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: verification_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=verification_handler.go -destination=verification_handler_mock_test.go -package=handlers_test -typed=true
+//
+
+// Package handlers_test is a generated GoMock package.
+package handlers_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockverificationService is a mock of verificationService interface.
+type MockverificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockverificationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockverificationServiceMockRecorder is the mock recorder for MockverificationService.
+type MockverificationServiceMockRecorder struct {
+	mock *MockverificationService
+}
+
+// NewMockverificationService creates a new mock instance.
+func NewMockverificationService(ctrl *gomock.Controller) *MockverificationService {
+	mock := &MockverificationService{ctrl: ctrl}
+	mock.recorder = &MockverificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockverificationService) EXPECT() *MockverificationServiceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockverificationService) ConfirmEmailChange(ctx context.Context, changeToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", ctx, changeToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockverificationServiceMockRecorder) ConfirmEmailChange(ctx, changeToken any) *MockverificationServiceConfirmEmailChangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockverificationService)(nil).ConfirmEmailChange), ctx, changeToken)
+	return &MockverificationServiceConfirmEmailChangeCall{Call: call}
+}
+
+// MockverificationServiceConfirmEmailChangeCall wrap *gomock.Call
+type MockverificationServiceConfirmEmailChangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockverificationServiceConfirmEmailChangeCall) Return(arg0 error) *MockverificationServiceConfirmEmailChangeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockverificationServiceConfirmEmailChangeCall) Do(f func(context.Context, string) error) *MockverificationServiceConfirmEmailChangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockverificationServiceConfirmEmailChangeCall) DoAndReturn(f func(context.Context, string) error) *MockverificationServiceConfirmEmailChangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RequestEmailChange mocks base method.
+func (m *MockverificationService) RequestEmailChange(ctx context.Context, userID uint, newEmail, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestEmailChange", ctx, userID, newEmail, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestEmailChange indicates an expected call of RequestEmailChange.
+func (mr *MockverificationServiceMockRecorder) RequestEmailChange(ctx, userID, newEmail, password any) *MockverificationServiceRequestEmailChangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestEmailChange", reflect.TypeOf((*MockverificationService)(nil).RequestEmailChange), ctx, userID, newEmail, password)
+	return &MockverificationServiceRequestEmailChangeCall{Call: call}
+}
+
+// MockverificationServiceRequestEmailChangeCall wrap *gomock.Call
+type MockverificationServiceRequestEmailChangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockverificationServiceRequestEmailChangeCall) Return(arg0 error) *MockverificationServiceRequestEmailChangeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockverificationServiceRequestEmailChangeCall) Do(f func(context.Context, uint, string, string) error) *MockverificationServiceRequestEmailChangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockverificationServiceRequestEmailChangeCall) DoAndReturn(f func(context.Context, uint, string, string) error) *MockverificationServiceRequestEmailChangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResendVerification mocks base method.
+func (m *MockverificationService) ResendVerification(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockverificationServiceMockRecorder) ResendVerification(ctx, email any) *MockverificationServiceResendVerificationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockverificationService)(nil).ResendVerification), ctx, email)
+	return &MockverificationServiceResendVerificationCall{Call: call}
+}
+
+// MockverificationServiceResendVerificationCall wrap *gomock.Call
+type MockverificationServiceResendVerificationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockverificationServiceResendVerificationCall) Return(arg0 error) *MockverificationServiceResendVerificationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockverificationServiceResendVerificationCall) Do(f func(context.Context, string) error) *MockverificationServiceResendVerificationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockverificationServiceResendVerificationCall) DoAndReturn(f func(context.Context, string) error) *MockverificationServiceResendVerificationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// VerifyEmail mocks base method.
+func (m *MockverificationService) VerifyEmail(ctx context.Context, verificationToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, verificationToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockverificationServiceMockRecorder) VerifyEmail(ctx, verificationToken any) *MockverificationServiceVerifyEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockverificationService)(nil).VerifyEmail), ctx, verificationToken)
+	return &MockverificationServiceVerifyEmailCall{Call: call}
+}
+
+// MockverificationServiceVerifyEmailCall wrap *gomock.Call
+type MockverificationServiceVerifyEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockverificationServiceVerifyEmailCall) Return(arg0 error) *MockverificationServiceVerifyEmailCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockverificationServiceVerifyEmailCall) Do(f func(context.Context, string) error) *MockverificationServiceVerifyEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockverificationServiceVerifyEmailCall) DoAndReturn(f func(context.Context, string) error) *MockverificationServiceVerifyEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
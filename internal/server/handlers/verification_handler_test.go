@@ -0,0 +1,367 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newVerificationHandler(t *testing.T) (*handlers.VerificationHandler, *MockverificationService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	verificationService := NewMockverificationService(ctrl)
+	verificationHandler := handlers.NewVerificationHandler(verificationService)
+
+	return verificationHandler, verificationService
+}
+
+func TestVerificationHandler_VerifyEmail(t *testing.T) {
+	testCases := map[string]struct {
+		setExpectations func(verificationService *MockverificationService)
+		request         requests.VerifyEmailRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when token is empty": {
+			setExpectations: func(*MockverificationService) {},
+			request:         requests.VerifyEmailRequest{},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should respond with a 400 status code when token is invalid": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					VerifyEmail(gomock.Any(), "verification-token").
+					Return(errors.Join(models.ErrInvalidActionToken, errors.New("test error")))
+			},
+			request:    requests.VerifyEmailRequest{Token: "verification-token"},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Invalid or expired verification token",
+			},
+		},
+		"It should respond with a 500 status code when verification fails": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					VerifyEmail(gomock.Any(), "verification-token").
+					Return(errors.New("test error"))
+			},
+			request:    requests.VerifyEmailRequest{Token: "verification-token"},
+			wantStatus: http.StatusInternalServerError,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusInternalServerError,
+				Error: "Internal Server Error",
+			},
+		},
+		"It should verify email": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					VerifyEmail(gomock.Any(), "verification-token").
+					Return(nil)
+			},
+			request:    requests.VerifyEmailRequest{Token: "verification-token"},
+			wantStatus: http.StatusOK,
+			wantResponse: responses.MessageResponse{
+				Message: "Email successfully verified",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			verificationHandler, verificationService := newVerificationHandler(t)
+
+			testCase.setExpectations(verificationService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/verify-email", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = verificationHandler.VerifyEmail(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestVerificationHandler_ResendVerification(t *testing.T) {
+	testCases := map[string]struct {
+		setExpectations func(verificationService *MockverificationService)
+		request         requests.ResendVerificationRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when email is invalid": {
+			setExpectations: func(*MockverificationService) {},
+			request:         requests.ResendVerificationRequest{Email: "invalid_email"},
+			wantStatus:      http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should respond with a 500 status code when sending fails": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					ResendVerification(gomock.Any(), "example@email.com").
+					Return(errors.New("test error"))
+			},
+			request:    requests.ResendVerificationRequest{Email: "example@email.com"},
+			wantStatus: http.StatusInternalServerError,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusInternalServerError,
+				Error: "Internal Server Error",
+			},
+		},
+		"It should resend verification": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					ResendVerification(gomock.Any(), "example@email.com").
+					Return(nil)
+			},
+			request:    requests.ResendVerificationRequest{Email: "example@email.com"},
+			wantStatus: http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "If the email is registered and not verified, a verification link is sent",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			verificationHandler, verificationService := newVerificationHandler(t)
+
+			testCase.setExpectations(verificationService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(
+				t.Context(),
+				http.MethodPost,
+				"/verify-email/resend",
+				bytes.NewBuffer(rawRequest),
+			)
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = verificationHandler.ResendVerification(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestVerificationHandler_ChangeEmail(t *testing.T) {
+	authClaims := &jwt.Token{Claims: &token.JwtCustomClaims{ID: 1}}
+
+	changeRequest := requests.ChangeEmailRequest{
+		BasicAuth: requests.BasicAuth{Email: "new@example.com", Password: "current-password"},
+	}
+
+	testCases := map[string]struct {
+		setExpectations func(verificationService *MockverificationService)
+		request         requests.ChangeEmailRequest
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when email is invalid": {
+			setExpectations: func(*MockverificationService) {},
+			request: requests.ChangeEmailRequest{
+				BasicAuth: requests.BasicAuth{Email: "invalid_email", Password: "current-password"},
+			},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Required fields are empty or invalid",
+			},
+		},
+		"It should respond with a 403 status code when password is invalid": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					RequestEmailChange(gomock.Any(), uint(1), "new@example.com", "current-password").
+					Return(models.ErrInvalidPassword)
+			},
+			request:    changeRequest,
+			wantStatus: http.StatusForbidden,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusForbidden,
+				Error: "Password is invalid",
+			},
+		},
+		"It should respond with a 409 status code when email is taken": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					RequestEmailChange(gomock.Any(), uint(1), "new@example.com", "current-password").
+					Return(models.ErrUserAlreadyExists)
+			},
+			request:    changeRequest,
+			wantStatus: http.StatusConflict,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusConflict,
+				Error: "User already exists",
+			},
+		},
+		"It should send confirmation link": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					RequestEmailChange(gomock.Any(), uint(1), "new@example.com", "current-password").
+					Return(nil)
+			},
+			request:    changeRequest,
+			wantStatus: http.StatusAccepted,
+			wantResponse: responses.MessageResponse{
+				Message: "A confirmation link is sent to the new email",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			verificationHandler, verificationService := newVerificationHandler(t)
+
+			testCase.setExpectations(verificationService)
+
+			rawRequest, err := json.Marshal(testCase.request)
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPut, "/me/email", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+			c.Set("user", authClaims)
+
+			err = verificationHandler.ChangeEmail(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
+
+func TestVerificationHandler_ConfirmEmailChange(t *testing.T) {
+	testCases := map[string]struct {
+		setExpectations func(verificationService *MockverificationService)
+		wantStatus      int
+		wantResponse    any
+	}{
+		"It should respond with a 400 status code when token is invalid": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					ConfirmEmailChange(gomock.Any(), "change-token").
+					Return(models.ErrInvalidActionToken)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusBadRequest,
+				Error: "Invalid or expired confirmation token",
+			},
+		},
+		"It should respond with a 409 status code when email has been taken": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					ConfirmEmailChange(gomock.Any(), "change-token").
+					Return(models.ErrUserAlreadyExists)
+			},
+			wantStatus: http.StatusConflict,
+			wantResponse: responses.ErrorResponse{
+				Code:  http.StatusConflict,
+				Error: "User already exists",
+			},
+		},
+		"It should change email": {
+			setExpectations: func(verificationService *MockverificationService) {
+				verificationService.
+					EXPECT().
+					ConfirmEmailChange(gomock.Any(), "change-token").
+					Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: responses.MessageResponse{
+				Message: "Email successfully changed",
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			verificationHandler, verificationService := newVerificationHandler(t)
+
+			testCase.setExpectations(verificationService)
+
+			rawRequest, err := json.Marshal(requests.ConfirmEmailChangeRequest{Token: "change-token"})
+			require.NoError(t, err)
+
+			request := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/email/confirm", bytes.NewBuffer(rawRequest))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			recorder := httptest.NewRecorder()
+			c := echo.New().NewContext(request, recorder)
+
+			err = verificationHandler.ConfirmEmailChange(c)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantStatus, recorder.Result().StatusCode)
+
+			wantResponse, err := json.Marshal(testCase.wantResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantResponse), recorder.Body.String())
+		})
+	}
+}
@@ -1,6 +1,14 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 	"github.com/nix-united/golang-echo-boilerplate/internal/slogx"
 
@@ -9,33 +17,167 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-// authContextKey is a key to use in context to propagate user data between middlewares.
-const authContextKey = "user"
+const (
+	// authContextKey is a key to use in context to propagate user data between middlewares.
+	authContextKey = "user"
+
+	// apiKeyAuthScheme is the Authorization header scheme for API keys, e.g. "Authorization: ApiKey ak_...".
+	apiKeyAuthScheme = "ApiKey"
+)
+
+type accessTokenDenylist interface {
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+type sessionRevocationChecker interface {
+	IsRevoked(ctx context.Context, sessionID string) (bool, error)
+}
 
-func NewAuthMiddleware(accessSecret string) echo.MiddlewareFunc {
+type apiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*token.JwtCustomClaims, error)
+}
+
+// NewAuthMiddleware authenticates requests with access tokens or API keys.
+// The keyFunc selects a key to verify the token signature with, see [token.Service.AccessTokenKeyfunc].
+// The access token is read from the Authorization header or, when there is no such header and cookieAuthEnabled
+// is set, from the cookie of browser clients, see [authcookie].
+//
+// Access tokens are rejected once they are revoked or their session is revoked, e.g. on logout from all devices.
+//
+// Requests authenticated with an API key get the same claims in the context as requests with an access token,
+// so handlers don't need to know how the request was authenticated.
+func NewAuthMiddleware(
+	keyFunc jwt.Keyfunc,
+	denylist accessTokenDenylist,
+	sessions sessionRevocationChecker,
+	apiKeys apiKeyAuthenticator,
+	cookieAuthEnabled bool,
+) echo.MiddlewareFunc {
 	echoJWTConfig := echojwt.Config{
 		NewClaimsFunc: func(echo.Context) jwt.Claims {
 			return new(token.JwtCustomClaims)
 		},
-		SigningKey: []byte(accessSecret),
+		KeyFunc: keyFunc,
 		SuccessHandler: func(c echo.Context) {
-			user, ok := c.Get(authContextKey).(*jwt.Token)
-			if !ok {
-				return
+			if claims, ok := authClaims(c); ok {
+				setUserContext(c, claims)
 			}
+		},
+		ContextKey: authContextKey,
+	}
 
-			claims, ok := user.Claims.(*token.JwtCustomClaims)
+	jwtMiddleware := echojwt.WithConfig(echoJWTConfig)
+
+	// The cookie is never a fallback for an invalid header, as only cookie requests are checked for CSRF.
+	echoJWTConfig.TokenLookup = "cookie:" + authcookie.AccessTokenName
+	jwtCookieMiddleware := echojwt.WithConfig(echoJWTConfig)
+	revocationMiddleware := newRevocationMiddleware(denylist, sessions)
+	apiKeyMiddleware := newAPIKeyMiddleware(apiKeys)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withAccessToken := jwtMiddleware(revocationMiddleware(next))
+		withAccessTokenCookie := jwtCookieMiddleware(revocationMiddleware(next))
+		withAPIKey := apiKeyMiddleware(next)
+
+		return func(c echo.Context) error {
+			if _, ok := apiKeyFromHeader(c); ok {
+				return withAPIKey(c)
+			}
+
+			if cookieAuthEnabled && c.Request().Header.Get(echo.HeaderAuthorization) == "" && authcookie.HasTokens(c.Request()) {
+				return withAccessTokenCookie(c)
+			}
+
+			return withAccessToken(c)
+		}
+	}
+}
+
+// newAPIKeyMiddleware authenticates requests with API keys.
+func newAPIKeyMiddleware(apiKeys apiKeyAuthenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key, _ := apiKeyFromHeader(c)
+
+			claims, err := apiKeys.Authenticate(c.Request().Context(), key)
+			if errors.Is(err, models.ErrInvalidAuthToken) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired api key")
+			} else if err != nil {
+				return fmt.Errorf("authenticate api key: %w", err)
+			}
+
+			c.Set(authContextKey, &jwt.Token{Claims: claims, Valid: true})
+			setUserContext(c, claims)
+
+			return next(c)
+		}
+	}
+}
+
+func apiKeyFromHeader(c echo.Context) (string, bool) {
+	scheme, key, ok := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, apiKeyAuthScheme) {
+		return "", false
+	}
+
+	return key, true
+}
+
+// setUserContext enriches logs and context execution with user ID, and with impersonator ID while impersonating.
+func setUserContext(c echo.Context, claims *token.JwtCustomClaims) {
+	ctx := c.Request().Context()
+	ctx = slogx.ContextWithUserID(ctx, claims.ID)
+	if impersonatorID, ok := claims.ImpersonatorID(); ok {
+		ctx = slogx.ContextWithImpersonatorID(ctx, impersonatorID)
+	}
+	c.SetRequest(c.Request().WithContext(ctx))
+}
+
+// newRevocationMiddleware rejects access tokens that were revoked before they expired, e.g. on logout,
+// and access tokens of revoked sessions.
+func newRevocationMiddleware(denylist accessTokenDenylist, sessions sessionRevocationChecker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := authClaims(c)
 			if !ok {
-				return
+				return next(c)
 			}
 
-			// Enrich logs and context execution with user ID.
-			ctx := c.Request().Context()
-			ctx = slogx.ContextWithUserID(ctx, claims.ID)
-			c.SetRequest(c.Request().WithContext(ctx))
-		},
-		ContextKey: authContextKey,
+			if claims.RegisteredClaims.ID != "" {
+				revoked, err := denylist.IsRevoked(c.Request().Context(), claims.RegisteredClaims.ID)
+				if err != nil {
+					return fmt.Errorf("check if access token is revoked: %w", err)
+				}
+
+				if revoked {
+					return echo.NewHTTPError(http.StatusUnauthorized, "access token has been revoked")
+				}
+			}
+
+			// Tokens issued before sessions were introduced, and impersonation tokens, have no session.
+			if claims.SessionID != "" {
+				revoked, err := sessions.IsRevoked(c.Request().Context(), claims.SessionID)
+				if err != nil {
+					return fmt.Errorf("check if session is revoked: %w", err)
+				}
+
+				if revoked {
+					return echo.NewHTTPError(http.StatusUnauthorized, "session has been revoked")
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func authClaims(c echo.Context) (*token.JwtCustomClaims, bool) {
+	user, ok := c.Get(authContextKey).(*jwt.Token)
+	if !ok {
+		return nil, false
 	}
 
-	return echojwt.WithConfig(echoJWTConfig)
+	claims, ok := user.Claims.(*token.JwtCustomClaims)
+
+	return claims, ok
 }
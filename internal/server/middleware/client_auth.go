@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/slogx"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// NewClientAuth authenticates other services by client credentials sent with HTTP Basic authentication,
+// clients map client IDs to their secrets. The client ID is appended to all log messages of the request.
+func NewClientAuth(clients map[string]string) echo.MiddlewareFunc {
+	return echomiddleware.BasicAuthWithConfig(echomiddleware.BasicAuthConfig{
+		Realm: "token",
+		Validator: func(clientID, clientSecret string, c echo.Context) (bool, error) {
+			secret, ok := clients[clientID]
+			if !ok || secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(clientSecret)) != 1 {
+				return false, nil
+			}
+
+			ctx := slogx.ContextWithBaggage(c.Request().Context(), "client_id", clientID)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return true, nil
+		},
+	})
+}
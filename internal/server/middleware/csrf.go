@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/authcookie"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CSRF rejects mutating requests authenticated with token cookies unless they repeat the CSRF token cookie
+// in the header, see [authcookie]. Requests with the Authorization header aren't checked: browsers never add
+// the header to cross-site requests on their own, so API clients keep working without CSRF tokens.
+func CSRF(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := c.Request()
+
+		switch request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return next(c)
+		}
+
+		if request.Header.Get(echo.HeaderAuthorization) != "" || !authcookie.HasTokens(request) {
+			return next(c)
+		}
+
+		if !authcookie.ValidCSRF(request) {
+			return echo.NewHTTPError(http.StatusForbidden, "invalid csrf token")
+		}
+
+		return next(c)
+	}
+}
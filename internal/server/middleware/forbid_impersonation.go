@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ForbidImpersonation rejects the request when the token was issued for an impersonator,
+// so that sensitive operations, e.g. a password change, can be performed only by the user.
+// It must be used after the auth middleware.
+func ForbidImpersonation(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, ok := authClaims(c)
+		if !ok {
+			return echo.NewHTTPError(http.StatusUnauthorized, "missing access token")
+		}
+
+		if claims.Impersonated() {
+			return echo.NewHTTPError(http.StatusForbidden, "not allowed while impersonating")
+		}
+
+		return next(c)
+	}
+}
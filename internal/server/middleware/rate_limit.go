@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"golang.org/x/time/rate"
+)
+
+// RateLimit allows up to requestsPerMinute requests from a single client IP and responds with 429 to the rest.
+// Limits are kept in memory, so they are not shared between replicas of the service.
+// Zero or a negative requestsPerMinute disables the limit, e.g. when a proxy in front of the service limits requests.
+func RateLimit(requestsPerMinute int) echo.MiddlewareFunc {
+	if requestsPerMinute <= 0 {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}
+	}
+
+	interval := time.Minute / time.Duration(requestsPerMinute)
+	retryAfter := strconv.Itoa(int(math.Ceil(interval.Seconds())))
+
+	return echomiddleware.RateLimiterWithConfig(echomiddleware.RateLimiterConfig{
+		Store: echomiddleware.NewRateLimiterMemoryStoreWithConfig(echomiddleware.RateLimiterMemoryStoreConfig{
+			Rate:      rate.Every(interval),
+			Burst:     requestsPerMinute,
+			ExpiresIn: time.Minute,
+		}),
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			return c.RealIP(), nil
+		},
+		DenyHandler: func(c echo.Context, _ string, _ error) error {
+			c.Response().Header().Set("Retry-After", retryAfter)
+			return echo.NewHTTPError(http.StatusTooManyRequests, "too many requests")
+		},
+	})
+}
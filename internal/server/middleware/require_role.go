@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireRole allows the request only if the authenticated user has any of the given roles.
+// It must be used after the auth middleware.
+func RequireRole(roles ...models.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := authClaims(c)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "missing access token")
+			}
+
+			if !claims.Roles.Has(roles...) {
+				return echo.NewHTTPError(http.StatusForbidden, "insufficient role")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequireScope allows the request only if the token has all the given scopes.
+// Otherwise, it responds with 403 and an "insufficient_scope" challenge as described in RFC 6750.
+// It must be used after the auth middleware.
+func RequireScope(scopes ...models.Scope) echo.MiddlewareFunc {
+	challenge := fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, models.Scopes(scopes).String())
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := authClaims(c)
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "missing access token")
+			}
+
+			if !claims.Scopes().Contains(scopes...) {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, challenge)
+				return echo.NewHTTPError(http.StatusForbidden, "insufficient scope")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -3,26 +3,62 @@ package routes
 import (
 	"net/http"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/server/handlers"
+	"github.com/nix-united/golang-echo-boilerplate/internal/server/middleware"
 
 	"github.com/labstack/echo/v4"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
 type Handlers struct {
-	PostHandler     *handlers.PostHandlers
-	AuthHandler     *handlers.AuthHandler
-	OAuthHandler    *handlers.OAuthHandler
-	RegisterHandler *handlers.RegisterHandler
+	PostHandler         *handlers.PostHandlers
+	AuthHandler         *handlers.AuthHandler
+	OAuthHandler        *handlers.OAuthHandler
+	RegisterHandler     *handlers.RegisterHandler
+	JWKSHandler         *handlers.JWKSHandler
+	APIKeyHandler       *handlers.APIKeyHandler
+	IdentityHandler     *handlers.IdentityHandler
+	VerificationHandler *handlers.VerificationHandler
+	PasswordHandler     *handlers.PasswordHandler
+	MFAHandler          *handlers.MFAHandler
+	SessionHandler      *handlers.SessionHandler
+
+	ImpersonationHandler *handlers.ImpersonationHandler
+
+	// MagicLinkHandler is nil when magic link login is disabled.
+	MagicLinkHandler *handlers.MagicLinkHandler
+
+	// TokenHandler is nil when no clients may introspect and revoke tokens.
+	TokenHandler *handlers.TokenHandler
 
 	AuthMiddleware            echo.MiddlewareFunc
 	RequestLoggerMiddleware   echo.MiddlewareFunc
 	RequestDebuggerMiddleware echo.MiddlewareFunc
+
+	// PasswordForgotRateLimiter limits requests which send password reset emails.
+	PasswordForgotRateLimiter echo.MiddlewareFunc
+
+	// MagicLinkRateLimiter limits requests which send magic link emails.
+	MagicLinkRateLimiter echo.MiddlewareFunc
+
+	// ClientAuthMiddleware authenticates other services by client credentials.
+	ClientAuthMiddleware echo.MiddlewareFunc
+
+	// IPExtractor extracts the client IP, the remote address of the connection is used when nil.
+	IPExtractor echo.IPExtractor
 }
 
 func ConfigureRoutes(handlers Handlers) *echo.Echo {
 	engine := echo.New()
 
+	// Without an extractor echo trusts the X-Forwarded-For and X-Real-IP headers of any client,
+	// so rate limits and login lockouts could be bypassed by spoofing them.
+	engine.IPExtractor = handlers.IPExtractor
+	if engine.IPExtractor == nil {
+		engine.IPExtractor = echo.ExtractIPDirect()
+	}
+
 	// Technical API route initialization.
 	//
 	// These endpoints exist solely to keep the service running and must not include any
@@ -32,7 +68,13 @@ func ConfigureRoutes(handlers Handlers) *echo.Echo {
 		return c.NoContent(http.StatusOK)
 	})
 
-	api := engine.Group("", handlers.RequestLoggerMiddleware)
+	// Mutating requests authenticated with token cookies must carry the CSRF token.
+	api := engine.Group("", handlers.RequestLoggerMiddleware, middleware.CSRF)
+
+	// Public API routes initialization.
+	//
+	// These endpoints expose only public data and don't require authentication.
+	api.GET("/.well-known/jwks.json", handlers.JWKSHandler.GetJWKS)
 
 	// Private API routes initialization.
 	//
@@ -41,9 +83,64 @@ func ConfigureRoutes(handlers Handlers) *echo.Echo {
 	privateAPI := api.Group("")
 
 	privateAPI.POST("/login", handlers.AuthHandler.Login)
+	privateAPI.POST("/login/mfa", handlers.AuthHandler.LoginMFA)
+
+	if handlers.MagicLinkHandler != nil {
+		privateAPI.POST("/login/magic-link", handlers.MagicLinkHandler.SendMagicLink, handlers.MagicLinkRateLimiter)
+		privateAPI.POST("/login/magic-link/verify", handlers.MagicLinkHandler.LoginMagicLink)
+	}
+
 	privateAPI.POST("/register", handlers.RegisterHandler.Register)
+	privateAPI.POST("/verify-email", handlers.VerificationHandler.VerifyEmail)
+	privateAPI.POST("/verify-email/resend", handlers.VerificationHandler.ResendVerification)
+	privateAPI.POST("/password/forgot", handlers.PasswordHandler.ForgotPassword, handlers.PasswordForgotRateLimiter)
+	privateAPI.POST("/password/reset", handlers.PasswordHandler.ResetPassword)
+	privateAPI.POST("/email/confirm", handlers.VerificationHandler.ConfirmEmailChange)
 	privateAPI.POST("/google-oauth", handlers.OAuthHandler.GoogleOAuth)
+	privateAPI.POST("/oauth/:provider", handlers.OAuthHandler.Authenticate)
+	privateAPI.GET("/oauth/:provider/start", handlers.OAuthHandler.StartAuthorization)
+	privateAPI.GET("/oauth/:provider/callback", handlers.OAuthHandler.CompleteAuthorization)
+	if handlers.TokenHandler != nil {
+		privateAPI.POST("/oauth/introspect", handlers.TokenHandler.Introspect, handlers.ClientAuthMiddleware)
+		privateAPI.POST("/oauth/revoke", handlers.TokenHandler.Revoke, handlers.ClientAuthMiddleware)
+	}
+
+	// Credentials and sessions of other devices can be changed only by the user,
+	// not by an admin impersonating the user.
+	forbidImpersonation := middleware.ForbidImpersonation
+
 	privateAPI.POST("/refresh", handlers.AuthHandler.RefreshToken)
+	privateAPI.POST("/logout", handlers.AuthHandler.Logout, handlers.AuthMiddleware)
+	privateAPI.POST("/logout-all", handlers.AuthHandler.LogoutAll, handlers.AuthMiddleware, forbidImpersonation)
+
+	accountAPI := privateAPI.Group("/me", handlers.AuthMiddleware, middleware.RequireScope(models.ScopeAccount))
+
+	accountAPI.PUT("/password", handlers.PasswordHandler.ChangePassword, forbidImpersonation)
+	accountAPI.PUT("/email", handlers.VerificationHandler.ChangeEmail, forbidImpersonation)
+
+	accountAPI.POST("/mfa/totp", handlers.MFAHandler.EnrollTOTP, forbidImpersonation)
+	accountAPI.POST("/mfa/totp/confirm", handlers.MFAHandler.ConfirmTOTP, forbidImpersonation)
+
+	accountAPI.GET("/sessions", handlers.SessionHandler.GetSessions)
+	accountAPI.DELETE("/sessions/:id", handlers.SessionHandler.RevokeSession, forbidImpersonation)
+
+	accountAPI.POST("/api-keys", handlers.APIKeyHandler.CreateAPIKey, forbidImpersonation)
+	accountAPI.GET("/api-keys", handlers.APIKeyHandler.GetAPIKeys)
+	accountAPI.DELETE("/api-keys/:id", handlers.APIKeyHandler.RevokeAPIKey, forbidImpersonation)
+
+	accountAPI.POST("/identities/:provider", handlers.IdentityHandler.LinkIdentity, forbidImpersonation)
+	accountAPI.GET("/identities", handlers.IdentityHandler.GetIdentities)
+	accountAPI.DELETE("/identities/:id", handlers.IdentityHandler.UnlinkIdentity, forbidImpersonation)
+
+	adminAPI := privateAPI.Group(
+		"/admin",
+		handlers.AuthMiddleware,
+		middleware.RequireRole(models.RoleAdmin),
+		middleware.RequireScope(models.ScopeAdmin),
+		forbidImpersonation,
+	)
+
+	adminAPI.POST("/users/:id/impersonate", handlers.ImpersonationHandler.Impersonate)
 
 	// Authorized API route initialization.
 	//
@@ -51,10 +148,13 @@ func ConfigureRoutes(handlers Handlers) *echo.Echo {
 	// before they can be accessed.
 	authorizedAPI := api.Group("", handlers.RequestDebuggerMiddleware, handlers.AuthMiddleware)
 
-	authorizedAPI.POST("/posts", handlers.PostHandler.CreatePost)
-	authorizedAPI.GET("/posts", handlers.PostHandler.GetPosts)
-	authorizedAPI.PUT("/posts/:id", handlers.PostHandler.UpdatePost)
-	authorizedAPI.DELETE("/posts/:id", handlers.PostHandler.DeletePost)
+	readPosts := middleware.RequireScope(models.ScopePostsRead)
+	writePosts := middleware.RequireScope(models.ScopePostsWrite)
+
+	authorizedAPI.POST("/posts", handlers.PostHandler.CreatePost, writePosts)
+	authorizedAPI.GET("/posts", handlers.PostHandler.GetPosts, readPosts)
+	authorizedAPI.PUT("/posts/:id", handlers.PostHandler.UpdatePost, writePosts)
+	authorizedAPI.DELETE("/posts/:id", handlers.PostHandler.DeletePost, writePosts)
 
 	return engine
 }
@@ -0,0 +1,145 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	// keyPrefix makes API keys recognizable, e.g. by secret scanners.
+	keyPrefix = "ak_"
+
+	// displayedPrefixLength is how many leading characters of a key are stored in plain text.
+	displayedPrefixLength = len(keyPrefix) + 8
+)
+
+type apiKeyRepository interface {
+	Create(ctx context.Context, apiKey *models.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (models.APIKey, error)
+	GetByUser(ctx context.Context, userID uint) ([]models.APIKey, error)
+	Revoke(ctx context.Context, userID, id uint, revokedAt time.Time) error
+}
+
+type userService interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+}
+
+// Service manages API keys, which let machine clients authenticate without a password.
+type Service struct {
+	now              func() time.Time
+	apiKeyRepository apiKeyRepository
+	userService      userService
+}
+
+func NewService(now func() time.Time, apiKeyRepository apiKeyRepository, userService userService) *Service {
+	return &Service{
+		now:              now,
+		apiKeyRepository: apiKeyRepository,
+		userService:      userService,
+	}
+}
+
+// Create creates an API key for the user. The key itself is returned only once and never stored.
+//
+// The key can't have more scopes than the token used to create it, so allowed are scopes of that token.
+// It returns [models.ErrInvalidScope] when the request asks for other scopes.
+func (s *Service) Create(
+	ctx context.Context,
+	userID uint,
+	allowed models.Scopes,
+	request *requests.CreateAPIKeyRequest,
+) (key string, apiKey models.APIKey, err error) {
+	scopes, err := allowed.Grant(request.Scopes)
+	if err != nil {
+		return "", models.APIKey{}, fmt.Errorf("grant api key scopes: %w", err)
+	}
+
+	key = keyPrefix + rand.Text()
+
+	apiKey = models.APIKey{
+		UserID:    userID,
+		Name:      request.Name,
+		Prefix:    key[:displayedPrefixLength],
+		KeyHash:   hashKey(key),
+		Scopes:    scopes,
+		ExpiresAt: request.ExpiresAt,
+	}
+
+	if err := s.apiKeyRepository.Create(ctx, &apiKey); err != nil {
+		return "", models.APIKey{}, fmt.Errorf("create api key in repository: %w", err)
+	}
+
+	return key, apiKey, nil
+}
+
+// GetByUser returns active API keys of the user.
+func (s *Service) GetByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	apiKeys, err := s.apiKeyRepository.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get api keys from repository: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+// Revoke revokes the API key of the user.
+func (s *Service) Revoke(ctx context.Context, userID, id uint) error {
+	if err := s.apiKeyRepository.Revoke(ctx, userID, id, s.now()); err != nil {
+		return fmt.Errorf("revoke api key in repository: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate returns claims of the key owner in the same shape as claims of an access token.
+// It returns [models.ErrInvalidAuthToken] when the key is unknown, revoked or expired.
+func (s *Service) Authenticate(ctx context.Context, key string) (*token.JwtCustomClaims, error) {
+	apiKey, err := s.apiKeyRepository.GetByHash(ctx, hashKey(key))
+	if errors.Is(err, models.ErrAPIKeyNotFound) {
+		return nil, errors.Join(err, models.ErrInvalidAuthToken)
+	} else if err != nil {
+		return nil, fmt.Errorf("get api key from repository: %w", err)
+	}
+
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !s.now().Before(*apiKey.ExpiresAt)) {
+		return nil, models.ErrInvalidAuthToken
+	}
+
+	user, err := s.userService.GetByID(ctx, apiKey.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("get api key owner: %w", err)
+	}
+
+	claims := &token.JwtCustomClaims{
+		Name:  user.Name,
+		ID:    user.ID,
+		Roles: user.Roles,
+		Scope: apiKey.Scopes.String(),
+	}
+
+	if apiKey.ExpiresAt != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*apiKey.ExpiresAt)
+	}
+
+	return claims, nil
+}
+
+// hashKey returns a hex encoded SHA-256 hash of the key.
+// API keys are random high-entropy strings, so a fast hash is sufficient.
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
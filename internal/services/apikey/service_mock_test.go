@@ -0,0 +1,260 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=apikey_test -typed=true
+//
+
+// Package apikey_test is a generated GoMock package.
+package apikey_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockapiKeyRepository is a mock of apiKeyRepository interface.
+type MockapiKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockapiKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockapiKeyRepositoryMockRecorder is the mock recorder for MockapiKeyRepository.
+type MockapiKeyRepositoryMockRecorder struct {
+	mock *MockapiKeyRepository
+}
+
+// NewMockapiKeyRepository creates a new mock instance.
+func NewMockapiKeyRepository(ctrl *gomock.Controller) *MockapiKeyRepository {
+	mock := &MockapiKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockapiKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapiKeyRepository) EXPECT() *MockapiKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockapiKeyRepository) Create(ctx context.Context, apiKey *models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockapiKeyRepositoryMockRecorder) Create(ctx, apiKey any) *MockapiKeyRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockapiKeyRepository)(nil).Create), ctx, apiKey)
+	return &MockapiKeyRepositoryCreateCall{Call: call}
+}
+
+// MockapiKeyRepositoryCreateCall wrap *gomock.Call
+type MockapiKeyRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockapiKeyRepositoryCreateCall) Return(arg0 error) *MockapiKeyRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockapiKeyRepositoryCreateCall) Do(f func(context.Context, *models.APIKey) error) *MockapiKeyRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockapiKeyRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.APIKey) error) *MockapiKeyRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByHash mocks base method.
+func (m *MockapiKeyRepository) GetByHash(ctx context.Context, keyHash string) (models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, keyHash)
+	ret0, _ := ret[0].(models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockapiKeyRepositoryMockRecorder) GetByHash(ctx, keyHash any) *MockapiKeyRepositoryGetByHashCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockapiKeyRepository)(nil).GetByHash), ctx, keyHash)
+	return &MockapiKeyRepositoryGetByHashCall{Call: call}
+}
+
+// MockapiKeyRepositoryGetByHashCall wrap *gomock.Call
+type MockapiKeyRepositoryGetByHashCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockapiKeyRepositoryGetByHashCall) Return(arg0 models.APIKey, arg1 error) *MockapiKeyRepositoryGetByHashCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockapiKeyRepositoryGetByHashCall) Do(f func(context.Context, string) (models.APIKey, error)) *MockapiKeyRepositoryGetByHashCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockapiKeyRepositoryGetByHashCall) DoAndReturn(f func(context.Context, string) (models.APIKey, error)) *MockapiKeyRepositoryGetByHashCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByUser mocks base method.
+func (m *MockapiKeyRepository) GetByUser(ctx context.Context, userID uint) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockapiKeyRepositoryMockRecorder) GetByUser(ctx, userID any) *MockapiKeyRepositoryGetByUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockapiKeyRepository)(nil).GetByUser), ctx, userID)
+	return &MockapiKeyRepositoryGetByUserCall{Call: call}
+}
+
+// MockapiKeyRepositoryGetByUserCall wrap *gomock.Call
+type MockapiKeyRepositoryGetByUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockapiKeyRepositoryGetByUserCall) Return(arg0 []models.APIKey, arg1 error) *MockapiKeyRepositoryGetByUserCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockapiKeyRepositoryGetByUserCall) Do(f func(context.Context, uint) ([]models.APIKey, error)) *MockapiKeyRepositoryGetByUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockapiKeyRepositoryGetByUserCall) DoAndReturn(f func(context.Context, uint) ([]models.APIKey, error)) *MockapiKeyRepositoryGetByUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockapiKeyRepository) Revoke(ctx context.Context, userID, id uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockapiKeyRepositoryMockRecorder) Revoke(ctx, userID, id, revokedAt any) *MockapiKeyRepositoryRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockapiKeyRepository)(nil).Revoke), ctx, userID, id, revokedAt)
+	return &MockapiKeyRepositoryRevokeCall{Call: call}
+}
+
+// MockapiKeyRepositoryRevokeCall wrap *gomock.Call
+type MockapiKeyRepositoryRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockapiKeyRepositoryRevokeCall) Return(arg0 error) *MockapiKeyRepositoryRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockapiKeyRepositoryRevokeCall) Do(f func(context.Context, uint, uint, time.Time) error) *MockapiKeyRepositoryRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockapiKeyRepositoryRevokeCall) DoAndReturn(f func(context.Context, uint, uint, time.Time) error) *MockapiKeyRepositoryRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockuserService is a mock of userService interface.
+type MockuserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockuserServiceMockRecorder is the mock recorder for MockuserService.
+type MockuserServiceMockRecorder struct {
+	mock *MockuserService
+}
+
+// NewMockuserService creates a new mock instance.
+func NewMockuserService(ctrl *gomock.Controller) *MockuserService {
+	mock := &MockuserService{ctrl: ctrl}
+	mock.recorder = &MockuserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserService) EXPECT() *MockuserServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserService) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserServiceMockRecorder) GetByID(ctx, id any) *MockuserServiceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserService)(nil).GetByID), ctx, id)
+	return &MockuserServiceGetByIDCall{Call: call}
+}
+
+// MockuserServiceGetByIDCall wrap *gomock.Call
+type MockuserServiceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
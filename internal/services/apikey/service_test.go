@@ -0,0 +1,205 @@
+package apikey_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/apikey"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type serviceMocks struct {
+	apiKeyRepository *MockapiKeyRepository
+	userService      *MockuserService
+}
+
+var currentTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newService(t *testing.T) (*apikey.Service, serviceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	apiKeyRepository := NewMockapiKeyRepository(ctrl)
+	userService := NewMockuserService(ctrl)
+
+	service := apikey.NewService(func() time.Time { return currentTime }, apiKeyRepository, userService)
+
+	mocks := serviceMocks{
+		apiKeyRepository: apiKeyRepository,
+		userService:      userService,
+	}
+
+	return service, mocks
+}
+
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestService_Create(t *testing.T) {
+	expiresAt := currentTime.Add(time.Hour)
+
+	service, mocks := newService(t)
+
+	var storedAPIKey *models.APIKey
+	mocks.apiKeyRepository.
+		EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, apiKey *models.APIKey) error {
+			storedAPIKey = apiKey
+			apiKey.ID = 10
+			return nil
+		})
+
+	key, apiKey, err := service.Create(t.Context(), 1, models.AllScopes(), &requests.CreateAPIKeyRequest{
+		Name:      "CI",
+		Scopes:    models.Scopes{models.ScopePostsRead},
+		ExpiresAt: &expiresAt,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key, "ak_"))
+	assert.Equal(t, models.APIKey{
+		Model:     gorm.Model{ID: 10},
+		UserID:    1,
+		Name:      "CI",
+		Prefix:    key[:11],
+		KeyHash:   hash(key),
+		Scopes:    models.Scopes{models.ScopePostsRead},
+		ExpiresAt: &expiresAt,
+	}, apiKey)
+	assert.Equal(t, hash(key), storedAPIKey.KeyHash)
+	assert.NotContains(t, storedAPIKey.Prefix+storedAPIKey.KeyHash, key)
+}
+
+func TestService_Create_Scopes(t *testing.T) {
+	t.Run("It should grant all allowed scopes when none are requested", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.apiKeyRepository.
+			EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			Return(nil)
+
+		_, apiKey, err := service.Create(
+			t.Context(),
+			1,
+			models.Scopes{models.ScopePostsRead},
+			&requests.CreateAPIKeyRequest{Name: "CI"},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.Scopes{models.ScopePostsRead}, apiKey.Scopes)
+	})
+
+	t.Run("It should return ErrInvalidScope when requested scopes are not allowed", func(t *testing.T) {
+		service, _ := newService(t)
+
+		_, _, err := service.Create(
+			t.Context(),
+			1,
+			models.Scopes{models.ScopePostsRead},
+			&requests.CreateAPIKeyRequest{Name: "CI", Scopes: models.Scopes{models.ScopePostsWrite}},
+		)
+		assert.ErrorIs(t, err, models.ErrInvalidScope)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	service, mocks := newService(t)
+
+	mocks.apiKeyRepository.
+		EXPECT().
+		Revoke(gomock.Any(), uint(1), uint(10), currentTime).
+		Return(models.ErrAPIKeyNotFound)
+
+	err := service.Revoke(t.Context(), 1, 10)
+	assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+}
+
+func TestService_Authenticate(t *testing.T) {
+	user := models.User{
+		Model: gorm.Model{ID: 1},
+		Name:  "name",
+		Roles: models.Roles{models.RoleUser},
+	}
+
+	pastTime := currentTime.Add(-time.Second)
+	futureTime := currentTime.Add(time.Hour)
+
+	t.Run("It should return ErrInvalidAuthToken when api key is unknown", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.apiKeyRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("ak_key")).
+			Return(models.APIKey{}, models.ErrAPIKeyNotFound)
+
+		_, err := service.Authenticate(t.Context(), "ak_key")
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should return ErrInvalidAuthToken when api key is revoked", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.apiKeyRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("ak_key")).
+			Return(models.APIKey{UserID: 1, RevokedAt: &pastTime}, nil)
+
+		_, err := service.Authenticate(t.Context(), "ak_key")
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should return ErrInvalidAuthToken when api key is expired", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.apiKeyRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("ak_key")).
+			Return(models.APIKey{UserID: 1, ExpiresAt: &pastTime}, nil)
+
+		_, err := service.Authenticate(t.Context(), "ak_key")
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should return claims of the api key owner", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.apiKeyRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("ak_key")).
+			Return(models.APIKey{UserID: 1, Scopes: models.Scopes{models.ScopePostsRead}, ExpiresAt: &futureTime}, nil)
+
+		mocks.userService.
+			EXPECT().
+			GetByID(gomock.Any(), uint(1)).
+			Return(user, nil)
+
+		claims, err := service.Authenticate(t.Context(), "ak_key")
+		require.NoError(t, err)
+
+		assert.Equal(t, &token.JwtCustomClaims{
+			Name:  "name",
+			ID:    1,
+			Roles: models.Roles{models.RoleUser},
+			Scope: "posts:read",
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(futureTime),
+			},
+		}, claims)
+	})
+}
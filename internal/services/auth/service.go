@@ -4,87 +4,348 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
 	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 //go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
 
+// mfaChallengeDuration is how long the user has to enter the second factor after the password check.
+const mfaChallengeDuration = 5 * time.Minute
+
 type userService interface {
 	GetByID(ctx context.Context, id uint) (models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	ComparePassword(ctx context.Context, user *models.User, password string) error
 }
 
 type tokenService interface {
 	ParseRefreshToken(ctx context.Context, token string) (*token.JwtCustomRefreshClaims, error)
-	CreateAccessToken(ctx context.Context, user *models.User) (string, int64, error)
-	CreateRefreshToken(ctx context.Context, user *models.User) (string, error)
+	CreateAccessToken(ctx context.Context, user *models.User, sessionID string, scopes models.Scopes) (string, int64, error)
+}
+
+type sessionService interface {
+	Create(
+		ctx context.Context,
+		user *models.User,
+		scopes models.Scopes,
+		client models.Client,
+		provider string,
+	) (refreshToken, sessionID string, err error)
+	Rotate(
+		ctx context.Context,
+		user *models.User,
+		refreshToken string,
+		scopes models.Scopes,
+	) (newRefreshToken, sessionID string, err error)
+	Revoke(ctx context.Context, userID uint, sessionID string) error
+	RevokeAll(ctx context.Context, userID uint) error
+}
+
+type accessTokenRevoker interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+}
+
+type mfaService interface {
+	IsEnabled(ctx context.Context, userID uint) (bool, error)
+	Verify(ctx context.Context, userID uint, code string) error
+}
+
+type mfaChallengeService interface {
+	CreateMFAChallenge(
+		ctx context.Context,
+		user *models.User,
+		scopes models.Scopes,
+		provider string,
+		duration time.Duration,
+	) (string, error)
+	Consume(ctx context.Context, purpose models.ActionPurpose, actionToken string) (*token.JwtActionClaims, error)
+}
+
+type loginLockout interface {
+	Check(ctx context.Context, email, clientIP string) error
+	RegisterFailure(ctx context.Context, email, clientIP string) error
+	RegisterSuccess(ctx context.Context, email string) error
 }
 
 type Service struct {
-	userService  userService
-	tokenService tokenService
+	userService          userService
+	tokenService         tokenService
+	sessionService       sessionService
+	accessTokenRevoker   accessTokenRevoker
+	mfaService           mfaService
+	mfaChallengeService  mfaChallengeService
+	loginLockout         loginLockout
+	requireVerifiedEmail bool
 }
 
-func NewService(userService userService, tokenService tokenService) *Service {
+// NewService creates the service. With requireVerifiedEmail users can't log in with a password
+// until they verify their email.
+func NewService(
+	userService userService,
+	tokenService tokenService,
+	sessionService sessionService,
+	accessTokenRevoker accessTokenRevoker,
+	mfaService mfaService,
+	mfaChallengeService mfaChallengeService,
+	loginLockout loginLockout,
+	requireVerifiedEmail bool,
+) *Service {
 	return &Service{
-		userService:  userService,
-		tokenService: tokenService,
+		userService:          userService,
+		tokenService:         tokenService,
+		sessionService:       sessionService,
+		accessTokenRevoker:   accessTokenRevoker,
+		mfaService:           mfaService,
+		mfaChallengeService:  mfaChallengeService,
+		loginLockout:         loginLockout,
+		requireVerifiedEmail: requireVerifiedEmail,
 	}
 }
 
-func (s *Service) GenerateToken(ctx context.Context, request *requests.LoginRequest) (*responses.LoginResponse, error) {
-	user, err := s.userService.GetUserByEmail(ctx, request.Email)
+// GenerateToken logs the user in. It returns [models.ErrInvalidScope] when unknown scopes are requested,
+// and [models.ErrEmailNotVerified] when verified email is required and the user hasn't verified it yet.
+//
+// Failed logins are counted per account and per client IP, and [*models.LoginLockedError] is returned
+// while logins are locked after too many of them.
+//
+// When MFA is enabled for the user, only an MFA token is returned, which is exchanged for the session
+// with [Service.CompleteMFALogin].
+func (s *Service) GenerateToken(
+	ctx context.Context,
+	request *requests.LoginRequest,
+	client models.Client,
+) (*responses.LoginResponse, error) {
+	scopes, err := models.AllScopes().Grant(request.Scopes)
 	if err != nil {
-		return nil, fmt.Errorf("get user by email: %w", err)
+		return nil, fmt.Errorf("grant scopes: %w", err)
+	}
+
+	if err := s.loginLockout.Check(ctx, request.Email, client.IPAddress); err != nil {
+		return nil, fmt.Errorf("check login lockout: %w", err)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)); err != nil {
-		return nil, errors.Join(fmt.Errorf("compare hash and passowrd: %w", err), models.ErrInvalidPassword)
+	user, err := s.checkCredentials(ctx, request)
+	if errors.Is(err, models.ErrUserNotFound) || errors.Is(err, models.ErrInvalidPassword) {
+		if err := s.loginLockout.RegisterFailure(ctx, request.Email, client.IPAddress); err != nil {
+			return nil, fmt.Errorf("register login failure: %w", err)
+		}
+
+		return nil, err
+	} else if err != nil {
+		return nil, err
+	}
+
+	if s.requireVerifiedEmail && user.VerifiedAt == nil {
+		return nil, models.ErrEmailNotVerified
 	}
 
-	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, &user)
+	return s.LoginUser(ctx, &user, scopes, client, models.LoginProviderPassword)
+}
+
+// LoginUser logs in the user who has passed the first factor with the provider, either the password
+// or another one, e.g. a magic link. When MFA is enabled for the user, only an MFA token is returned
+// as with [Service.GenerateToken].
+func (s *Service) LoginUser(
+	ctx context.Context,
+	user *models.User,
+	scopes models.Scopes,
+	client models.Client,
+	provider string,
+) (*responses.LoginResponse, error) {
+	mfaEnabled, err := s.mfaService.IsEnabled(ctx, user.ID)
 	if err != nil {
-		return nil, fmt.Errorf("create access token: %w", err)
+		return nil, fmt.Errorf("check if mfa is enabled: %w", err)
+	}
+
+	// With MFA failures are kept until the second factor is passed too,
+	// so that codes can't be guessed with a known password.
+	if mfaEnabled {
+		mfaToken, err := s.mfaChallengeService.CreateMFAChallenge(ctx, user, scopes, provider, mfaChallengeDuration)
+		if err != nil {
+			return nil, fmt.Errorf("create mfa challenge: %w", err)
+		}
+
+		return responses.NewMFAChallengeResponse(mfaToken), nil
+	}
+
+	if err := s.loginLockout.RegisterSuccess(ctx, user.Email); err != nil {
+		return nil, fmt.Errorf("register login success: %w", err)
 	}
 
-	refreshToken, err := s.tokenService.CreateRefreshToken(ctx, &user)
+	return s.login(ctx, user, scopes, client, provider)
+}
+
+func (s *Service) checkCredentials(ctx context.Context, request *requests.LoginRequest) (models.User, error) {
+	user, err := s.userService.GetUserByEmail(ctx, request.Email)
+	if errors.Is(err, models.ErrUserNotFound) {
+		// The password of unknown users is compared as well, so that the response time doesn't reveal
+		// which emails are registered.
+		_ = s.userService.ComparePassword(ctx, &models.User{}, request.Password)
+
+		return models.User{}, fmt.Errorf("get user by email: %w", err)
+	} else if err != nil {
+		return models.User{}, fmt.Errorf("get user by email: %w", err)
+	}
+
+	// Outdated password hashes are upgraded here transparently.
+	if err := s.userService.ComparePassword(ctx, &user, request.Password); err != nil {
+		return models.User{}, fmt.Errorf("compare password: %w", err)
+	}
+
+	return user, nil
+}
+
+// CompleteMFALogin logs the user in with the MFA token returned by [Service.GenerateToken] and the second factor.
+// The MFA token is single-use, so the user has to log in with the password again after an invalid code.
+// It returns [models.ErrInvalidActionToken] when the MFA token is invalid, expired or already used,
+// and [models.ErrInvalidMFACode] when the code doesn't match. Invalid codes count as failed logins.
+func (s *Service) CompleteMFALogin(
+	ctx context.Context,
+	request *requests.MFALoginRequest,
+	client models.Client,
+) (*responses.LoginResponse, error) {
+	claims, err := s.mfaChallengeService.Consume(ctx, models.ActionMFALogin, request.MFAToken)
 	if err != nil {
-		return nil, fmt.Errorf("create refresh token: %w", err)
+		return nil, fmt.Errorf("consume mfa challenge: %w", err)
 	}
 
-	response := responses.NewLoginResponse(accessToken, refreshToken, exp)
+	user, err := s.userService.GetByID(ctx, claims.ID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil, errors.Join(models.ErrInvalidActionToken, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
 
-	return response, nil
+	if err := s.loginLockout.Check(ctx, user.Email, client.IPAddress); err != nil {
+		return nil, fmt.Errorf("check login lockout: %w", err)
+	}
+
+	err = s.mfaService.Verify(ctx, user.ID, request.Code)
+	if errors.Is(err, models.ErrInvalidMFACode) {
+		if err := s.loginLockout.RegisterFailure(ctx, user.Email, client.IPAddress); err != nil {
+			return nil, fmt.Errorf("register login failure: %w", err)
+		}
+
+		return nil, fmt.Errorf("verify mfa code: %w", err)
+	} else if err != nil {
+		return nil, fmt.Errorf("verify mfa code: %w", err)
+	}
+
+	if err := s.loginLockout.RegisterSuccess(ctx, user.Email); err != nil {
+		return nil, fmt.Errorf("register login success: %w", err)
+	}
+
+	// MFA tokens issued before the provider was recorded were all issued after the password check.
+	provider := claims.Provider
+	if provider == "" {
+		provider = models.LoginProviderPassword
+	}
+
+	return s.login(ctx, &user, claims.Scopes(), client, provider)
 }
 
+func (s *Service) login(
+	ctx context.Context,
+	user *models.User,
+	scopes models.Scopes,
+	client models.Client,
+	provider string,
+) (*responses.LoginResponse, error) {
+	refreshToken, sessionID, err := s.sessionService.Create(ctx, user, scopes, client, provider)
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+
+	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, user, sessionID, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("create access token: %w", err)
+	}
+
+	return responses.NewLoginResponse(accessToken, refreshToken, exp), nil
+}
+
+// RefreshToken rotates the refresh token and issues a new access token, which also marks the session as used.
+// It returns [models.ErrInvalidAuthToken] when the session has been revoked.
+// The access token may get a subset of the session scopes, while the session keeps all of them.
+// It returns [models.ErrInvalidScope] when scopes outside of the session are requested.
 func (s *Service) RefreshToken(ctx context.Context, request *requests.RefreshRequest) (*responses.LoginResponse, error) {
 	claims, err := s.tokenService.ParseRefreshToken(ctx, request.Token)
 	if err != nil {
 		return nil, errors.Join(fmt.Errorf("parse token: %w", err), models.ErrInvalidAuthToken)
 	}
 
+	sessionScopes := claims.Scopes()
+
+	scopes, err := sessionScopes.Grant(request.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("grant scopes: %w", err)
+	}
+
 	user, err := s.userService.GetByID(ctx, claims.ID)
 	if err != nil {
 		return nil, fmt.Errorf("get user by email: %w", err)
 	}
 
-	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, &user)
+	refreshToken, sessionID, err := s.sessionService.Rotate(ctx, &user, request.Token, sessionScopes)
 	if err != nil {
-		return nil, fmt.Errorf("create access token: %w", err)
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
 	}
 
-	refreshToken, err := s.tokenService.CreateRefreshToken(ctx, &user)
+	accessToken, exp, err := s.tokenService.CreateAccessToken(ctx, &user, sessionID, scopes)
 	if err != nil {
-		return nil, fmt.Errorf("create refresh token: %w", err)
+		return nil, fmt.Errorf("create access token: %w", err)
 	}
 
 	response := responses.NewLoginResponse(accessToken, refreshToken, exp)
 
 	return response, nil
 }
+
+// Logout revokes the session the access token was issued for and the access token itself.
+func (s *Service) Logout(ctx context.Context, claims *token.JwtCustomClaims) error {
+	if claims.SessionID != "" {
+		// The session may have been revoked from another device already, the access token is revoked anyway.
+		err := s.sessionService.Revoke(ctx, claims.ID, claims.SessionID)
+		if err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+			return fmt.Errorf("revoke session: %w", err)
+		}
+	}
+
+	if err := s.revokeAccessToken(ctx, claims); err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+// LogoutAll revokes every session of the user, so access tokens issued for the sessions are rejected as well.
+// The access token used for the request is revoked too, in case it was issued without a session.
+func (s *Service) LogoutAll(ctx context.Context, claims *token.JwtCustomClaims) error {
+	if err := s.sessionService.RevokeAll(ctx, claims.ID); err != nil {
+		return fmt.Errorf("revoke all sessions: %w", err)
+	}
+
+	if err := s.revokeAccessToken(ctx, claims); err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) revokeAccessToken(ctx context.Context, claims *token.JwtCustomClaims) error {
+	// Tokens issued before access token IDs were introduced can't be revoked and expire on their own.
+	if claims.RegisteredClaims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	if err := s.accessTokenRevoker.Revoke(ctx, claims.RegisteredClaims.ID, claims.ExpiresAt.Time); err != nil {
+		return fmt.Errorf("add access token to denylist: %w", err)
+	}
+
+	return nil
+}
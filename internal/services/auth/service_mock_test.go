@@ -12,6 +12,7 @@ package auth_test
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
 	token "github.com/nix-united/golang-echo-boilerplate/internal/services/token"
@@ -42,6 +43,44 @@ func (m *MockuserService) EXPECT() *MockuserServiceMockRecorder {
 	return m.recorder
 }
 
+// ComparePassword mocks base method.
+func (m *MockuserService) ComparePassword(ctx context.Context, user *models.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComparePassword", ctx, user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ComparePassword indicates an expected call of ComparePassword.
+func (mr *MockuserServiceMockRecorder) ComparePassword(ctx, user, password any) *MockuserServiceComparePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComparePassword", reflect.TypeOf((*MockuserService)(nil).ComparePassword), ctx, user, password)
+	return &MockuserServiceComparePasswordCall{Call: call}
+}
+
+// MockuserServiceComparePasswordCall wrap *gomock.Call
+type MockuserServiceComparePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceComparePasswordCall) Return(arg0 error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceComparePasswordCall) Do(f func(context.Context, *models.User, string) error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceComparePasswordCall) DoAndReturn(f func(context.Context, *models.User, string) error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetByID mocks base method.
 func (m *MockuserService) GetByID(ctx context.Context, id uint) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -145,9 +184,9 @@ func (m *MocktokenService) EXPECT() *MocktokenServiceMockRecorder {
 }
 
 // CreateAccessToken mocks base method.
-func (m *MocktokenService) CreateAccessToken(ctx context.Context, user *models.User) (string, int64, error) {
+func (m *MocktokenService) CreateAccessToken(ctx context.Context, user *models.User, sessionID string, scopes models.Scopes) (string, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccessToken", ctx, user)
+	ret := m.ctrl.Call(m, "CreateAccessToken", ctx, user, sessionID, scopes)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
@@ -155,9 +194,9 @@ func (m *MocktokenService) CreateAccessToken(ctx context.Context, user *models.U
 }
 
 // CreateAccessToken indicates an expected call of CreateAccessToken.
-func (mr *MocktokenServiceMockRecorder) CreateAccessToken(ctx, user any) *MocktokenServiceCreateAccessTokenCall {
+func (mr *MocktokenServiceMockRecorder) CreateAccessToken(ctx, user, sessionID, scopes any) *MocktokenServiceCreateAccessTokenCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MocktokenService)(nil).CreateAccessToken), ctx, user)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MocktokenService)(nil).CreateAccessToken), ctx, user, sessionID, scopes)
 	return &MocktokenServiceCreateAccessTokenCall{Call: call}
 }
 
@@ -173,91 +212,635 @@ func (c *MocktokenServiceCreateAccessTokenCall) Return(arg0 string, arg1 int64,
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MocktokenServiceCreateAccessTokenCall) Do(f func(context.Context, *models.User) (string, int64, error)) *MocktokenServiceCreateAccessTokenCall {
+func (c *MocktokenServiceCreateAccessTokenCall) Do(f func(context.Context, *models.User, string, models.Scopes) (string, int64, error)) *MocktokenServiceCreateAccessTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceCreateAccessTokenCall) DoAndReturn(f func(context.Context, *models.User, string, models.Scopes) (string, int64, error)) *MocktokenServiceCreateAccessTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ParseRefreshToken mocks base method.
+func (m *MocktokenService) ParseRefreshToken(ctx context.Context, arg1 string) (*token.JwtCustomRefreshClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseRefreshToken", ctx, arg1)
+	ret0, _ := ret[0].(*token.JwtCustomRefreshClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseRefreshToken indicates an expected call of ParseRefreshToken.
+func (mr *MocktokenServiceMockRecorder) ParseRefreshToken(ctx, arg1 any) *MocktokenServiceParseRefreshTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseRefreshToken", reflect.TypeOf((*MocktokenService)(nil).ParseRefreshToken), ctx, arg1)
+	return &MocktokenServiceParseRefreshTokenCall{Call: call}
+}
+
+// MocktokenServiceParseRefreshTokenCall wrap *gomock.Call
+type MocktokenServiceParseRefreshTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenServiceParseRefreshTokenCall) Return(arg0 *token.JwtCustomRefreshClaims, arg1 error) *MocktokenServiceParseRefreshTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenServiceParseRefreshTokenCall) Do(f func(context.Context, string) (*token.JwtCustomRefreshClaims, error)) *MocktokenServiceParseRefreshTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceParseRefreshTokenCall) DoAndReturn(f func(context.Context, string) (*token.JwtCustomRefreshClaims, error)) *MocktokenServiceParseRefreshTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocksessionService is a mock of sessionService interface.
+type MocksessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionServiceMockRecorder is the mock recorder for MocksessionService.
+type MocksessionServiceMockRecorder struct {
+	mock *MocksessionService
+}
+
+// NewMocksessionService creates a new mock instance.
+func NewMocksessionService(ctrl *gomock.Controller) *MocksessionService {
+	mock := &MocksessionService{ctrl: ctrl}
+	mock.recorder = &MocksessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionService) EXPECT() *MocksessionServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MocksessionService) Create(ctx context.Context, user *models.User, scopes models.Scopes, client models.Client, provider string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, user, scopes, client, provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocksessionServiceMockRecorder) Create(ctx, user, scopes, client, provider any) *MocksessionServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocksessionService)(nil).Create), ctx, user, scopes, client, provider)
+	return &MocksessionServiceCreateCall{Call: call}
+}
+
+// MocksessionServiceCreateCall wrap *gomock.Call
+type MocksessionServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceCreateCall) Return(refreshToken, sessionID string, err error) *MocksessionServiceCreateCall {
+	c.Call = c.Call.Return(refreshToken, sessionID, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceCreateCall) Do(f func(context.Context, *models.User, models.Scopes, models.Client, string) (string, string, error)) *MocksessionServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceCreateCall) DoAndReturn(f func(context.Context, *models.User, models.Scopes, models.Client, string) (string, string, error)) *MocksessionServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MocksessionService) Revoke(ctx context.Context, userID uint, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MocksessionServiceMockRecorder) Revoke(ctx, userID, sessionID any) *MocksessionServiceRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MocksessionService)(nil).Revoke), ctx, userID, sessionID)
+	return &MocksessionServiceRevokeCall{Call: call}
+}
+
+// MocksessionServiceRevokeCall wrap *gomock.Call
+type MocksessionServiceRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRevokeCall) Return(arg0 error) *MocksessionServiceRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRevokeCall) Do(f func(context.Context, uint, string) error) *MocksessionServiceRevokeCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MocktokenServiceCreateAccessTokenCall) DoAndReturn(f func(context.Context, *models.User) (string, int64, error)) *MocktokenServiceCreateAccessTokenCall {
+func (c *MocksessionServiceRevokeCall) DoAndReturn(f func(context.Context, uint, string) error) *MocksessionServiceRevokeCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// CreateRefreshToken mocks base method.
-func (m *MocktokenService) CreateRefreshToken(ctx context.Context, user *models.User) (string, error) {
+// RevokeAll mocks base method.
+func (m *MocksessionService) RevokeAll(ctx context.Context, userID uint) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, user)
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MocksessionServiceMockRecorder) RevokeAll(ctx, userID any) *MocksessionServiceRevokeAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MocksessionService)(nil).RevokeAll), ctx, userID)
+	return &MocksessionServiceRevokeAllCall{Call: call}
+}
+
+// MocksessionServiceRevokeAllCall wrap *gomock.Call
+type MocksessionServiceRevokeAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRevokeAllCall) Return(arg0 error) *MocksessionServiceRevokeAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRevokeAllCall) Do(f func(context.Context, uint) error) *MocksessionServiceRevokeAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceRevokeAllCall) DoAndReturn(f func(context.Context, uint) error) *MocksessionServiceRevokeAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Rotate mocks base method.
+func (m *MocksessionService) Rotate(ctx context.Context, user *models.User, refreshToken string, scopes models.Scopes) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", ctx, user, refreshToken, scopes)
 	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MocksessionServiceMockRecorder) Rotate(ctx, user, refreshToken, scopes any) *MocksessionServiceRotateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MocksessionService)(nil).Rotate), ctx, user, refreshToken, scopes)
+	return &MocksessionServiceRotateCall{Call: call}
+}
+
+// MocksessionServiceRotateCall wrap *gomock.Call
+type MocksessionServiceRotateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRotateCall) Return(newRefreshToken, sessionID string, err error) *MocksessionServiceRotateCall {
+	c.Call = c.Call.Return(newRefreshToken, sessionID, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRotateCall) Do(f func(context.Context, *models.User, string, models.Scopes) (string, string, error)) *MocksessionServiceRotateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceRotateCall) DoAndReturn(f func(context.Context, *models.User, string, models.Scopes) (string, string, error)) *MocksessionServiceRotateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockaccessTokenRevoker is a mock of accessTokenRevoker interface.
+type MockaccessTokenRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessTokenRevokerMockRecorder
+	isgomock struct{}
+}
+
+// MockaccessTokenRevokerMockRecorder is the mock recorder for MockaccessTokenRevoker.
+type MockaccessTokenRevokerMockRecorder struct {
+	mock *MockaccessTokenRevoker
+}
+
+// NewMockaccessTokenRevoker creates a new mock instance.
+func NewMockaccessTokenRevoker(ctrl *gomock.Controller) *MockaccessTokenRevoker {
+	mock := &MockaccessTokenRevoker{ctrl: ctrl}
+	mock.recorder = &MockaccessTokenRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessTokenRevoker) EXPECT() *MockaccessTokenRevokerMockRecorder {
+	return m.recorder
+}
+
+// Revoke mocks base method.
+func (m *MockaccessTokenRevoker) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockaccessTokenRevokerMockRecorder) Revoke(ctx, tokenID, expiresAt any) *MockaccessTokenRevokerRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockaccessTokenRevoker)(nil).Revoke), ctx, tokenID, expiresAt)
+	return &MockaccessTokenRevokerRevokeCall{Call: call}
+}
+
+// MockaccessTokenRevokerRevokeCall wrap *gomock.Call
+type MockaccessTokenRevokerRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockaccessTokenRevokerRevokeCall) Return(arg0 error) *MockaccessTokenRevokerRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockaccessTokenRevokerRevokeCall) Do(f func(context.Context, string, time.Time) error) *MockaccessTokenRevokerRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockaccessTokenRevokerRevokeCall) DoAndReturn(f func(context.Context, string, time.Time) error) *MockaccessTokenRevokerRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockmfaService is a mock of mfaService interface.
+type MockmfaService struct {
+	ctrl     *gomock.Controller
+	recorder *MockmfaServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockmfaServiceMockRecorder is the mock recorder for MockmfaService.
+type MockmfaServiceMockRecorder struct {
+	mock *MockmfaService
+}
+
+// NewMockmfaService creates a new mock instance.
+func NewMockmfaService(ctrl *gomock.Controller) *MockmfaService {
+	mock := &MockmfaService{ctrl: ctrl}
+	mock.recorder = &MockmfaServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmfaService) EXPECT() *MockmfaServiceMockRecorder {
+	return m.recorder
+}
+
+// IsEnabled mocks base method.
+func (m *MockmfaService) IsEnabled(ctx context.Context, userID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", ctx, userID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateRefreshToken indicates an expected call of CreateRefreshToken.
-func (mr *MocktokenServiceMockRecorder) CreateRefreshToken(ctx, user any) *MocktokenServiceCreateRefreshTokenCall {
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockmfaServiceMockRecorder) IsEnabled(ctx, userID any) *MockmfaServiceIsEnabledCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MocktokenService)(nil).CreateRefreshToken), ctx, user)
-	return &MocktokenServiceCreateRefreshTokenCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockmfaService)(nil).IsEnabled), ctx, userID)
+	return &MockmfaServiceIsEnabledCall{Call: call}
 }
 
-// MocktokenServiceCreateRefreshTokenCall wrap *gomock.Call
-type MocktokenServiceCreateRefreshTokenCall struct {
+// MockmfaServiceIsEnabledCall wrap *gomock.Call
+type MockmfaServiceIsEnabledCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MocktokenServiceCreateRefreshTokenCall) Return(arg0 string, arg1 error) *MocktokenServiceCreateRefreshTokenCall {
+func (c *MockmfaServiceIsEnabledCall) Return(arg0 bool, arg1 error) *MockmfaServiceIsEnabledCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MocktokenServiceCreateRefreshTokenCall) Do(f func(context.Context, *models.User) (string, error)) *MocktokenServiceCreateRefreshTokenCall {
+func (c *MockmfaServiceIsEnabledCall) Do(f func(context.Context, uint) (bool, error)) *MockmfaServiceIsEnabledCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MocktokenServiceCreateRefreshTokenCall) DoAndReturn(f func(context.Context, *models.User) (string, error)) *MocktokenServiceCreateRefreshTokenCall {
+func (c *MockmfaServiceIsEnabledCall) DoAndReturn(f func(context.Context, uint) (bool, error)) *MockmfaServiceIsEnabledCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
-// ParseRefreshToken mocks base method.
-func (m *MocktokenService) ParseRefreshToken(ctx context.Context, arg1 string) (*token.JwtCustomRefreshClaims, error) {
+// Verify mocks base method.
+func (m *MockmfaService) Verify(ctx context.Context, userID uint, code string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseRefreshToken", ctx, arg1)
-	ret0, _ := ret[0].(*token.JwtCustomRefreshClaims)
+	ret := m.ctrl.Call(m, "Verify", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockmfaServiceMockRecorder) Verify(ctx, userID, code any) *MockmfaServiceVerifyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockmfaService)(nil).Verify), ctx, userID, code)
+	return &MockmfaServiceVerifyCall{Call: call}
+}
+
+// MockmfaServiceVerifyCall wrap *gomock.Call
+type MockmfaServiceVerifyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaServiceVerifyCall) Return(arg0 error) *MockmfaServiceVerifyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaServiceVerifyCall) Do(f func(context.Context, uint, string) error) *MockmfaServiceVerifyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaServiceVerifyCall) DoAndReturn(f func(context.Context, uint, string) error) *MockmfaServiceVerifyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockmfaChallengeService is a mock of mfaChallengeService interface.
+type MockmfaChallengeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockmfaChallengeServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockmfaChallengeServiceMockRecorder is the mock recorder for MockmfaChallengeService.
+type MockmfaChallengeServiceMockRecorder struct {
+	mock *MockmfaChallengeService
+}
+
+// NewMockmfaChallengeService creates a new mock instance.
+func NewMockmfaChallengeService(ctrl *gomock.Controller) *MockmfaChallengeService {
+	mock := &MockmfaChallengeService{ctrl: ctrl}
+	mock.recorder = &MockmfaChallengeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmfaChallengeService) EXPECT() *MockmfaChallengeServiceMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockmfaChallengeService) Consume(ctx context.Context, purpose models.ActionPurpose, actionToken string) (*token.JwtActionClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, purpose, actionToken)
+	ret0, _ := ret[0].(*token.JwtActionClaims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ParseRefreshToken indicates an expected call of ParseRefreshToken.
-func (mr *MocktokenServiceMockRecorder) ParseRefreshToken(ctx, arg1 any) *MocktokenServiceParseRefreshTokenCall {
+// Consume indicates an expected call of Consume.
+func (mr *MockmfaChallengeServiceMockRecorder) Consume(ctx, purpose, actionToken any) *MockmfaChallengeServiceConsumeCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseRefreshToken", reflect.TypeOf((*MocktokenService)(nil).ParseRefreshToken), ctx, arg1)
-	return &MocktokenServiceParseRefreshTokenCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockmfaChallengeService)(nil).Consume), ctx, purpose, actionToken)
+	return &MockmfaChallengeServiceConsumeCall{Call: call}
 }
 
-// MocktokenServiceParseRefreshTokenCall wrap *gomock.Call
-type MocktokenServiceParseRefreshTokenCall struct {
+// MockmfaChallengeServiceConsumeCall wrap *gomock.Call
+type MockmfaChallengeServiceConsumeCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MocktokenServiceParseRefreshTokenCall) Return(arg0 *token.JwtCustomRefreshClaims, arg1 error) *MocktokenServiceParseRefreshTokenCall {
+func (c *MockmfaChallengeServiceConsumeCall) Return(arg0 *token.JwtActionClaims, arg1 error) *MockmfaChallengeServiceConsumeCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MocktokenServiceParseRefreshTokenCall) Do(f func(context.Context, string) (*token.JwtCustomRefreshClaims, error)) *MocktokenServiceParseRefreshTokenCall {
+func (c *MockmfaChallengeServiceConsumeCall) Do(f func(context.Context, models.ActionPurpose, string) (*token.JwtActionClaims, error)) *MockmfaChallengeServiceConsumeCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MocktokenServiceParseRefreshTokenCall) DoAndReturn(f func(context.Context, string) (*token.JwtCustomRefreshClaims, error)) *MocktokenServiceParseRefreshTokenCall {
+func (c *MockmfaChallengeServiceConsumeCall) DoAndReturn(f func(context.Context, models.ActionPurpose, string) (*token.JwtActionClaims, error)) *MockmfaChallengeServiceConsumeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateMFAChallenge mocks base method.
+func (m *MockmfaChallengeService) CreateMFAChallenge(ctx context.Context, user *models.User, scopes models.Scopes, provider string, duration time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMFAChallenge", ctx, user, scopes, provider, duration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMFAChallenge indicates an expected call of CreateMFAChallenge.
+func (mr *MockmfaChallengeServiceMockRecorder) CreateMFAChallenge(ctx, user, scopes, provider, duration any) *MockmfaChallengeServiceCreateMFAChallengeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMFAChallenge", reflect.TypeOf((*MockmfaChallengeService)(nil).CreateMFAChallenge), ctx, user, scopes, provider, duration)
+	return &MockmfaChallengeServiceCreateMFAChallengeCall{Call: call}
+}
+
+// MockmfaChallengeServiceCreateMFAChallengeCall wrap *gomock.Call
+type MockmfaChallengeServiceCreateMFAChallengeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaChallengeServiceCreateMFAChallengeCall) Return(arg0 string, arg1 error) *MockmfaChallengeServiceCreateMFAChallengeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaChallengeServiceCreateMFAChallengeCall) Do(f func(context.Context, *models.User, models.Scopes, string, time.Duration) (string, error)) *MockmfaChallengeServiceCreateMFAChallengeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaChallengeServiceCreateMFAChallengeCall) DoAndReturn(f func(context.Context, *models.User, models.Scopes, string, time.Duration) (string, error)) *MockmfaChallengeServiceCreateMFAChallengeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockloginLockout is a mock of loginLockout interface.
+type MockloginLockout struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginLockoutMockRecorder
+	isgomock struct{}
+}
+
+// MockloginLockoutMockRecorder is the mock recorder for MockloginLockout.
+type MockloginLockoutMockRecorder struct {
+	mock *MockloginLockout
+}
+
+// NewMockloginLockout creates a new mock instance.
+func NewMockloginLockout(ctrl *gomock.Controller) *MockloginLockout {
+	mock := &MockloginLockout{ctrl: ctrl}
+	mock.recorder = &MockloginLockoutMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginLockout) EXPECT() *MockloginLockoutMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockloginLockout) Check(ctx context.Context, email, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, email, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockloginLockoutMockRecorder) Check(ctx, email, clientIP any) *MockloginLockoutCheckCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockloginLockout)(nil).Check), ctx, email, clientIP)
+	return &MockloginLockoutCheckCall{Call: call}
+}
+
+// MockloginLockoutCheckCall wrap *gomock.Call
+type MockloginLockoutCheckCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockloginLockoutCheckCall) Return(arg0 error) *MockloginLockoutCheckCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockloginLockoutCheckCall) Do(f func(context.Context, string, string) error) *MockloginLockoutCheckCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockloginLockoutCheckCall) DoAndReturn(f func(context.Context, string, string) error) *MockloginLockoutCheckCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RegisterFailure mocks base method.
+func (m *MockloginLockout) RegisterFailure(ctx context.Context, email, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailure", ctx, email, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockloginLockoutMockRecorder) RegisterFailure(ctx, email, clientIP any) *MockloginLockoutRegisterFailureCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockloginLockout)(nil).RegisterFailure), ctx, email, clientIP)
+	return &MockloginLockoutRegisterFailureCall{Call: call}
+}
+
+// MockloginLockoutRegisterFailureCall wrap *gomock.Call
+type MockloginLockoutRegisterFailureCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockloginLockoutRegisterFailureCall) Return(arg0 error) *MockloginLockoutRegisterFailureCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockloginLockoutRegisterFailureCall) Do(f func(context.Context, string, string) error) *MockloginLockoutRegisterFailureCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockloginLockoutRegisterFailureCall) DoAndReturn(f func(context.Context, string, string) error) *MockloginLockoutRegisterFailureCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RegisterSuccess mocks base method.
+func (m *MockloginLockout) RegisterSuccess(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSuccess", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterSuccess indicates an expected call of RegisterSuccess.
+func (mr *MockloginLockoutMockRecorder) RegisterSuccess(ctx, email any) *MockloginLockoutRegisterSuccessCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSuccess", reflect.TypeOf((*MockloginLockout)(nil).RegisterSuccess), ctx, email)
+	return &MockloginLockoutRegisterSuccessCall{Call: call}
+}
+
+// MockloginLockoutRegisterSuccessCall wrap *gomock.Call
+type MockloginLockoutRegisterSuccessCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockloginLockoutRegisterSuccessCall) Return(arg0 error) *MockloginLockoutRegisterSuccessCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockloginLockoutRegisterSuccessCall) Do(f func(context.Context, string) error) *MockloginLockoutRegisterSuccessCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockloginLockoutRegisterSuccessCall) DoAndReturn(f func(context.Context, string) error) *MockloginLockoutRegisterSuccessCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -3,6 +3,7 @@ package auth_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
@@ -10,38 +11,67 @@ import (
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/auth"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type serviceMocks struct {
-	userService  *MockuserService
-	tokenService *MocktokenService
+	userService        *MockuserService
+	tokenService       *MocktokenService
+	sessionService     *MocksessionService
+	accessTokenRevoker *MockaccessTokenRevoker
+	mfaService         *MockmfaService
+	mfaChallenge       *MockmfaChallengeService
+	loginLockout       *MockloginLockout
 }
 
+var client = models.Client{IPAddress: "192.0.2.1", UserAgent: "Mozilla/5.0"}
+
 func newService(t *testing.T) (*auth.Service, serviceMocks) {
 	t.Helper()
 
+	return newServiceWithVerifiedEmail(t, false)
+}
+
+func newServiceWithVerifiedEmail(t *testing.T, requireVerifiedEmail bool) (*auth.Service, serviceMocks) {
+	t.Helper()
+
 	ctrl := gomock.NewController(t)
 	userService := NewMockuserService(ctrl)
 	tokenService := NewMocktokenService(ctrl)
-	authService := auth.NewService(userService, tokenService)
+	sessionService := NewMocksessionService(ctrl)
+	accessTokenRevoker := NewMockaccessTokenRevoker(ctrl)
+	mfaService := NewMockmfaService(ctrl)
+	mfaChallenge := NewMockmfaChallengeService(ctrl)
+	loginLockout := NewMockloginLockout(ctrl)
+	authService := auth.NewService(
+		userService,
+		tokenService,
+		sessionService,
+		accessTokenRevoker,
+		mfaService,
+		mfaChallenge,
+		loginLockout,
+		requireVerifiedEmail,
+	)
 
 	mocks := serviceMocks{
-		userService:  userService,
-		tokenService: tokenService,
+		userService:        userService,
+		tokenService:       tokenService,
+		sessionService:     sessionService,
+		accessTokenRevoker: accessTokenRevoker,
+		mfaService:         mfaService,
+		mfaChallenge:       mfaChallenge,
+		loginLockout:       loginLockout,
 	}
 
 	return authService, mocks
 }
 
 func TestService_GenerateToken(t *testing.T) {
-	password, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
-	require.NoError(t, err)
-
 	loginRequest := &requests.LoginRequest{
 		BasicAuth: requests.BasicAuth{
 			Email:    "example@email.com",
@@ -53,7 +83,7 @@ func TestService_GenerateToken(t *testing.T) {
 		Model:    gorm.Model{ID: 1},
 		Email:    "example@email.com",
 		Name:     "name",
-		Password: string(password),
+		Password: "password-hash",
 	}
 
 	wantResponse := &responses.LoginResponse{
@@ -67,12 +97,17 @@ func TestService_GenerateToken(t *testing.T) {
 
 		userServiceErr := errors.New("error from user service")
 
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
 		mocks.userService.
 			EXPECT().
 			GetUserByEmail(gomock.Any(), loginRequest.Email).
 			Return(models.User{}, userServiceErr)
 
-		_, err := service.GenerateToken(t.Context(), loginRequest)
+		_, err := service.GenerateToken(t.Context(), loginRequest, client)
 		assert.ErrorIs(t, err, userServiceErr)
 	})
 
@@ -82,34 +117,392 @@ func TestService_GenerateToken(t *testing.T) {
 		loginRequestWithInvalidPassword := *loginRequest
 		loginRequestWithInvalidPassword.Password = "invalid-password"
 
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
 		mocks.userService.
 			EXPECT().
 			GetUserByEmail(gomock.Any(), loginRequest.Email).
 			Return(user, nil)
 
-		_, err := service.GenerateToken(t.Context(), &loginRequestWithInvalidPassword)
+		mocks.userService.
+			EXPECT().
+			ComparePassword(gomock.Any(), &user, "invalid-password").
+			Return(models.ErrInvalidPassword)
+
+		mocks.loginLockout.
+			EXPECT().
+			RegisterFailure(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
+		_, err := service.GenerateToken(t.Context(), &loginRequestWithInvalidPassword, client)
 		assert.ErrorIs(t, err, models.ErrInvalidPassword)
 	})
 
+	t.Run("It should return ErrEmailNotVerified error when verified email is required", func(t *testing.T) {
+		service, mocks := newServiceWithVerifiedEmail(t, true)
+
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
+		mocks.userService.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), loginRequest.Email).
+			Return(user, nil)
+
+		mocks.userService.
+			EXPECT().
+			ComparePassword(gomock.Any(), &user, "password").
+			Return(nil)
+
+		_, err := service.GenerateToken(t.Context(), loginRequest, client)
+		assert.ErrorIs(t, err, models.ErrEmailNotVerified)
+	})
+
 	t.Run("It should generate token", func(t *testing.T) {
 		service, mocks := newService(t)
 
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
 		mocks.userService.
 			EXPECT().
 			GetUserByEmail(gomock.Any(), loginRequest.Email).
 			Return(user, nil)
 
+		mocks.userService.
+			EXPECT().
+			ComparePassword(gomock.Any(), &user, "password").
+			Return(nil)
+
+		mocks.mfaService.
+			EXPECT().
+			IsEnabled(gomock.Any(), user.ID).
+			Return(false, nil)
+
+		mocks.loginLockout.
+			EXPECT().
+			RegisterSuccess(gomock.Any(), user.Email).
+			Return(nil)
+
+		mocks.sessionService.
+			EXPECT().
+			Create(gomock.Any(), &user, models.AllScopes(), client, models.LoginProviderPassword).
+			Return(wantResponse.RefreshToken, "session-id", nil)
+
 		mocks.tokenService.
 			EXPECT().
-			CreateAccessToken(gomock.Any(), &user).
+			CreateAccessToken(gomock.Any(), &user, "session-id", models.AllScopes()).
 			Return(wantResponse.AccessToken, wantResponse.Exp, nil)
 
+		response, err := service.GenerateToken(t.Context(), loginRequest, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantResponse, response)
+	})
+
+	t.Run("It should generate token with requested scopes", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		scopedLoginRequest := *loginRequest
+		scopedLoginRequest.Scopes = models.Scopes{models.ScopePostsRead}
+
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
+		mocks.userService.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), loginRequest.Email).
+			Return(user, nil)
+
+		mocks.userService.
+			EXPECT().
+			ComparePassword(gomock.Any(), &user, "password").
+			Return(nil)
+
+		mocks.mfaService.
+			EXPECT().
+			IsEnabled(gomock.Any(), user.ID).
+			Return(false, nil)
+
+		mocks.loginLockout.
+			EXPECT().
+			RegisterSuccess(gomock.Any(), user.Email).
+			Return(nil)
+
+		mocks.sessionService.
+			EXPECT().
+			Create(gomock.Any(), &user, models.Scopes{models.ScopePostsRead}, client, models.LoginProviderPassword).
+			Return(wantResponse.RefreshToken, "session-id", nil)
+
 		mocks.tokenService.
 			EXPECT().
-			CreateRefreshToken(gomock.Any(), &user).
-			Return(wantResponse.RefreshToken, nil)
+			CreateAccessToken(gomock.Any(), &user, "session-id", models.Scopes{models.ScopePostsRead}).
+			Return(wantResponse.AccessToken, wantResponse.Exp, nil)
 
-		response, err := service.GenerateToken(t.Context(), loginRequest)
+		response, err := service.GenerateToken(t.Context(), &scopedLoginRequest, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantResponse, response)
+	})
+
+	t.Run("It should return ErrInvalidScope when unknown scope is requested", func(t *testing.T) {
+		service, _ := newService(t)
+
+		scopedLoginRequest := *loginRequest
+		scopedLoginRequest.Scopes = models.Scopes{"posts:admin"}
+
+		_, err := service.GenerateToken(t.Context(), &scopedLoginRequest, client)
+		assert.ErrorIs(t, err, models.ErrInvalidScope)
+	})
+
+	t.Run("It should propagate LoginLockedError when login is locked", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(&models.LoginLockedError{RetryAfter: time.Minute})
+
+		_, err := service.GenerateToken(t.Context(), loginRequest, client)
+		assert.ErrorIs(t, err, models.ErrLoginLocked)
+	})
+
+	t.Run("It should compare password and register failure when user doesn't exist", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
+		mocks.userService.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), loginRequest.Email).
+			Return(models.User{}, models.ErrUserNotFound)
+
+		mocks.userService.
+			EXPECT().
+			ComparePassword(gomock.Any(), &models.User{}, "password").
+			Return(models.ErrInvalidPassword)
+
+		mocks.loginLockout.
+			EXPECT().
+			RegisterFailure(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
+		_, err := service.GenerateToken(t.Context(), loginRequest, client)
+		assert.ErrorIs(t, err, models.ErrUserNotFound)
+	})
+
+	t.Run("It should return MFA token when MFA is enabled", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), loginRequest.Email, client.IPAddress).
+			Return(nil)
+
+		mocks.userService.
+			EXPECT().
+			GetUserByEmail(gomock.Any(), loginRequest.Email).
+			Return(user, nil)
+
+		mocks.userService.
+			EXPECT().
+			ComparePassword(gomock.Any(), &user, "password").
+			Return(nil)
+
+		mocks.mfaService.
+			EXPECT().
+			IsEnabled(gomock.Any(), user.ID).
+			Return(true, nil)
+
+		mocks.mfaChallenge.
+			EXPECT().
+			CreateMFAChallenge(gomock.Any(), &user, models.AllScopes(), models.LoginProviderPassword, 5*time.Minute).
+			Return("mfa-token", nil)
+
+		response, err := service.GenerateToken(t.Context(), loginRequest, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, &responses.LoginResponse{MFAToken: "mfa-token"}, response)
+	})
+}
+
+func TestService_LoginUser(t *testing.T) {
+	user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com"}
+	scopes := models.Scopes{models.ScopePostsRead}
+
+	t.Run("It should return MFA token when MFA is enabled", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.mfaService.EXPECT().IsEnabled(gomock.Any(), user.ID).Return(true, nil)
+		mocks.mfaChallenge.
+			EXPECT().
+			CreateMFAChallenge(gomock.Any(), &user, scopes, models.LoginProviderMagicLink, 5*time.Minute).
+			Return("mfa-token", nil)
+
+		response, err := service.LoginUser(t.Context(), &user, scopes, client, models.LoginProviderMagicLink)
+		require.NoError(t, err)
+
+		assert.Equal(t, &responses.LoginResponse{MFAToken: "mfa-token"}, response)
+	})
+
+	t.Run("It should log in user", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.mfaService.EXPECT().IsEnabled(gomock.Any(), user.ID).Return(false, nil)
+		mocks.loginLockout.EXPECT().RegisterSuccess(gomock.Any(), user.Email).Return(nil)
+		mocks.sessionService.
+			EXPECT().
+			Create(gomock.Any(), &user, scopes, client, models.LoginProviderMagicLink).
+			Return("refresh-token", "session-id", nil)
+		mocks.tokenService.
+			EXPECT().
+			CreateAccessToken(gomock.Any(), &user, "session-id", scopes).
+			Return("access-token", int64(1735786800), nil)
+
+		response, err := service.LoginUser(t.Context(), &user, scopes, client, models.LoginProviderMagicLink)
+		require.NoError(t, err)
+
+		assert.Equal(t, responses.NewLoginResponse("access-token", "refresh-token", 1735786800), response)
+	})
+}
+
+func TestService_CompleteMFALogin(t *testing.T) {
+	request := &requests.MFALoginRequest{
+		MFAToken: "mfa-token",
+		Code:     "123456",
+	}
+
+	claims := &token.JwtActionClaims{
+		ID:       1,
+		Purpose:  models.ActionMFALogin,
+		Scope:    "posts:read",
+		Provider: models.LoginProviderMagicLink,
+	}
+
+	user := models.User{
+		Model: gorm.Model{ID: 1},
+		Email: "example@email.com",
+		Name:  "name",
+	}
+
+	wantResponse := &responses.LoginResponse{
+		AccessToken:  "access-token",
+		RefreshToken: "refresh-token",
+		Exp:          1000,
+	}
+
+	t.Run("It should propagate ErrInvalidActionToken when MFA token is invalid", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.mfaChallenge.
+			EXPECT().
+			Consume(gomock.Any(), models.ActionMFALogin, request.MFAToken).
+			Return(nil, models.ErrInvalidActionToken)
+
+		_, err := service.CompleteMFALogin(t.Context(), request, client)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should return ErrInvalidActionToken when user doesn't exist", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.mfaChallenge.
+			EXPECT().
+			Consume(gomock.Any(), models.ActionMFALogin, request.MFAToken).
+			Return(claims, nil)
+
+		mocks.userService.
+			EXPECT().
+			GetByID(gomock.Any(), uint(1)).
+			Return(models.User{}, models.ErrUserNotFound)
+
+		_, err := service.CompleteMFALogin(t.Context(), request, client)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should propagate ErrInvalidMFACode when code is invalid", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.mfaChallenge.
+			EXPECT().
+			Consume(gomock.Any(), models.ActionMFALogin, request.MFAToken).
+			Return(claims, nil)
+
+		mocks.userService.
+			EXPECT().
+			GetByID(gomock.Any(), uint(1)).
+			Return(user, nil)
+
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), user.Email, client.IPAddress).
+			Return(nil)
+
+		mocks.mfaService.
+			EXPECT().
+			Verify(gomock.Any(), user.ID, request.Code).
+			Return(models.ErrInvalidMFACode)
+
+		mocks.loginLockout.
+			EXPECT().
+			RegisterFailure(gomock.Any(), user.Email, client.IPAddress).
+			Return(nil)
+
+		_, err := service.CompleteMFALogin(t.Context(), request, client)
+		assert.ErrorIs(t, err, models.ErrInvalidMFACode)
+	})
+
+	t.Run("It should log in with scopes from MFA token", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.mfaChallenge.
+			EXPECT().
+			Consume(gomock.Any(), models.ActionMFALogin, request.MFAToken).
+			Return(claims, nil)
+
+		mocks.userService.
+			EXPECT().
+			GetByID(gomock.Any(), uint(1)).
+			Return(user, nil)
+
+		mocks.loginLockout.
+			EXPECT().
+			Check(gomock.Any(), user.Email, client.IPAddress).
+			Return(nil)
+
+		mocks.mfaService.
+			EXPECT().
+			Verify(gomock.Any(), user.ID, request.Code).
+			Return(nil)
+
+		mocks.loginLockout.
+			EXPECT().
+			RegisterSuccess(gomock.Any(), user.Email).
+			Return(nil)
+
+		mocks.sessionService.
+			EXPECT().
+			Create(gomock.Any(), &user, models.Scopes{models.ScopePostsRead}, client, models.LoginProviderMagicLink).
+			Return(wantResponse.RefreshToken, "session-id", nil)
+
+		mocks.tokenService.
+			EXPECT().
+			CreateAccessToken(gomock.Any(), &user, "session-id", models.Scopes{models.ScopePostsRead}).
+			Return(wantResponse.AccessToken, wantResponse.Exp, nil)
+
+		response, err := service.CompleteMFALogin(t.Context(), request, client)
 		require.NoError(t, err)
 
 		assert.Equal(t, wantResponse, response)
@@ -122,9 +515,12 @@ func TestService_RefreshToken(t *testing.T) {
 	}
 
 	claims := &token.JwtCustomRefreshClaims{
-		ID: 1,
+		ID:    1,
+		Scope: "posts:read posts:write",
 	}
 
+	sessionScopes := models.Scopes{models.ScopePostsRead, models.ScopePostsWrite}
+
 	user := models.User{
 		Model:    gorm.Model{ID: 1},
 		Email:    "example@email.com",
@@ -169,6 +565,28 @@ func TestService_RefreshToken(t *testing.T) {
 		assert.ErrorIs(t, err, userServiceErr)
 	})
 
+	t.Run("It should propagate ErrRefreshTokenReused when refresh token has already been used", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.tokenService.
+			EXPECT().
+			ParseRefreshToken(gomock.Any(), refreshRequest.Token).
+			Return(claims, nil)
+
+		mocks.userService.
+			EXPECT().
+			GetByID(gomock.Any(), uint(1)).
+			Return(user, nil)
+
+		mocks.sessionService.
+			EXPECT().
+			Rotate(gomock.Any(), &user, refreshRequest.Token, sessionScopes).
+			Return("", "", models.ErrRefreshTokenReused)
+
+		_, err := service.RefreshToken(t.Context(), refreshRequest)
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+	})
+
 	t.Run("It should refresh token", func(t *testing.T) {
 		service, mocks := newService(t)
 
@@ -182,19 +600,155 @@ func TestService_RefreshToken(t *testing.T) {
 			GetByID(gomock.Any(), uint(1)).
 			Return(user, nil)
 
+		mocks.sessionService.
+			EXPECT().
+			Rotate(gomock.Any(), &user, refreshRequest.Token, sessionScopes).
+			Return(wantResponse.RefreshToken, "session-id", nil)
+
 		mocks.tokenService.
 			EXPECT().
-			CreateAccessToken(gomock.Any(), &user).
+			CreateAccessToken(gomock.Any(), &user, "session-id", sessionScopes).
 			Return(wantResponse.AccessToken, wantResponse.Exp, nil)
 
+		response, err := service.RefreshToken(t.Context(), refreshRequest)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantResponse, response)
+	})
+
+	t.Run("It should narrow scopes of the access token but keep scopes of the session", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		scopedRefreshRequest := *refreshRequest
+		scopedRefreshRequest.Scopes = models.Scopes{models.ScopePostsRead}
+
 		mocks.tokenService.
 			EXPECT().
-			CreateRefreshToken(gomock.Any(), &user).
-			Return(wantResponse.RefreshToken, nil)
+			ParseRefreshToken(gomock.Any(), refreshRequest.Token).
+			Return(claims, nil)
 
-		response, err := service.RefreshToken(t.Context(), refreshRequest)
+		mocks.userService.
+			EXPECT().
+			GetByID(gomock.Any(), uint(1)).
+			Return(user, nil)
+
+		mocks.sessionService.
+			EXPECT().
+			Rotate(gomock.Any(), &user, refreshRequest.Token, sessionScopes).
+			Return(wantResponse.RefreshToken, "session-id", nil)
+
+		mocks.tokenService.
+			EXPECT().
+			CreateAccessToken(gomock.Any(), &user, "session-id", models.Scopes{models.ScopePostsRead}).
+			Return(wantResponse.AccessToken, wantResponse.Exp, nil)
+
+		response, err := service.RefreshToken(t.Context(), &scopedRefreshRequest)
 		require.NoError(t, err)
 
 		assert.Equal(t, wantResponse, response)
 	})
+
+	t.Run("It should return ErrInvalidScope when scope outside of the session is requested", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		scopedRefreshRequest := *refreshRequest
+		scopedRefreshRequest.Scopes = models.Scopes{models.ScopeAccount}
+
+		mocks.tokenService.
+			EXPECT().
+			ParseRefreshToken(gomock.Any(), refreshRequest.Token).
+			Return(claims, nil)
+
+		_, err := service.RefreshToken(t.Context(), &scopedRefreshRequest)
+		assert.ErrorIs(t, err, models.ErrInvalidScope)
+	})
+}
+
+func TestService_Logout(t *testing.T) {
+	expiresAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	claims := &token.JwtCustomClaims{
+		ID:        1,
+		SessionID: "session-id",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "access-token-id",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	t.Run("It should propagate an error from session service", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		sessionServiceErr := errors.New("error from session service")
+
+		mocks.sessionService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "session-id").
+			Return(sessionServiceErr)
+
+		err := service.Logout(t.Context(), claims)
+		assert.ErrorIs(t, err, sessionServiceErr)
+	})
+
+	t.Run("It should revoke access token when session is already revoked", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.sessionService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "session-id").
+			Return(models.ErrSessionNotFound)
+
+		mocks.accessTokenRevoker.
+			EXPECT().
+			Revoke(gomock.Any(), "access-token-id", expiresAt).
+			Return(nil)
+
+		err := service.Logout(t.Context(), claims)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should revoke session and access token", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.sessionService.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "session-id").
+			Return(nil)
+
+		mocks.accessTokenRevoker.
+			EXPECT().
+			Revoke(gomock.Any(), "access-token-id", expiresAt).
+			Return(nil)
+
+		err := service.Logout(t.Context(), claims)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_LogoutAll(t *testing.T) {
+	expiresAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	claims := &token.JwtCustomClaims{
+		ID:        1,
+		SessionID: "session-id",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "access-token-id",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	service, mocks := newService(t)
+
+	mocks.sessionService.
+		EXPECT().
+		RevokeAll(gomock.Any(), uint(1)).
+		Return(nil)
+
+	mocks.accessTokenRevoker.
+		EXPECT().
+		Revoke(gomock.Any(), "access-token-id", expiresAt).
+		Return(nil)
+
+	err := service.LogoutAll(t.Context(), claims)
+	require.NoError(t, err)
 }
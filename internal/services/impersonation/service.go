@@ -0,0 +1,83 @@
+package impersonation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type userService interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+}
+
+type tokenService interface {
+	CreateImpersonationToken(
+		ctx context.Context,
+		user *models.User,
+		impersonatorID uint,
+		scopes models.Scopes,
+		duration time.Duration,
+	) (string, int64, error)
+}
+
+// Service lets support staff act on behalf of users to reproduce their issues.
+type Service struct {
+	userService   userService
+	tokenService  tokenService
+	tokenDuration time.Duration
+}
+
+func NewService(userService userService, tokenService tokenService, tokenDuration time.Duration) *Service {
+	return &Service{
+		userService:   userService,
+		tokenService:  tokenService,
+		tokenDuration: tokenDuration,
+	}
+}
+
+// Impersonate issues a short-lived access token of the user for the impersonator. The token names
+// the impersonator in the act claim and can't be refreshed.
+//
+// Admins can't be impersonated, so the token never grants more than the impersonated user has.
+// The token never grants more scopes than the impersonator has either, except for [models.ScopeAdmin],
+// which it never grants.
+// It returns an error wrapping [models.ErrForbidden] when the user can't be impersonated.
+func (s *Service) Impersonate(
+	ctx context.Context,
+	impersonatorID uint,
+	impersonatorScopes models.Scopes,
+	userID uint,
+) (*responses.LoginResponse, error) {
+	if impersonatorID == userID {
+		return nil, fmt.Errorf("%w: impersonate yourself", models.ErrForbidden)
+	}
+
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user.Roles.Has(models.RoleAdmin) {
+		return nil, fmt.Errorf("%w: impersonate admin", models.ErrForbidden)
+	}
+
+	scopes := slices.DeleteFunc(slices.Clone(impersonatorScopes), func(scope models.Scope) bool {
+		return scope == models.ScopeAdmin
+	})
+
+	accessToken, exp, err := s.tokenService.CreateImpersonationToken(ctx, &user, impersonatorID, scopes, s.tokenDuration)
+	if err != nil {
+		return nil, fmt.Errorf("create impersonation token: %w", err)
+	}
+
+	slog.InfoContext(ctx, "User impersonation started", "impersonator_id", impersonatorID, "impersonated_user_id", userID)
+
+	return responses.NewLoginResponse(accessToken, "", exp), nil
+}
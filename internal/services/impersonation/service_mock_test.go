@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=impersonation_test -typed=true
+//
+
+// Package impersonation_test is a generated GoMock package.
+package impersonation_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserService is a mock of userService interface.
+type MockuserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockuserServiceMockRecorder is the mock recorder for MockuserService.
+type MockuserServiceMockRecorder struct {
+	mock *MockuserService
+}
+
+// NewMockuserService creates a new mock instance.
+func NewMockuserService(ctrl *gomock.Controller) *MockuserService {
+	mock := &MockuserService{ctrl: ctrl}
+	mock.recorder = &MockuserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserService) EXPECT() *MockuserServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserService) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserServiceMockRecorder) GetByID(ctx, id any) *MockuserServiceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserService)(nil).GetByID), ctx, id)
+	return &MockuserServiceGetByIDCall{Call: call}
+}
+
+// MockuserServiceGetByIDCall wrap *gomock.Call
+type MockuserServiceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocktokenService is a mock of tokenService interface.
+type MocktokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocktokenServiceMockRecorder is the mock recorder for MocktokenService.
+type MocktokenServiceMockRecorder struct {
+	mock *MocktokenService
+}
+
+// NewMocktokenService creates a new mock instance.
+func NewMocktokenService(ctrl *gomock.Controller) *MocktokenService {
+	mock := &MocktokenService{ctrl: ctrl}
+	mock.recorder = &MocktokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenService) EXPECT() *MocktokenServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateImpersonationToken mocks base method.
+func (m *MocktokenService) CreateImpersonationToken(ctx context.Context, user *models.User, impersonatorID uint, scopes models.Scopes, duration time.Duration) (string, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImpersonationToken", ctx, user, impersonatorID, scopes, duration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateImpersonationToken indicates an expected call of CreateImpersonationToken.
+func (mr *MocktokenServiceMockRecorder) CreateImpersonationToken(ctx, user, impersonatorID, scopes, duration any) *MocktokenServiceCreateImpersonationTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImpersonationToken", reflect.TypeOf((*MocktokenService)(nil).CreateImpersonationToken), ctx, user, impersonatorID, scopes, duration)
+	return &MocktokenServiceCreateImpersonationTokenCall{Call: call}
+}
+
+// MocktokenServiceCreateImpersonationTokenCall wrap *gomock.Call
+type MocktokenServiceCreateImpersonationTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenServiceCreateImpersonationTokenCall) Return(arg0 string, arg1 int64, arg2 error) *MocktokenServiceCreateImpersonationTokenCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenServiceCreateImpersonationTokenCall) Do(f func(context.Context, *models.User, uint, models.Scopes, time.Duration) (string, int64, error)) *MocktokenServiceCreateImpersonationTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceCreateImpersonationTokenCall) DoAndReturn(f func(context.Context, *models.User, uint, models.Scopes, time.Duration) (string, int64, error)) *MocktokenServiceCreateImpersonationTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
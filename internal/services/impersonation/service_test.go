@@ -0,0 +1,96 @@
+package impersonation_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/impersonation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+const tokenDuration = 15 * time.Minute
+
+func TestService_Impersonate(t *testing.T) {
+	user := models.User{Model: gorm.Model{ID: 2}, Name: "name", Roles: models.Roles{models.RoleUser}}
+	admin := models.User{Model: gorm.Model{ID: 3}, Name: "admin", Roles: models.Roles{models.RoleAdmin}}
+
+	testCases := map[string]struct {
+		userID    uint
+		user      models.User
+		userErr   error
+		wantToken bool
+		wantErr   error
+	}{
+		"It should issue impersonation token with the impersonator scopes except admin": {
+			userID:    2,
+			user:      user,
+			wantToken: true,
+		},
+		"It should forbid impersonating yourself": {
+			userID:  1,
+			wantErr: models.ErrForbidden,
+		},
+		"It should forbid impersonating admin": {
+			userID:  3,
+			user:    admin,
+			wantErr: models.ErrForbidden,
+		},
+		"It should return error when user is not found": {
+			userID:  4,
+			userErr: models.ErrUserNotFound,
+			wantErr: models.ErrUserNotFound,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userService := NewMockuserService(ctrl)
+			tokenService := NewMocktokenService(ctrl)
+			service := impersonation.NewService(userService, tokenService, tokenDuration)
+
+			if testCase.userID != 1 {
+				userService.EXPECT().GetByID(gomock.Any(), testCase.userID).Return(testCase.user, testCase.userErr)
+			}
+
+			if testCase.wantToken {
+				tokenService.
+					EXPECT().
+					CreateImpersonationToken(gomock.Any(), &testCase.user, uint(1), models.Scopes{models.ScopePostsRead}, tokenDuration).
+					Return("access-token", int64(100), nil)
+			}
+
+			got, err := service.Impersonate(t.Context(), 1, models.Scopes{models.ScopePostsRead, models.ScopeAdmin}, testCase.userID)
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, responses.NewLoginResponse("access-token", "", 100), got)
+		})
+	}
+
+	t.Run("It should return error when token is not created", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		userService := NewMockuserService(ctrl)
+		tokenService := NewMocktokenService(ctrl)
+		service := impersonation.NewService(userService, tokenService, tokenDuration)
+
+		userService.EXPECT().GetByID(gomock.Any(), uint(2)).Return(user, nil)
+		tokenService.
+			EXPECT().
+			CreateImpersonationToken(gomock.Any(), &user, uint(1), gomock.Any(), tokenDuration).
+			Return("", int64(0), errors.New("sign error"))
+
+		_, err := service.Impersonate(t.Context(), 1, models.AllScopes(), 2)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,186 @@
+package introspection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+// Token types as named in the token_type_hint parameter of RFC 7662 and RFC 7009.
+const (
+	TokenTypeAccess  = "access_token"
+	TokenTypeRefresh = "refresh_token"
+)
+
+type tokenService interface {
+	ParseAccessToken(ctx context.Context, tokenString string) (*token.JwtCustomClaims, error)
+	ParseRefreshToken(ctx context.Context, tokenString string) (*token.JwtCustomRefreshClaims, error)
+}
+
+type accessTokenDenylist interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+type sessionService interface {
+	IsActive(ctx context.Context, refreshToken string) (bool, error)
+	RevokeByRefreshToken(ctx context.Context, refreshToken string) error
+}
+
+// Service lets other services validate and revoke tokens without sharing the signing keys.
+type Service struct {
+	tokenService        tokenService
+	accessTokenDenylist accessTokenDenylist
+	sessionService      sessionService
+}
+
+func NewService(
+	tokenService tokenService,
+	accessTokenDenylist accessTokenDenylist,
+	sessionService sessionService,
+) *Service {
+	return &Service{
+		tokenService:        tokenService,
+		accessTokenDenylist: accessTokenDenylist,
+		sessionService:      sessionService,
+	}
+}
+
+// Introspect describes the access or refresh token. The hint is the expected token type,
+// tokens of the other type are recognized as well, just after a failed attempt.
+func (s *Service) Introspect(ctx context.Context, tokenString, hint string) (*responses.IntrospectionResponse, error) {
+	for _, tokenType := range tokenTypes(hint) {
+		switch tokenType {
+		case TokenTypeAccess:
+			if claims, err := s.tokenService.ParseAccessToken(ctx, tokenString); err == nil {
+				return s.introspectAccessToken(ctx, claims)
+			}
+		case TokenTypeRefresh:
+			if claims, err := s.tokenService.ParseRefreshToken(ctx, tokenString); err == nil {
+				return s.introspectRefreshToken(ctx, tokenString, claims)
+			}
+		}
+	}
+
+	return responses.NewInactiveIntrospectionResponse(), nil
+}
+
+// Revoke revokes the access or refresh token. Revoking a refresh token revokes its whole session.
+// Invalid tokens are ignored, as they can't be used anyway.
+func (s *Service) Revoke(ctx context.Context, tokenString, hint string) error {
+	for _, tokenType := range tokenTypes(hint) {
+		switch tokenType {
+		case TokenTypeAccess:
+			if claims, err := s.tokenService.ParseAccessToken(ctx, tokenString); err == nil {
+				return s.revokeAccessToken(ctx, claims)
+			}
+		case TokenTypeRefresh:
+			if _, err := s.tokenService.ParseRefreshToken(ctx, tokenString); err == nil {
+				return s.revokeRefreshToken(ctx, tokenString)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) introspectAccessToken(
+	ctx context.Context,
+	claims *token.JwtCustomClaims,
+) (*responses.IntrospectionResponse, error) {
+	// Tokens issued before access token IDs were introduced can't be revoked.
+	if claims.RegisteredClaims.ID != "" {
+		revoked, err := s.accessTokenDenylist.IsRevoked(ctx, claims.RegisteredClaims.ID)
+		if err != nil {
+			return nil, fmt.Errorf("check access token in denylist: %w", err)
+		}
+
+		if revoked {
+			return responses.NewInactiveIntrospectionResponse(), nil
+		}
+	}
+
+	response := &responses.IntrospectionResponse{
+		Active:    true,
+		Subject:   strconv.FormatUint(uint64(claims.ID), 10),
+		Scope:     claims.Scope,
+		TokenType: TokenTypeAccess,
+	}
+
+	if claims.ExpiresAt != nil {
+		response.Exp = claims.ExpiresAt.Unix()
+	}
+
+	if claims.Actor != nil {
+		response.Actor = &responses.IntrospectionActor{Subject: claims.Actor.Subject}
+	}
+
+	return response, nil
+}
+
+func (s *Service) introspectRefreshToken(
+	ctx context.Context,
+	refreshToken string,
+	claims *token.JwtCustomRefreshClaims,
+) (*responses.IntrospectionResponse, error) {
+	active, err := s.sessionService.IsActive(ctx, refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("check refresh token session: %w", err)
+	}
+
+	if !active {
+		return responses.NewInactiveIntrospectionResponse(), nil
+	}
+
+	response := &responses.IntrospectionResponse{
+		Active:    true,
+		Subject:   strconv.FormatUint(uint64(claims.ID), 10),
+		Scope:     claims.Scope,
+		TokenType: TokenTypeRefresh,
+	}
+
+	if claims.ExpiresAt != nil {
+		response.Exp = claims.ExpiresAt.Unix()
+	}
+
+	return response, nil
+}
+
+func (s *Service) revokeAccessToken(ctx context.Context, claims *token.JwtCustomClaims) error {
+	// Tokens issued before access token IDs were introduced can't be revoked and expire on their own.
+	if claims.RegisteredClaims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+
+	if err := s.accessTokenDenylist.Revoke(ctx, claims.RegisteredClaims.ID, claims.ExpiresAt.Time); err != nil {
+		return fmt.Errorf("add access token to denylist: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) revokeRefreshToken(ctx context.Context, refreshToken string) error {
+	err := s.sessionService.RevokeByRefreshToken(ctx, refreshToken)
+	if err != nil && !errors.Is(err, models.ErrRefreshTokenNotFound) {
+		return fmt.Errorf("revoke refresh token session: %w", err)
+	}
+
+	return nil
+}
+
+// tokenTypes returns token types in the order the token is tried as, the hinted type goes first.
+func tokenTypes(hint string) []string {
+	if hint == TokenTypeRefresh {
+		return []string{TokenTypeRefresh, TokenTypeAccess}
+	}
+
+	return []string{TokenTypeAccess, TokenTypeRefresh}
+}
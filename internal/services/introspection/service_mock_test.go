@@ -0,0 +1,323 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=introspection_test -typed=true
+//
+
+// Package introspection_test is a generated GoMock package.
+package introspection_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	token "github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MocktokenService is a mock of tokenService interface.
+type MocktokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocktokenServiceMockRecorder is the mock recorder for MocktokenService.
+type MocktokenServiceMockRecorder struct {
+	mock *MocktokenService
+}
+
+// NewMocktokenService creates a new mock instance.
+func NewMocktokenService(ctrl *gomock.Controller) *MocktokenService {
+	mock := &MocktokenService{ctrl: ctrl}
+	mock.recorder = &MocktokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenService) EXPECT() *MocktokenServiceMockRecorder {
+	return m.recorder
+}
+
+// ParseAccessToken mocks base method.
+func (m *MocktokenService) ParseAccessToken(ctx context.Context, tokenString string) (*token.JwtCustomClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseAccessToken", ctx, tokenString)
+	ret0, _ := ret[0].(*token.JwtCustomClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseAccessToken indicates an expected call of ParseAccessToken.
+func (mr *MocktokenServiceMockRecorder) ParseAccessToken(ctx, tokenString any) *MocktokenServiceParseAccessTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseAccessToken", reflect.TypeOf((*MocktokenService)(nil).ParseAccessToken), ctx, tokenString)
+	return &MocktokenServiceParseAccessTokenCall{Call: call}
+}
+
+// MocktokenServiceParseAccessTokenCall wrap *gomock.Call
+type MocktokenServiceParseAccessTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenServiceParseAccessTokenCall) Return(arg0 *token.JwtCustomClaims, arg1 error) *MocktokenServiceParseAccessTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenServiceParseAccessTokenCall) Do(f func(context.Context, string) (*token.JwtCustomClaims, error)) *MocktokenServiceParseAccessTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceParseAccessTokenCall) DoAndReturn(f func(context.Context, string) (*token.JwtCustomClaims, error)) *MocktokenServiceParseAccessTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ParseRefreshToken mocks base method.
+func (m *MocktokenService) ParseRefreshToken(ctx context.Context, tokenString string) (*token.JwtCustomRefreshClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseRefreshToken", ctx, tokenString)
+	ret0, _ := ret[0].(*token.JwtCustomRefreshClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseRefreshToken indicates an expected call of ParseRefreshToken.
+func (mr *MocktokenServiceMockRecorder) ParseRefreshToken(ctx, tokenString any) *MocktokenServiceParseRefreshTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseRefreshToken", reflect.TypeOf((*MocktokenService)(nil).ParseRefreshToken), ctx, tokenString)
+	return &MocktokenServiceParseRefreshTokenCall{Call: call}
+}
+
+// MocktokenServiceParseRefreshTokenCall wrap *gomock.Call
+type MocktokenServiceParseRefreshTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenServiceParseRefreshTokenCall) Return(arg0 *token.JwtCustomRefreshClaims, arg1 error) *MocktokenServiceParseRefreshTokenCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenServiceParseRefreshTokenCall) Do(f func(context.Context, string) (*token.JwtCustomRefreshClaims, error)) *MocktokenServiceParseRefreshTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceParseRefreshTokenCall) DoAndReturn(f func(context.Context, string) (*token.JwtCustomRefreshClaims, error)) *MocktokenServiceParseRefreshTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockaccessTokenDenylist is a mock of accessTokenDenylist interface.
+type MockaccessTokenDenylist struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccessTokenDenylistMockRecorder
+	isgomock struct{}
+}
+
+// MockaccessTokenDenylistMockRecorder is the mock recorder for MockaccessTokenDenylist.
+type MockaccessTokenDenylistMockRecorder struct {
+	mock *MockaccessTokenDenylist
+}
+
+// NewMockaccessTokenDenylist creates a new mock instance.
+func NewMockaccessTokenDenylist(ctrl *gomock.Controller) *MockaccessTokenDenylist {
+	mock := &MockaccessTokenDenylist{ctrl: ctrl}
+	mock.recorder = &MockaccessTokenDenylistMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccessTokenDenylist) EXPECT() *MockaccessTokenDenylistMockRecorder {
+	return m.recorder
+}
+
+// IsRevoked mocks base method.
+func (m *MockaccessTokenDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRevoked", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRevoked indicates an expected call of IsRevoked.
+func (mr *MockaccessTokenDenylistMockRecorder) IsRevoked(ctx, tokenID any) *MockaccessTokenDenylistIsRevokedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRevoked", reflect.TypeOf((*MockaccessTokenDenylist)(nil).IsRevoked), ctx, tokenID)
+	return &MockaccessTokenDenylistIsRevokedCall{Call: call}
+}
+
+// MockaccessTokenDenylistIsRevokedCall wrap *gomock.Call
+type MockaccessTokenDenylistIsRevokedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockaccessTokenDenylistIsRevokedCall) Return(arg0 bool, arg1 error) *MockaccessTokenDenylistIsRevokedCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockaccessTokenDenylistIsRevokedCall) Do(f func(context.Context, string) (bool, error)) *MockaccessTokenDenylistIsRevokedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockaccessTokenDenylistIsRevokedCall) DoAndReturn(f func(context.Context, string) (bool, error)) *MockaccessTokenDenylistIsRevokedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MockaccessTokenDenylist) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockaccessTokenDenylistMockRecorder) Revoke(ctx, tokenID, expiresAt any) *MockaccessTokenDenylistRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockaccessTokenDenylist)(nil).Revoke), ctx, tokenID, expiresAt)
+	return &MockaccessTokenDenylistRevokeCall{Call: call}
+}
+
+// MockaccessTokenDenylistRevokeCall wrap *gomock.Call
+type MockaccessTokenDenylistRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockaccessTokenDenylistRevokeCall) Return(arg0 error) *MockaccessTokenDenylistRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockaccessTokenDenylistRevokeCall) Do(f func(context.Context, string, time.Time) error) *MockaccessTokenDenylistRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockaccessTokenDenylistRevokeCall) DoAndReturn(f func(context.Context, string, time.Time) error) *MockaccessTokenDenylistRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocksessionService is a mock of sessionService interface.
+type MocksessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionServiceMockRecorder is the mock recorder for MocksessionService.
+type MocksessionServiceMockRecorder struct {
+	mock *MocksessionService
+}
+
+// NewMocksessionService creates a new mock instance.
+func NewMocksessionService(ctrl *gomock.Controller) *MocksessionService {
+	mock := &MocksessionService{ctrl: ctrl}
+	mock.recorder = &MocksessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionService) EXPECT() *MocksessionServiceMockRecorder {
+	return m.recorder
+}
+
+// IsActive mocks base method.
+func (m *MocksessionService) IsActive(ctx context.Context, refreshToken string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsActive", ctx, refreshToken)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsActive indicates an expected call of IsActive.
+func (mr *MocksessionServiceMockRecorder) IsActive(ctx, refreshToken any) *MocksessionServiceIsActiveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MocksessionService)(nil).IsActive), ctx, refreshToken)
+	return &MocksessionServiceIsActiveCall{Call: call}
+}
+
+// MocksessionServiceIsActiveCall wrap *gomock.Call
+type MocksessionServiceIsActiveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceIsActiveCall) Return(arg0 bool, arg1 error) *MocksessionServiceIsActiveCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceIsActiveCall) Do(f func(context.Context, string) (bool, error)) *MocksessionServiceIsActiveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceIsActiveCall) DoAndReturn(f func(context.Context, string) (bool, error)) *MocksessionServiceIsActiveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeByRefreshToken mocks base method.
+func (m *MocksessionService) RevokeByRefreshToken(ctx context.Context, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByRefreshToken", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeByRefreshToken indicates an expected call of RevokeByRefreshToken.
+func (mr *MocksessionServiceMockRecorder) RevokeByRefreshToken(ctx, refreshToken any) *MocksessionServiceRevokeByRefreshTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByRefreshToken", reflect.TypeOf((*MocksessionService)(nil).RevokeByRefreshToken), ctx, refreshToken)
+	return &MocksessionServiceRevokeByRefreshTokenCall{Call: call}
+}
+
+// MocksessionServiceRevokeByRefreshTokenCall wrap *gomock.Call
+type MocksessionServiceRevokeByRefreshTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRevokeByRefreshTokenCall) Return(arg0 error) *MocksessionServiceRevokeByRefreshTokenCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRevokeByRefreshTokenCall) Do(f func(context.Context, string) error) *MocksessionServiceRevokeByRefreshTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceRevokeByRefreshTokenCall) DoAndReturn(f func(context.Context, string) error) *MocksessionServiceRevokeByRefreshTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,219 @@
+package introspection_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/introspection"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type serviceMocks struct {
+	tokenService        *MocktokenService
+	accessTokenDenylist *MockaccessTokenDenylist
+	sessionService      *MocksessionService
+}
+
+var (
+	expiresAt   = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	errNotToken = errors.New("token is malformed")
+)
+
+func newService(t *testing.T) (*introspection.Service, serviceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := serviceMocks{
+		tokenService:        NewMocktokenService(ctrl),
+		accessTokenDenylist: NewMockaccessTokenDenylist(ctrl),
+		sessionService:      NewMocksessionService(ctrl),
+	}
+
+	return introspection.NewService(mocks.tokenService, mocks.accessTokenDenylist, mocks.sessionService), mocks
+}
+
+func accessClaims() *token.JwtCustomClaims {
+	return &token.JwtCustomClaims{
+		ID:    1,
+		Scope: "posts:read",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "access-token-id",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+}
+
+func refreshClaims() *token.JwtCustomRefreshClaims {
+	return &token.JwtCustomRefreshClaims{
+		ID:    1,
+		Scope: "posts:read account",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "refresh-token-id",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+}
+
+func TestService_Introspect(t *testing.T) {
+	testCases := map[string]struct {
+		hint            string
+		setExpectations func(mocks serviceMocks)
+		wantResponse    *responses.IntrospectionResponse
+	}{
+		"It should describe active access token": {
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(accessClaims(), nil)
+				mocks.accessTokenDenylist.EXPECT().IsRevoked(gomock.Any(), "access-token-id").Return(false, nil)
+			},
+			wantResponse: &responses.IntrospectionResponse{
+				Active:    true,
+				Subject:   "1",
+				Scope:     "posts:read",
+				Exp:       expiresAt.Unix(),
+				TokenType: introspection.TokenTypeAccess,
+			},
+		},
+		"It should describe the impersonator of access token": {
+			setExpectations: func(mocks serviceMocks) {
+				claims := accessClaims()
+				claims.Actor = &token.ActorClaims{Subject: "2"}
+
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(claims, nil)
+				mocks.accessTokenDenylist.EXPECT().IsRevoked(gomock.Any(), "access-token-id").Return(false, nil)
+			},
+			wantResponse: &responses.IntrospectionResponse{
+				Active:    true,
+				Subject:   "1",
+				Scope:     "posts:read",
+				Exp:       expiresAt.Unix(),
+				TokenType: introspection.TokenTypeAccess,
+				Actor:     &responses.IntrospectionActor{Subject: "2"},
+			},
+		},
+		"It should report revoked access token as inactive": {
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(accessClaims(), nil)
+				mocks.accessTokenDenylist.EXPECT().IsRevoked(gomock.Any(), "access-token-id").Return(true, nil)
+			},
+			wantResponse: responses.NewInactiveIntrospectionResponse(),
+		},
+		"It should describe active refresh token": {
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(nil, errNotToken)
+				mocks.tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "some-token").Return(refreshClaims(), nil)
+				mocks.sessionService.EXPECT().IsActive(gomock.Any(), "some-token").Return(true, nil)
+			},
+			wantResponse: &responses.IntrospectionResponse{
+				Active:    true,
+				Subject:   "1",
+				Scope:     "posts:read account",
+				Exp:       expiresAt.Unix(),
+				TokenType: introspection.TokenTypeRefresh,
+			},
+		},
+		"It should try refresh token first when hinted": {
+			hint: introspection.TokenTypeRefresh,
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "some-token").Return(refreshClaims(), nil)
+				mocks.sessionService.EXPECT().IsActive(gomock.Any(), "some-token").Return(false, nil)
+			},
+			wantResponse: responses.NewInactiveIntrospectionResponse(),
+		},
+		"It should report invalid token as inactive": {
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(nil, errNotToken)
+				mocks.tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "some-token").Return(nil, errNotToken)
+			},
+			wantResponse: responses.NewInactiveIntrospectionResponse(),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mocks := newService(t)
+
+			testCase.setExpectations(mocks)
+
+			response, err := service.Introspect(t.Context(), "some-token", testCase.hint)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantResponse, response)
+		})
+	}
+
+	t.Run("It should return error when denylist fails", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(accessClaims(), nil)
+		mocks.accessTokenDenylist.EXPECT().IsRevoked(gomock.Any(), "access-token-id").Return(false, errors.New("denylist error"))
+
+		_, err := service.Introspect(t.Context(), "some-token", "")
+		require.Error(t, err)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	testCases := map[string]struct {
+		hint            string
+		setExpectations func(mocks serviceMocks)
+	}{
+		"It should add access token to denylist": {
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(accessClaims(), nil)
+				mocks.accessTokenDenylist.EXPECT().Revoke(gomock.Any(), "access-token-id", expiresAt).Return(nil)
+			},
+		},
+		"It should revoke session of refresh token": {
+			hint: introspection.TokenTypeRefresh,
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "some-token").Return(refreshClaims(), nil)
+				mocks.sessionService.EXPECT().RevokeByRefreshToken(gomock.Any(), "some-token").Return(nil)
+			},
+		},
+		"It should ignore unknown refresh token": {
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(nil, errNotToken)
+				mocks.tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "some-token").Return(refreshClaims(), nil)
+				mocks.sessionService.
+					EXPECT().
+					RevokeByRefreshToken(gomock.Any(), "some-token").
+					Return(models.ErrRefreshTokenNotFound)
+			},
+		},
+		"It should ignore invalid token": {
+			setExpectations: func(mocks serviceMocks) {
+				mocks.tokenService.EXPECT().ParseAccessToken(gomock.Any(), "some-token").Return(nil, errNotToken)
+				mocks.tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "some-token").Return(nil, errNotToken)
+			},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mocks := newService(t)
+
+			testCase.setExpectations(mocks)
+
+			err := service.Revoke(t.Context(), "some-token", testCase.hint)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("It should return error when session service fails", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.tokenService.EXPECT().ParseRefreshToken(gomock.Any(), "some-token").Return(refreshClaims(), nil)
+		mocks.sessionService.EXPECT().RevokeByRefreshToken(gomock.Any(), "some-token").Return(errors.New("session error"))
+
+		err := service.Revoke(t.Context(), "some-token", introspection.TokenTypeRefresh)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,194 @@
+package lockout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	accountKeyPrefix = "account:"
+	ipKeyPrefix      = "ip:"
+)
+
+type attemptStore interface {
+	Get(ctx context.Context, key string) (models.LoginAttempt, error)
+	AddFailure(ctx context.Context, key string, expiresAt time.Time) (int, error)
+	Lock(ctx context.Context, key string, lockedUntil time.Time) error
+	Reset(ctx context.Context, key string) error
+}
+
+// Policy tells when failed logins lock further attempts.
+type Policy struct {
+	// Threshold is how many failures lock logins.
+	Threshold int
+
+	// Duration is how long the first lockout lasts.
+	// Every further failure doubles it up to MaxDuration.
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+// Service tracks failed logins per account and per client IP and locks logins after too many of them.
+type Service struct {
+	now           func() time.Time
+	attemptStore  attemptStore
+	window        time.Duration
+	accountPolicy Policy
+	ipPolicy      Policy
+}
+
+// NewService creates the service. Failures are forgotten when there are none within the window.
+func NewService(
+	now func() time.Time,
+	attemptStore attemptStore,
+	window time.Duration,
+	accountPolicy Policy,
+	ipPolicy Policy,
+) *Service {
+	return &Service{
+		now:           now,
+		attemptStore:  attemptStore,
+		window:        window,
+		accountPolicy: accountPolicy,
+		ipPolicy:      ipPolicy,
+	}
+}
+
+// Check returns [*models.LoginLockedError] when logins to the account or from the client IP are locked.
+func (s *Service) Check(ctx context.Context, email, clientIP string) error {
+	now := s.now()
+
+	var retryAfter time.Duration
+	for _, counter := range s.failureCounters(email, clientIP) {
+		attempt, err := s.attemptStore.Get(ctx, counter.key)
+		if err != nil {
+			return fmt.Errorf("get login attempt: %w", err)
+		}
+
+		if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			retryAfter = max(retryAfter, attempt.LockedUntil.Sub(now))
+		}
+	}
+
+	if retryAfter > 0 {
+		slog.WarnContext(
+			ctx,
+			"Security event: locked login attempt",
+			"event", "login_locked_attempt",
+			"email", email,
+			"client_ip", clientIP,
+			"retry_after", retryAfter,
+		)
+
+		return &models.LoginLockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// RegisterFailure counts a failed login to the account from the client IP.
+// Once a policy threshold is reached, logins are locked for an exponentially growing duration.
+func (s *Service) RegisterFailure(ctx context.Context, email, clientIP string) error {
+	now := s.now()
+	counters := s.failureCounters(email, clientIP)
+
+	failures := make([]int, len(counters))
+	logArgs := []any{"event", "login_failed", "email", email, "client_ip", clientIP}
+	for i, counter := range counters {
+		var err error
+		failures[i], err = s.attemptStore.AddFailure(ctx, counter.key, now.Add(s.window))
+		if err != nil {
+			return fmt.Errorf("add login failure: %w", err)
+		}
+
+		logArgs = append(logArgs, counter.name+"_failures", failures[i])
+	}
+
+	slog.WarnContext(ctx, "Security event: failed login", logArgs...)
+
+	for i, counter := range counters {
+		if failures[i] < counter.policy.Threshold {
+			continue
+		}
+
+		lockDuration := counter.policy.lockDuration(failures[i])
+		if err := s.attemptStore.Lock(ctx, counter.key, now.Add(lockDuration)); err != nil {
+			return fmt.Errorf("lock login attempts: %w", err)
+		}
+
+		slog.WarnContext(
+			ctx,
+			"Security event: login locked",
+			"event", "login_locked",
+			"email", email,
+			"client_ip", clientIP,
+			"counter", counter.name,
+			"failures", failures[i],
+			"duration", lockDuration,
+		)
+	}
+
+	return nil
+}
+
+// RegisterSuccess forgets failed logins to the account. Failures from the client IP are kept,
+// so that logging in to an own account doesn't reset guesses against other accounts.
+func (s *Service) RegisterSuccess(ctx context.Context, email string) error {
+	if err := s.attemptStore.Reset(ctx, accountKey(email)); err != nil {
+		return fmt.Errorf("reset login attempts: %w", err)
+	}
+
+	return nil
+}
+
+// failureCounter counts failures of a subject and locks logins on its own.
+type failureCounter struct {
+	key    string
+	name   string
+	policy Policy
+}
+
+func (s *Service) failureCounters(email, clientIP string) []failureCounter {
+	counters := []failureCounter{{key: accountKey(email), name: "account", policy: s.accountPolicy}}
+	if clientIP != "" {
+		counters = append(counters, failureCounter{key: ipKey(clientIP), name: "ip", policy: s.ipPolicy})
+	}
+
+	return counters
+}
+
+// accountKey and ipKey hash the subjects, so that stored keys have a fixed length and don't disclose emails.
+func accountKey(email string) string {
+	return accountKeyPrefix + hashSubject(strings.ToLower(strings.TrimSpace(email)))
+}
+
+func ipKey(clientIP string) string {
+	return ipKeyPrefix + hashSubject(clientIP)
+}
+
+func hashSubject(subject string) string {
+	hash := sha256.Sum256([]byte(subject))
+	return hex.EncodeToString(hash[:])
+}
+
+func (p Policy) lockDuration(failures int) time.Duration {
+	lockDuration := p.Duration
+	for range failures - p.Threshold {
+		if lockDuration >= p.MaxDuration {
+			break
+		}
+
+		lockDuration *= 2
+	}
+
+	return min(lockDuration, p.MaxDuration)
+}
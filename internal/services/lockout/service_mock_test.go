@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=lockout_test -typed=true
+//
+
+// Package lockout_test is a generated GoMock package.
+package lockout_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockattemptStore is a mock of attemptStore interface.
+type MockattemptStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockattemptStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockattemptStoreMockRecorder is the mock recorder for MockattemptStore.
+type MockattemptStoreMockRecorder struct {
+	mock *MockattemptStore
+}
+
+// NewMockattemptStore creates a new mock instance.
+func NewMockattemptStore(ctrl *gomock.Controller) *MockattemptStore {
+	mock := &MockattemptStore{ctrl: ctrl}
+	mock.recorder = &MockattemptStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockattemptStore) EXPECT() *MockattemptStoreMockRecorder {
+	return m.recorder
+}
+
+// AddFailure mocks base method.
+func (m *MockattemptStore) AddFailure(ctx context.Context, key string, expiresAt time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFailure", ctx, key, expiresAt)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFailure indicates an expected call of AddFailure.
+func (mr *MockattemptStoreMockRecorder) AddFailure(ctx, key, expiresAt any) *MockattemptStoreAddFailureCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFailure", reflect.TypeOf((*MockattemptStore)(nil).AddFailure), ctx, key, expiresAt)
+	return &MockattemptStoreAddFailureCall{Call: call}
+}
+
+// MockattemptStoreAddFailureCall wrap *gomock.Call
+type MockattemptStoreAddFailureCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockattemptStoreAddFailureCall) Return(arg0 int, arg1 error) *MockattemptStoreAddFailureCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockattemptStoreAddFailureCall) Do(f func(context.Context, string, time.Time) (int, error)) *MockattemptStoreAddFailureCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockattemptStoreAddFailureCall) DoAndReturn(f func(context.Context, string, time.Time) (int, error)) *MockattemptStoreAddFailureCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockattemptStore) Get(ctx context.Context, key string) (models.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(models.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockattemptStoreMockRecorder) Get(ctx, key any) *MockattemptStoreGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockattemptStore)(nil).Get), ctx, key)
+	return &MockattemptStoreGetCall{Call: call}
+}
+
+// MockattemptStoreGetCall wrap *gomock.Call
+type MockattemptStoreGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockattemptStoreGetCall) Return(arg0 models.LoginAttempt, arg1 error) *MockattemptStoreGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockattemptStoreGetCall) Do(f func(context.Context, string) (models.LoginAttempt, error)) *MockattemptStoreGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockattemptStoreGetCall) DoAndReturn(f func(context.Context, string) (models.LoginAttempt, error)) *MockattemptStoreGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Lock mocks base method.
+func (m *MockattemptStore) Lock(ctx context.Context, key string, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, key, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockattemptStoreMockRecorder) Lock(ctx, key, lockedUntil any) *MockattemptStoreLockCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockattemptStore)(nil).Lock), ctx, key, lockedUntil)
+	return &MockattemptStoreLockCall{Call: call}
+}
+
+// MockattemptStoreLockCall wrap *gomock.Call
+type MockattemptStoreLockCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockattemptStoreLockCall) Return(arg0 error) *MockattemptStoreLockCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockattemptStoreLockCall) Do(f func(context.Context, string, time.Time) error) *MockattemptStoreLockCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockattemptStoreLockCall) DoAndReturn(f func(context.Context, string, time.Time) error) *MockattemptStoreLockCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Reset mocks base method.
+func (m *MockattemptStore) Reset(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockattemptStoreMockRecorder) Reset(ctx, key any) *MockattemptStoreResetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockattemptStore)(nil).Reset), ctx, key)
+	return &MockattemptStoreResetCall{Call: call}
+}
+
+// MockattemptStoreResetCall wrap *gomock.Call
+type MockattemptStoreResetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockattemptStoreResetCall) Return(arg0 error) *MockattemptStoreResetCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockattemptStoreResetCall) Do(f func(context.Context, string) error) *MockattemptStoreResetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockattemptStoreResetCall) DoAndReturn(f func(context.Context, string) error) *MockattemptStoreResetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,171 @@
+package lockout_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/lockout"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	currentTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	accountPolicy = lockout.Policy{Threshold: 3, Duration: time.Minute, MaxDuration: 5 * time.Minute}
+	ipPolicy      = lockout.Policy{Threshold: 10, Duration: time.Minute, MaxDuration: time.Hour}
+)
+
+func newService(t *testing.T) (*lockout.Service, *MockattemptStore) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	attemptStore := NewMockattemptStore(ctrl)
+	service := lockout.NewService(func() time.Time { return currentTime }, attemptStore, 15*time.Minute, accountPolicy, ipPolicy)
+
+	return service, attemptStore
+}
+
+func key(prefix, subject string) string {
+	hash := sha256.Sum256([]byte(subject))
+	return prefix + hex.EncodeToString(hash[:])
+}
+
+func TestService_Check(t *testing.T) {
+	accountKey := key("account:", "user@example.com")
+	ipKey := key("ip:", "192.0.2.1")
+
+	lockedUntil := func(d time.Duration) *time.Time {
+		lockedUntil := currentTime.Add(d)
+		return &lockedUntil
+	}
+
+	testCases := map[string]struct {
+		accountAttempt models.LoginAttempt
+		ipAttempt      models.LoginAttempt
+		wantRetryAfter time.Duration
+	}{
+		"It should allow login without failures": {},
+		"It should allow login when lock has ended": {
+			accountAttempt: models.LoginAttempt{Failures: 3, LockedUntil: lockedUntil(-time.Second)},
+		},
+		"It should lock login when account is locked": {
+			accountAttempt: models.LoginAttempt{Failures: 3, LockedUntil: lockedUntil(time.Minute)},
+			wantRetryAfter: time.Minute,
+		},
+		"It should lock login until both account and client IP are unlocked": {
+			accountAttempt: models.LoginAttempt{Failures: 3, LockedUntil: lockedUntil(time.Minute)},
+			ipAttempt:      models.LoginAttempt{Failures: 12, LockedUntil: lockedUntil(4 * time.Minute)},
+			wantRetryAfter: 4 * time.Minute,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, attemptStore := newService(t)
+
+			attemptStore.EXPECT().Get(gomock.Any(), accountKey).Return(testCase.accountAttempt, nil)
+			attemptStore.EXPECT().Get(gomock.Any(), ipKey).Return(testCase.ipAttempt, nil)
+
+			err := service.Check(t.Context(), " User@Example.com", "192.0.2.1")
+			if testCase.wantRetryAfter == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, models.ErrLoginLocked)
+
+			var lockedErr *models.LoginLockedError
+			require.ErrorAs(t, err, &lockedErr)
+			assert.Equal(t, testCase.wantRetryAfter, lockedErr.RetryAfter)
+		})
+	}
+
+	t.Run("It should check only account when client IP is unknown", func(t *testing.T) {
+		service, attemptStore := newService(t)
+
+		attemptStore.EXPECT().Get(gomock.Any(), accountKey).Return(models.LoginAttempt{}, nil)
+
+		err := service.Check(t.Context(), "user@example.com", "")
+		require.NoError(t, err)
+	})
+
+	t.Run("It should propagate error from attempt store", func(t *testing.T) {
+		service, attemptStore := newService(t)
+
+		storeErr := errors.New("store error")
+		attemptStore.EXPECT().Get(gomock.Any(), accountKey).Return(models.LoginAttempt{}, storeErr)
+
+		err := service.Check(t.Context(), "user@example.com", "192.0.2.1")
+		assert.ErrorIs(t, err, storeErr)
+	})
+}
+
+func TestService_RegisterFailure(t *testing.T) {
+	accountKey := key("account:", "user@example.com")
+	ipKey := key("ip:", "192.0.2.1")
+	expiresAt := currentTime.Add(15 * time.Minute)
+
+	testCases := map[string]struct {
+		accountFailures int
+		wantLockedFor   time.Duration
+	}{
+		"It should not lock login below threshold": {
+			accountFailures: 2,
+		},
+		"It should lock login when threshold is reached": {
+			accountFailures: 3,
+			wantLockedFor:   time.Minute,
+		},
+		"It should double lock duration with every further failure": {
+			accountFailures: 5,
+			wantLockedFor:   4 * time.Minute,
+		},
+		"It should not lock login longer than max duration": {
+			accountFailures: 6,
+			wantLockedFor:   5 * time.Minute,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, attemptStore := newService(t)
+
+			attemptStore.EXPECT().AddFailure(gomock.Any(), accountKey, expiresAt).Return(testCase.accountFailures, nil)
+			attemptStore.EXPECT().AddFailure(gomock.Any(), ipKey, expiresAt).Return(1, nil)
+
+			if testCase.wantLockedFor != 0 {
+				attemptStore.EXPECT().Lock(gomock.Any(), accountKey, currentTime.Add(testCase.wantLockedFor)).Return(nil)
+			}
+
+			err := service.RegisterFailure(t.Context(), "user@example.com", "192.0.2.1")
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("It should lock login from client IP with its own policy", func(t *testing.T) {
+		service, attemptStore := newService(t)
+
+		attemptStore.EXPECT().AddFailure(gomock.Any(), accountKey, expiresAt).Return(1, nil)
+		attemptStore.EXPECT().AddFailure(gomock.Any(), ipKey, expiresAt).Return(10, nil)
+		attemptStore.EXPECT().Lock(gomock.Any(), ipKey, currentTime.Add(time.Minute)).Return(nil)
+
+		err := service.RegisterFailure(t.Context(), "user@example.com", "192.0.2.1")
+		require.NoError(t, err)
+	})
+}
+
+func TestService_RegisterSuccess(t *testing.T) {
+	service, attemptStore := newService(t)
+
+	attemptStore.EXPECT().Reset(gomock.Any(), key("account:", "user@example.com")).Return(nil)
+
+	err := service.RegisterSuccess(t.Context(), "User@example.com")
+	require.NoError(t, err)
+}
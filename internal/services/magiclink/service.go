@@ -0,0 +1,167 @@
+package magiclink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type userService interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	MarkVerified(ctx context.Context, id uint, verifiedAt time.Time) error
+}
+
+type actionTokenService interface {
+	Create(ctx context.Context, user *models.User, purpose models.ActionPurpose, duration time.Duration) (string, error)
+	Consume(ctx context.Context, purpose models.ActionPurpose, actionToken string) (*token.JwtActionClaims, error)
+}
+
+type authenticator interface {
+	LoginUser(
+		ctx context.Context,
+		user *models.User,
+		scopes models.Scopes,
+		client models.Client,
+		provider string,
+	) (*responses.LoginResponse, error)
+}
+
+type mailSender interface {
+	Send(ctx context.Context, message mail.Message) error
+}
+
+// Service logs users in without a password with single-use links sent to their email.
+type Service struct {
+	now                func() time.Time
+	userService        userService
+	actionTokenService actionTokenService
+	authenticator      authenticator
+	mailSender         mailSender
+	mailFrom           string
+	linkBaseURL        string
+	linkDuration       time.Duration
+}
+
+func NewService(
+	now func() time.Time,
+	userService userService,
+	actionTokenService actionTokenService,
+	authenticator authenticator,
+	mailSender mailSender,
+	mailFrom string,
+	linkBaseURL string,
+	linkDuration time.Duration,
+) *Service {
+	return &Service{
+		now:                now,
+		userService:        userService,
+		actionTokenService: actionTokenService,
+		authenticator:      authenticator,
+		mailSender:         mailSender,
+		mailFrom:           mailFrom,
+		linkBaseURL:        linkBaseURL,
+		linkDuration:       linkDuration,
+	}
+}
+
+// SendLink emails a login link to the user with the email.
+// It succeeds for unknown emails as well, so that it can't be used to check who is registered.
+func (s *Service) SendLink(ctx context.Context, email string) error {
+	user, err := s.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get user by email: %w", err)
+	}
+
+	actionToken, err := s.actionTokenService.Create(ctx, &user, models.ActionMagicLinkLogin, s.linkDuration)
+	if err != nil {
+		return fmt.Errorf("create magic link token: %w", err)
+	}
+
+	link, err := url.JoinPath(s.linkBaseURL, "login", "magic-link")
+	if err != nil {
+		return fmt.Errorf("join magic link path: %w", err)
+	}
+
+	message := mail.Message{
+		From:    s.mailFrom,
+		To:      user.Email,
+		Subject: "Your login link",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nLog in by following the link, it is valid for %s and can be used once:\n%s?token=%s\n\n"+
+				"If you didn't ask to log in, ignore this email.\n",
+			user.Name,
+			s.linkDuration,
+			link,
+			url.QueryEscape(actionToken),
+		),
+	}
+
+	if err := s.mailSender.Send(ctx, message); err != nil {
+		return fmt.Errorf("send magic link email: %w", err)
+	}
+
+	return nil
+}
+
+// Login logs in the user the magic link token was issued for. Following the link proves the ownership
+// of the email, so an unverified email is marked as verified. When MFA is enabled for the user,
+// only an MFA token is returned as with the password login.
+//
+// It returns [models.ErrInvalidScope] when unknown scopes are requested, and [models.ErrInvalidActionToken]
+// when the token is invalid, expired, already used, or the email has changed since the token was issued.
+func (s *Service) Login(
+	ctx context.Context,
+	magicLinkToken string,
+	requestedScopes models.Scopes,
+	client models.Client,
+) (*responses.LoginResponse, error) {
+	// Scopes are checked first, so that the single-use token isn't spent on a request that fails anyway.
+	scopes, err := models.AllScopes().Grant(requestedScopes)
+	if err != nil {
+		return nil, fmt.Errorf("grant scopes: %w", err)
+	}
+
+	claims, err := s.actionTokenService.Consume(ctx, models.ActionMagicLinkLogin, magicLinkToken)
+	if err != nil {
+		return nil, fmt.Errorf("consume magic link token: %w", err)
+	}
+
+	user, err := s.userService.GetByID(ctx, claims.ID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil, errors.Join(models.ErrInvalidActionToken, err)
+	} else if err != nil {
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user.Email != claims.Email {
+		return nil, fmt.Errorf("%w: issued for another email", models.ErrInvalidActionToken)
+	}
+
+	if user.VerifiedAt == nil {
+		verifiedAt := s.now()
+		if err := s.userService.MarkVerified(ctx, user.ID, verifiedAt); err != nil {
+			return nil, fmt.Errorf("mark user verified: %w", err)
+		}
+
+		user.VerifiedAt = &verifiedAt
+	}
+
+	response, err := s.authenticator.LoginUser(ctx, &user, scopes, client, models.LoginProviderMagicLink)
+	if err != nil {
+		return nil, fmt.Errorf("login user: %w", err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,206 @@
+package magiclink_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/magiclink"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var (
+	currentTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	client      = models.Client{IPAddress: "192.0.2.1", UserAgent: "Mozilla/5.0"}
+)
+
+type serviceMocks struct {
+	userService        *MockuserService
+	actionTokenService *MockactionTokenService
+	authenticator      *Mockauthenticator
+	mailSender         *mail.MemorySender
+}
+
+func newService(t *testing.T) (*magiclink.Service, serviceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := serviceMocks{
+		userService:        NewMockuserService(ctrl),
+		actionTokenService: NewMockactionTokenService(ctrl),
+		authenticator:      NewMockauthenticator(ctrl),
+		mailSender:         mail.NewMemorySender(),
+	}
+
+	service := magiclink.NewService(
+		func() time.Time { return currentTime },
+		mocks.userService,
+		mocks.actionTokenService,
+		mocks.authenticator,
+		mocks.mailSender,
+		"no-reply@example.com",
+		"https://app.example.com",
+		15*time.Minute,
+	)
+
+	return service, mocks
+}
+
+func TestService_SendLink(t *testing.T) {
+	user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", Name: "name"}
+
+	t.Run("It should email login link", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().GetUserByEmail(gomock.Any(), "user@example.com").Return(user, nil)
+		mocks.actionTokenService.EXPECT().
+			Create(gomock.Any(), &user, models.ActionMagicLinkLogin, 15*time.Minute).
+			Return("magic-link-token", nil)
+
+		err := service.SendLink(t.Context(), "user@example.com")
+		require.NoError(t, err)
+
+		messages := mocks.mailSender.Messages()
+		require.Len(t, messages, 1)
+
+		assert.Equal(t, "no-reply@example.com", messages[0].From)
+		assert.Equal(t, "user@example.com", messages[0].To)
+		assert.Contains(t, messages[0].Body, "https://app.example.com/login/magic-link?token=magic-link-token")
+	})
+
+	t.Run("It should not email unknown user", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().
+			GetUserByEmail(gomock.Any(), "unknown@example.com").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		err := service.SendLink(t.Context(), "unknown@example.com")
+		require.NoError(t, err)
+
+		assert.Empty(t, mocks.mailSender.Messages())
+	})
+}
+
+func TestService_Login(t *testing.T) {
+	claims := &token.JwtActionClaims{ID: 100, Purpose: models.ActionMagicLinkLogin, Email: "user@example.com"}
+	wantResponse := responses.NewLoginResponse("access-token", "refresh-token", 1735786800)
+
+	t.Run("It should return ErrInvalidScope without consuming token", func(t *testing.T) {
+		service, _ := newService(t)
+
+		_, err := service.Login(t.Context(), "magic-link-token", models.Scopes{"unknown"}, client)
+		assert.ErrorIs(t, err, models.ErrInvalidScope)
+	})
+
+	t.Run("It should propagate ErrInvalidActionToken when token is invalid", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.actionTokenService.EXPECT().
+			Consume(gomock.Any(), models.ActionMagicLinkLogin, "magic-link-token").
+			Return(nil, models.ErrInvalidActionToken)
+
+		_, err := service.Login(t.Context(), "magic-link-token", nil, client)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should return ErrInvalidActionToken when email has changed", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.actionTokenService.EXPECT().
+			Consume(gomock.Any(), models.ActionMagicLinkLogin, "magic-link-token").
+			Return(claims, nil)
+		mocks.userService.EXPECT().
+			GetByID(gomock.Any(), uint(100)).
+			Return(models.User{Model: gorm.Model{ID: 100}, Email: "new@example.com"}, nil)
+
+		_, err := service.Login(t.Context(), "magic-link-token", nil, client)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should return ErrInvalidActionToken when user doesn't exist", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.actionTokenService.EXPECT().
+			Consume(gomock.Any(), models.ActionMagicLinkLogin, "magic-link-token").
+			Return(claims, nil)
+		mocks.userService.EXPECT().
+			GetByID(gomock.Any(), uint(100)).
+			Return(models.User{}, models.ErrUserNotFound)
+
+		_, err := service.Login(t.Context(), "magic-link-token", nil, client)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should verify email and log in user with requested scopes", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com"}
+		scopes := models.Scopes{models.ScopePostsRead}
+
+		mocks.actionTokenService.EXPECT().
+			Consume(gomock.Any(), models.ActionMagicLinkLogin, "magic-link-token").
+			Return(claims, nil)
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().MarkVerified(gomock.Any(), uint(100), currentTime).Return(nil)
+
+		verifiedUser := user
+		verifiedUser.VerifiedAt = &currentTime
+		mocks.authenticator.
+			EXPECT().
+			LoginUser(gomock.Any(), &verifiedUser, scopes, client, models.LoginProviderMagicLink).
+			Return(wantResponse, nil)
+
+		response, err := service.Login(t.Context(), "magic-link-token", scopes, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantResponse, response)
+	})
+
+	t.Run("It should log in verified user with all scopes by default", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", VerifiedAt: &currentTime}
+
+		mocks.actionTokenService.EXPECT().
+			Consume(gomock.Any(), models.ActionMagicLinkLogin, "magic-link-token").
+			Return(claims, nil)
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.authenticator.
+			EXPECT().
+			LoginUser(gomock.Any(), &user, models.AllScopes(), client, models.LoginProviderMagicLink).
+			Return(wantResponse, nil)
+
+		response, err := service.Login(t.Context(), "magic-link-token", nil, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantResponse, response)
+	})
+
+	t.Run("It should propagate an error from authenticator", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		errTest := errors.New("test error")
+		user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", VerifiedAt: &currentTime}
+
+		mocks.actionTokenService.EXPECT().
+			Consume(gomock.Any(), models.ActionMagicLinkLogin, "magic-link-token").
+			Return(claims, nil)
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.authenticator.
+			EXPECT().
+			LoginUser(gomock.Any(), &user, models.AllScopes(), client, models.LoginProviderMagicLink).
+			Return(nil, errTest)
+
+		_, err := service.Login(t.Context(), "magic-link-token", nil, client)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
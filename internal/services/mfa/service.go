@@ -0,0 +1,229 @@
+package mfa
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	secretLength       = 20
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+type mfaRepository interface {
+	Enroll(ctx context.Context, totpSecret *models.TOTPSecret, recoveryCodes []models.RecoveryCode) error
+	GetTOTPSecret(ctx context.Context, userID uint) (models.TOTPSecret, error)
+	ConfirmTOTPSecret(ctx context.Context, userID uint, step int64, confirmedAt time.Time) error
+	UseTOTPStep(ctx context.Context, userID uint, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) error
+}
+
+type userService interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+}
+
+// Enrollment is what the user needs to set up the authenticator app.
+// The secret and recovery codes are returned only once and can't be retrieved later.
+type Enrollment struct {
+	Secret        string
+	URI           string
+	RecoveryCodes []string
+}
+
+// Service manages TOTP based two-factor authentication.
+type Service struct {
+	now           func() time.Time
+	mfaRepository mfaRepository
+	userService   userService
+	issuer        string
+}
+
+// NewService creates the service. The issuer names the service in authenticator apps.
+func NewService(now func() time.Time, mfaRepository mfaRepository, userService userService, issuer string) *Service {
+	return &Service{
+		now:           now,
+		mfaRepository: mfaRepository,
+		userService:   userService,
+		issuer:        issuer,
+	}
+}
+
+// Enroll starts MFA enrollment of the user with a new TOTP secret and recovery codes.
+// MFA is enabled only once the enrollment is confirmed with [Service.Confirm].
+// It returns [models.ErrMFAAlreadyEnabled] when MFA is already enabled for the user.
+func (s *Service) Enroll(ctx context.Context, userID uint) (Enrollment, error) {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return Enrollment{}, fmt.Errorf("get user by id: %w", err)
+	}
+
+	rawSecret := make([]byte, secretLength)
+	if _, err := rand.Read(rawSecret); err != nil {
+		return Enrollment{}, fmt.Errorf("generate totp secret: %w", err)
+	}
+
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(rawSecret)
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	storedRecoveryCodes := make([]models.RecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		recoveryCode := strings.ToLower(rand.Text()[:recoveryCodeLength])
+
+		recoveryCodes = append(recoveryCodes, recoveryCode)
+		storedRecoveryCodes = append(storedRecoveryCodes, models.RecoveryCode{
+			UserID:   user.ID,
+			CodeHash: hashRecoveryCode(recoveryCode),
+		})
+	}
+
+	totpSecret := &models.TOTPSecret{
+		UserID: user.ID,
+		Secret: secret,
+	}
+
+	if err := s.mfaRepository.Enroll(ctx, totpSecret, storedRecoveryCodes); err != nil {
+		return Enrollment{}, fmt.Errorf("enroll mfa in repository: %w", err)
+	}
+
+	enrollment := Enrollment{
+		Secret:        secret,
+		URI:           s.keyURI(user.Email, secret),
+		RecoveryCodes: recoveryCodes,
+	}
+
+	return enrollment, nil
+}
+
+// Confirm enables MFA for the user once the code from the authenticator app matches the pending enrollment.
+// It returns [models.ErrMFANotEnrolled] when there is no pending enrollment, [models.ErrMFAAlreadyEnabled]
+// when MFA is already enabled, and [models.ErrInvalidMFACode] when the code doesn't match.
+func (s *Service) Confirm(ctx context.Context, userID uint, code string) error {
+	totpSecret, err := s.mfaRepository.GetTOTPSecret(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get totp secret: %w", err)
+	}
+
+	if totpSecret.ConfirmedAt != nil {
+		return models.ErrMFAAlreadyEnabled
+	}
+
+	step, err := s.matchStep(&totpSecret, code)
+	if err != nil {
+		return fmt.Errorf("match totp code: %w", err)
+	}
+
+	if err := s.mfaRepository.ConfirmTOTPSecret(ctx, userID, step, s.now()); err != nil {
+		return fmt.Errorf("confirm totp secret in repository: %w", err)
+	}
+
+	return nil
+}
+
+// IsEnabled reports whether the user has confirmed MFA enrollment.
+func (s *Service) IsEnabled(ctx context.Context, userID uint) (bool, error) {
+	totpSecret, err := s.mfaRepository.GetTOTPSecret(ctx, userID)
+	if errors.Is(err, models.ErrMFANotEnrolled) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("get totp secret: %w", err)
+	}
+
+	return totpSecret.ConfirmedAt != nil, nil
+}
+
+// Verify checks the second factor of the user, either a code from the authenticator app or a recovery code.
+// Every code is accepted only once. It returns [models.ErrInvalidMFACode] when the code doesn't match.
+func (s *Service) Verify(ctx context.Context, userID uint, code string) error {
+	totpSecret, err := s.mfaRepository.GetTOTPSecret(ctx, userID)
+	if errors.Is(err, models.ErrMFANotEnrolled) {
+		return errors.Join(models.ErrInvalidMFACode, err)
+	} else if err != nil {
+		return fmt.Errorf("get totp secret: %w", err)
+	}
+
+	if totpSecret.ConfirmedAt == nil {
+		return fmt.Errorf("%w: mfa is not confirmed", models.ErrInvalidMFACode)
+	}
+
+	if len(code) != totpDigits {
+		err := s.mfaRepository.UseRecoveryCode(ctx, userID, hashRecoveryCode(code), s.now())
+		if err != nil {
+			return fmt.Errorf("use recovery code: %w", err)
+		}
+
+		return nil
+	}
+
+	step, err := s.matchStep(&totpSecret, code)
+	if err != nil {
+		return fmt.Errorf("match totp code: %w", err)
+	}
+
+	if err := s.mfaRepository.UseTOTPStep(ctx, userID, step); err != nil {
+		return fmt.Errorf("use totp step: %w", err)
+	}
+
+	return nil
+}
+
+// matchStep returns the time step the code has been generated for, allowing for clock drift.
+func (s *Service) matchStep(totpSecret *models.TOTPSecret, code string) (int64, error) {
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(totpSecret.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("decode totp secret: %w", err)
+	}
+
+	currentStep := s.now().Unix() / totpPeriod
+	for step := currentStep - totpSkew; step <= currentStep+totpSkew; step++ {
+		expected, err := totpCode(secret, step)
+		if err != nil {
+			return 0, fmt.Errorf("generate totp code: %w", err)
+		}
+
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, nil
+		}
+	}
+
+	return 0, models.ErrInvalidMFACode
+}
+
+// keyURI returns the URI authenticator apps import the secret from, usually rendered as a QR code.
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format.
+func (s *Service) keyURI(email, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", s.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", strconv.Itoa(totpDigits))
+	query.Set("period", strconv.Itoa(totpPeriod))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + s.issuer + ":" + email,
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String()
+}
+
+func hashRecoveryCode(recoveryCode string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(recoveryCode))))
+	return hex.EncodeToString(hash[:])
+}
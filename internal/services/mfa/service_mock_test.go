@@ -0,0 +1,297 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=mfa_test -typed=true
+//
+
+// Package mfa_test is a generated GoMock package.
+package mfa_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockmfaRepository is a mock of mfaRepository interface.
+type MockmfaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockmfaRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockmfaRepositoryMockRecorder is the mock recorder for MockmfaRepository.
+type MockmfaRepositoryMockRecorder struct {
+	mock *MockmfaRepository
+}
+
+// NewMockmfaRepository creates a new mock instance.
+func NewMockmfaRepository(ctrl *gomock.Controller) *MockmfaRepository {
+	mock := &MockmfaRepository{ctrl: ctrl}
+	mock.recorder = &MockmfaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmfaRepository) EXPECT() *MockmfaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTOTPSecret mocks base method.
+func (m *MockmfaRepository) ConfirmTOTPSecret(ctx context.Context, userID uint, step int64, confirmedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPSecret", ctx, userID, step, confirmedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTPSecret indicates an expected call of ConfirmTOTPSecret.
+func (mr *MockmfaRepositoryMockRecorder) ConfirmTOTPSecret(ctx, userID, step, confirmedAt any) *MockmfaRepositoryConfirmTOTPSecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPSecret", reflect.TypeOf((*MockmfaRepository)(nil).ConfirmTOTPSecret), ctx, userID, step, confirmedAt)
+	return &MockmfaRepositoryConfirmTOTPSecretCall{Call: call}
+}
+
+// MockmfaRepositoryConfirmTOTPSecretCall wrap *gomock.Call
+type MockmfaRepositoryConfirmTOTPSecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaRepositoryConfirmTOTPSecretCall) Return(arg0 error) *MockmfaRepositoryConfirmTOTPSecretCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaRepositoryConfirmTOTPSecretCall) Do(f func(context.Context, uint, int64, time.Time) error) *MockmfaRepositoryConfirmTOTPSecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaRepositoryConfirmTOTPSecretCall) DoAndReturn(f func(context.Context, uint, int64, time.Time) error) *MockmfaRepositoryConfirmTOTPSecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Enroll mocks base method.
+func (m *MockmfaRepository) Enroll(ctx context.Context, totpSecret *models.TOTPSecret, recoveryCodes []models.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, totpSecret, recoveryCodes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockmfaRepositoryMockRecorder) Enroll(ctx, totpSecret, recoveryCodes any) *MockmfaRepositoryEnrollCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockmfaRepository)(nil).Enroll), ctx, totpSecret, recoveryCodes)
+	return &MockmfaRepositoryEnrollCall{Call: call}
+}
+
+// MockmfaRepositoryEnrollCall wrap *gomock.Call
+type MockmfaRepositoryEnrollCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaRepositoryEnrollCall) Return(arg0 error) *MockmfaRepositoryEnrollCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaRepositoryEnrollCall) Do(f func(context.Context, *models.TOTPSecret, []models.RecoveryCode) error) *MockmfaRepositoryEnrollCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaRepositoryEnrollCall) DoAndReturn(f func(context.Context, *models.TOTPSecret, []models.RecoveryCode) error) *MockmfaRepositoryEnrollCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetTOTPSecret mocks base method.
+func (m *MockmfaRepository) GetTOTPSecret(ctx context.Context, userID uint) (models.TOTPSecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTPSecret", ctx, userID)
+	ret0, _ := ret[0].(models.TOTPSecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTPSecret indicates an expected call of GetTOTPSecret.
+func (mr *MockmfaRepositoryMockRecorder) GetTOTPSecret(ctx, userID any) *MockmfaRepositoryGetTOTPSecretCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTPSecret", reflect.TypeOf((*MockmfaRepository)(nil).GetTOTPSecret), ctx, userID)
+	return &MockmfaRepositoryGetTOTPSecretCall{Call: call}
+}
+
+// MockmfaRepositoryGetTOTPSecretCall wrap *gomock.Call
+type MockmfaRepositoryGetTOTPSecretCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaRepositoryGetTOTPSecretCall) Return(arg0 models.TOTPSecret, arg1 error) *MockmfaRepositoryGetTOTPSecretCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaRepositoryGetTOTPSecretCall) Do(f func(context.Context, uint) (models.TOTPSecret, error)) *MockmfaRepositoryGetTOTPSecretCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaRepositoryGetTOTPSecretCall) DoAndReturn(f func(context.Context, uint) (models.TOTPSecret, error)) *MockmfaRepositoryGetTOTPSecretCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockmfaRepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockmfaRepositoryMockRecorder) UseRecoveryCode(ctx, userID, codeHash, usedAt any) *MockmfaRepositoryUseRecoveryCodeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockmfaRepository)(nil).UseRecoveryCode), ctx, userID, codeHash, usedAt)
+	return &MockmfaRepositoryUseRecoveryCodeCall{Call: call}
+}
+
+// MockmfaRepositoryUseRecoveryCodeCall wrap *gomock.Call
+type MockmfaRepositoryUseRecoveryCodeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaRepositoryUseRecoveryCodeCall) Return(arg0 error) *MockmfaRepositoryUseRecoveryCodeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaRepositoryUseRecoveryCodeCall) Do(f func(context.Context, uint, string, time.Time) error) *MockmfaRepositoryUseRecoveryCodeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaRepositoryUseRecoveryCodeCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MockmfaRepositoryUseRecoveryCodeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockmfaRepository) UseTOTPStep(ctx context.Context, userID uint, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockmfaRepositoryMockRecorder) UseTOTPStep(ctx, userID, step any) *MockmfaRepositoryUseTOTPStepCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockmfaRepository)(nil).UseTOTPStep), ctx, userID, step)
+	return &MockmfaRepositoryUseTOTPStepCall{Call: call}
+}
+
+// MockmfaRepositoryUseTOTPStepCall wrap *gomock.Call
+type MockmfaRepositoryUseTOTPStepCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmfaRepositoryUseTOTPStepCall) Return(arg0 error) *MockmfaRepositoryUseTOTPStepCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmfaRepositoryUseTOTPStepCall) Do(f func(context.Context, uint, int64) error) *MockmfaRepositoryUseTOTPStepCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmfaRepositoryUseTOTPStepCall) DoAndReturn(f func(context.Context, uint, int64) error) *MockmfaRepositoryUseTOTPStepCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockuserService is a mock of userService interface.
+type MockuserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockuserServiceMockRecorder is the mock recorder for MockuserService.
+type MockuserServiceMockRecorder struct {
+	mock *MockuserService
+}
+
+// NewMockuserService creates a new mock instance.
+func NewMockuserService(ctrl *gomock.Controller) *MockuserService {
+	mock := &MockuserService{ctrl: ctrl}
+	mock.recorder = &MockuserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserService) EXPECT() *MockuserServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockuserService) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserServiceMockRecorder) GetByID(ctx, id any) *MockuserServiceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserService)(nil).GetByID), ctx, id)
+	return &MockuserServiceGetByIDCall{Call: call}
+}
+
+// MockuserServiceGetByIDCall wrap *gomock.Call
+type MockuserServiceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
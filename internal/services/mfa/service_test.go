@@ -0,0 +1,250 @@
+package mfa_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/mfa"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// The secret and codes are test vectors from RFC 6238, truncated to 6 digits.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var (
+	// currentTime is in time step 37037036.
+	currentTime = time.Unix(1111111109, 0)
+	currentCode = "081804"
+)
+
+func newService(t *testing.T) (*mfa.Service, *MockmfaRepository, *MockuserService) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mfaRepository := NewMockmfaRepository(ctrl)
+	userService := NewMockuserService(ctrl)
+
+	return mfa.NewService(func() time.Time { return currentTime }, mfaRepository, userService, "Echo"), mfaRepository, userService
+}
+
+func hash(recoveryCode string) string {
+	hash := sha256.Sum256([]byte(recoveryCode))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestService_Enroll(t *testing.T) {
+	service, mfaRepository, userService := newService(t)
+
+	user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com"}
+
+	userService.EXPECT().
+		GetByID(gomock.Any(), uint(100)).
+		Return(user, nil)
+
+	var storedRecoveryCodes []models.RecoveryCode
+	mfaRepository.EXPECT().
+		Enroll(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, totpSecret *models.TOTPSecret, recoveryCodes []models.RecoveryCode) error {
+			assert.Equal(t, uint(100), totpSecret.UserID)
+			assert.Nil(t, totpSecret.ConfirmedAt)
+
+			storedRecoveryCodes = recoveryCodes
+			return nil
+		})
+
+	enrollment, err := service.Enroll(t.Context(), user.ID)
+	require.NoError(t, err)
+
+	uri, err := url.Parse(enrollment.URI)
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Echo:user@example.com", uri.Path)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+	assert.Equal(t, "Echo", uri.Query().Get("issuer"))
+
+	require.Len(t, enrollment.RecoveryCodes, 10)
+	require.Len(t, storedRecoveryCodes, 10)
+	for i, recoveryCode := range enrollment.RecoveryCodes {
+		assert.Equal(t, uint(100), storedRecoveryCodes[i].UserID)
+		assert.Equal(t, hash(recoveryCode), storedRecoveryCodes[i].CodeHash)
+	}
+}
+
+func TestService_Confirm(t *testing.T) {
+	pendingSecret := models.TOTPSecret{UserID: 100, Secret: rfcSecret}
+
+	testCases := map[string]struct {
+		code            string
+		setExpectations func(mfaRepository *MockmfaRepository)
+		wantErr         error
+	}{
+		"It should confirm enrollment": {
+			code: currentCode,
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(pendingSecret, nil)
+				mfaRepository.EXPECT().ConfirmTOTPSecret(gomock.Any(), uint(100), int64(37037036), currentTime).Return(nil)
+			},
+		},
+		"It should accept code of the next time step to tolerate clock drift": {
+			code: "050471",
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(pendingSecret, nil)
+				mfaRepository.EXPECT().ConfirmTOTPSecret(gomock.Any(), uint(100), int64(37037037), currentTime).Return(nil)
+			},
+		},
+		"It should reject invalid code": {
+			code: "000000",
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(pendingSecret, nil)
+			},
+			wantErr: models.ErrInvalidMFACode,
+		},
+		"It should return ErrMFAAlreadyEnabled error when enrollment is confirmed": {
+			code: currentCode,
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().
+					GetTOTPSecret(gomock.Any(), uint(100)).
+					Return(models.TOTPSecret{UserID: 100, Secret: rfcSecret, ConfirmedAt: &currentTime}, nil)
+			},
+			wantErr: models.ErrMFAAlreadyEnabled,
+		},
+		"It should return ErrMFANotEnrolled error when there is no enrollment": {
+			code: currentCode,
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().
+					GetTOTPSecret(gomock.Any(), uint(100)).
+					Return(models.TOTPSecret{}, models.ErrMFANotEnrolled)
+			},
+			wantErr: models.ErrMFANotEnrolled,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mfaRepository, _ := newService(t)
+
+			testCase.setExpectations(mfaRepository)
+
+			err := service.Confirm(t.Context(), 100, testCase.code)
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_IsEnabled(t *testing.T) {
+	testCases := map[string]struct {
+		totpSecret models.TOTPSecret
+		err        error
+		want       bool
+	}{
+		"It should report enabled mfa": {
+			totpSecret: models.TOTPSecret{ConfirmedAt: &currentTime},
+			want:       true,
+		},
+		"It should not report pending enrollment as enabled": {
+			totpSecret: models.TOTPSecret{},
+			want:       false,
+		},
+		"It should not report missing enrollment as enabled": {
+			err:  models.ErrMFANotEnrolled,
+			want: false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mfaRepository, _ := newService(t)
+
+			mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(testCase.totpSecret, testCase.err)
+
+			got, err := service.IsEnabled(t.Context(), 100)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestService_Verify(t *testing.T) {
+	confirmedSecret := models.TOTPSecret{UserID: 100, Secret: rfcSecret, ConfirmedAt: &currentTime}
+
+	testCases := map[string]struct {
+		code            string
+		setExpectations func(mfaRepository *MockmfaRepository)
+		wantErr         error
+	}{
+		"It should accept totp code": {
+			code: currentCode,
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(confirmedSecret, nil)
+				mfaRepository.EXPECT().UseTOTPStep(gomock.Any(), uint(100), int64(37037036)).Return(nil)
+			},
+		},
+		"It should reject used totp code": {
+			code: currentCode,
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(confirmedSecret, nil)
+				mfaRepository.EXPECT().
+					UseTOTPStep(gomock.Any(), uint(100), int64(37037036)).
+					Return(models.ErrInvalidMFACode)
+			},
+			wantErr: models.ErrInvalidMFACode,
+		},
+		"It should reject invalid totp code": {
+			code: "000000",
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(confirmedSecret, nil)
+			},
+			wantErr: models.ErrInvalidMFACode,
+		},
+		"It should accept recovery code": {
+			code: "abcdefghij",
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().GetTOTPSecret(gomock.Any(), uint(100)).Return(confirmedSecret, nil)
+				mfaRepository.EXPECT().
+					UseRecoveryCode(gomock.Any(), uint(100), hash("abcdefghij"), currentTime).
+					Return(nil)
+			},
+		},
+		"It should reject code when mfa is not confirmed": {
+			code: currentCode,
+			setExpectations: func(mfaRepository *MockmfaRepository) {
+				mfaRepository.EXPECT().
+					GetTOTPSecret(gomock.Any(), uint(100)).
+					Return(models.TOTPSecret{UserID: 100, Secret: rfcSecret}, nil)
+			},
+			wantErr: models.ErrInvalidMFACode,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mfaRepository, _ := newService(t)
+
+			testCase.setExpectations(mfaRepository)
+
+			err := service.Verify(t.Context(), 100, testCase.code)
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
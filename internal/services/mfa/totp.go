@@ -0,0 +1,41 @@
+package mfa
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // RFC 6238 authenticator apps use HMAC-SHA1 by default.
+	"encoding/binary"
+	"fmt"
+
+	safecast "github.com/ccoveille/go-safecast"
+)
+
+// TOTP parameters as described in RFC 6238, the defaults supported by every authenticator app.
+const (
+	totpPeriod = 30
+	totpDigits = 6
+	totpModulo = 1_000_000
+
+	// totpSkew is how many time steps before and after the current one are accepted to tolerate clock drift.
+	totpSkew = 1
+)
+
+// totpCode returns the code for the time step.
+func totpCode(secret []byte, step int64) (string, error) {
+	counter, err := safecast.Convert[uint64](step)
+	if err != nil {
+		return "", fmt.Errorf("convert time step: %w", err)
+	}
+
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], counter)
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as described in RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulo), nil
+}
@@ -0,0 +1,198 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/config"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+const (
+	googleProviderName = string(models.GOOGLE)
+	googleIssuerURL    = "https://accounts.google.com"
+
+	defaultEmailClaim         = "email"
+	defaultEmailVerifiedClaim = "email_verified"
+	defaultNameClaim          = "name"
+)
+
+// Identity is a user identity asserted by an OpenID Connect provider.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider verifies ID tokens issued by an OpenID Connect provider
+// and drives the authorization code flow against it.
+type Provider struct {
+	name         string
+	verifier     *oidc.IDTokenVerifier
+	audiences    []string
+	claims       config.ClaimMapping
+	oauth2Config oauth2.Config
+}
+
+// NewProvider discovers the provider configuration and keys by its issuer URL.
+func NewProvider(ctx context.Context, cfg config.OIDCProvider) (*Provider, error) {
+	if cfg.Name == "" || cfg.IssuerURL == "" || cfg.ClientID == "" {
+		return nil, errors.New("name, issuer and client id are required")
+	}
+
+	oidcProvider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("discover provider %s: %w", cfg.Name, err)
+	}
+
+	claims := cfg.Claims
+	if claims.Email == "" {
+		claims.Email = defaultEmailClaim
+	}
+
+	if claims.Name == "" {
+		claims.Name = defaultNameClaim
+	}
+
+	if claims.EmailVerified == "" {
+		claims.EmailVerified = defaultEmailVerifiedClaim
+	}
+
+	return &Provider{
+		name: cfg.Name,
+		// The audience is checked by the provider itself, as more than one audience may be allowed.
+		verifier:  oidcProvider.Verifier(&oidc.Config{SkipClientIDCheck: true}),
+		audiences: append([]string{cfg.ClientID}, cfg.Audiences...),
+		claims:    claims,
+		oauth2Config: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     oidcProvider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+		},
+	}, nil
+}
+
+// NewProviders creates providers from the config. Google is added when its client ID is set.
+func NewProviders(ctx context.Context, cfg config.OAuthConfig) ([]*Provider, error) {
+	providerConfigs := slices.Clone(cfg.Providers)
+
+	hasGoogle := slices.ContainsFunc(providerConfigs, func(provider config.OIDCProvider) bool {
+		return provider.Name == googleProviderName
+	})
+
+	if cfg.ClientID != "" && !hasGoogle {
+		providerConfigs = append(providerConfigs, config.OIDCProvider{
+			Name:         googleProviderName,
+			IssuerURL:    googleIssuerURL,
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+		})
+	}
+
+	providers := make([]*Provider, 0, len(providerConfigs))
+	for _, providerConfig := range providerConfigs {
+		provider, err := NewProvider(ctx, providerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("new provider: %w", err)
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// Name returns the name of the provider from the config.
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL returns the URL of the provider consent page.
+// The code challenge is derived from the verifier as defined by PKCE with the S256 method.
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	return p.oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier))
+}
+
+// Exchange exchanges the authorization code for an ID token and verifies it, including its nonce.
+// It returns [models.ErrInvalidAuthToken] when the provider rejects the code or the ID token is invalid.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (rawIDToken string, identity Identity, err error) {
+	token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			err = errors.Join(models.ErrInvalidAuthToken, err)
+		}
+
+		return "", Identity{}, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", Identity{}, errors.Join(models.ErrInvalidAuthToken, errors.New("token response has no id token"))
+	}
+
+	idToken, identity, err := p.verify(ctx, rawIDToken)
+	if err != nil {
+		return "", Identity{}, err
+	}
+
+	if idToken.Nonce != nonce {
+		return "", Identity{}, errors.Join(models.ErrInvalidAuthToken, errors.New("unexpected id token nonce"))
+	}
+
+	return rawIDToken, identity, nil
+}
+
+// Verify verifies the ID token and returns the identity from its claims.
+// It returns [models.ErrInvalidAuthToken] when the token is invalid or issued for another audience.
+func (p *Provider) Verify(ctx context.Context, rawIDToken string) (Identity, error) {
+	_, identity, err := p.verify(ctx, rawIDToken)
+	return identity, err
+}
+
+func (p *Provider) verify(ctx context.Context, rawIDToken string) (*oidc.IDToken, Identity, error) {
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, Identity{}, errors.Join(models.ErrInvalidAuthToken, fmt.Errorf("verify id token: %w", err))
+	}
+
+	allowedAudience := slices.ContainsFunc(idToken.Audience, func(audience string) bool {
+		return slices.Contains(p.audiences, audience)
+	})
+	if !allowedAudience {
+		return nil, Identity{}, errors.Join(models.ErrInvalidAuthToken, fmt.Errorf("unexpected audience %v", idToken.Audience))
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, Identity{}, fmt.Errorf("extract claims: %w", err)
+	}
+
+	email, _ := claims[p.claims.Email].(string)
+	name, _ := claims[p.claims.Name].(string)
+
+	// Some providers issue the claim as a string.
+	var emailVerified bool
+	switch value := claims[p.claims.EmailVerified].(type) {
+	case bool:
+		emailVerified = value
+	case string:
+		emailVerified = value == "true"
+	}
+
+	return idToken, Identity{
+		Subject:       idToken.Subject,
+		Email:         email,
+		EmailVerified: emailVerified,
+		Name:          name,
+	}, nil
+}
@@ -0,0 +1,332 @@
+package oauth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/config"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/oauth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeIssuerKeyID = "fake-issuer-key"
+
+// fakeIssuer is a local OpenID Connect provider serving discovery and JWKS documents,
+// and exchanging authorization codes issued by [fakeIssuer.authorize].
+type fakeIssuer struct {
+	url string
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]fakeAuthorization
+}
+
+type fakeAuthorization struct {
+	codeChallenge string
+	nonce         string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{
+			"issuer":                                server.URL,
+			"authorization_endpoint":                server.URL + "/authorize",
+			"token_endpoint":                        server.URL + "/token",
+			"jwks_uri":                              server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": fakeIssuerKeyID,
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	issuer := &fakeIssuer{url: server.URL, key: key, codes: make(map[string]fakeAuthorization)}
+
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		issuer.mu.Lock()
+		authorization, ok := issuer.codes[r.PostForm.Get("code")]
+		delete(issuer.codes, r.PostForm.Get("code"))
+		issuer.mu.Unlock()
+
+		codeChallenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(codeChallenge[:]) != authorization.codeChallenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"}))
+			return
+		}
+
+		idToken, err := issuer.sign(jwt.MapClaims{"nonce": authorization.nonce})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(t, w, map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+
+	return issuer
+}
+
+// authorize simulates the user consent at the authorization URL and returns the code and state
+// the provider would redirect the user back with.
+func (f *fakeIssuer) authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+
+	parsedURL, err := url.Parse(authURL)
+	require.NoError(t, err)
+
+	query := parsedURL.Query()
+	require.Equal(t, f.url+"/authorize", parsedURL.Scheme+"://"+parsedURL.Host+parsedURL.Path)
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+
+	code = rand.Text()
+
+	f.mu.Lock()
+	f.codes[code] = fakeAuthorization{codeChallenge: query.Get("code_challenge"), nonce: query.Get("nonce")}
+	f.mu.Unlock()
+
+	return code, query.Get("state")
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	assert.NoError(t, json.NewEncoder(w).Encode(body))
+}
+
+// idToken issues an ID token with default claims overridden by the given claims.
+func (f *fakeIssuer) idToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	signedToken, err := f.sign(claims)
+	require.NoError(t, err)
+
+	return signedToken
+}
+
+func (f *fakeIssuer) sign(claims jwt.MapClaims) (string, error) {
+	tokenClaims := jwt.MapClaims{
+		"iss":            f.url,
+		"sub":            "subject",
+		"aud":            "client-id",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"email":          "example@email.com",
+		"email_verified": true,
+		"name":           "name",
+	}
+
+	for claim, value := range claims {
+		tokenClaims[claim] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, tokenClaims)
+	token.Header["kid"] = fakeIssuerKeyID
+
+	return token.SignedString(f.key)
+}
+
+func TestProvider_Verify(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	anotherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	forgedToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": issuer.url,
+		"sub": "subject",
+		"aud": "client-id",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	forgedToken.Header["kid"] = fakeIssuerKeyID
+
+	rawForgedToken, err := forgedToken.SignedString(anotherKey)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		providerConfig config.OIDCProvider
+		idToken        string
+		wantIdentity   oauth.Identity
+		wantError      error
+	}{
+		"It should verify ID token": {
+			providerConfig: config.OIDCProvider{Name: "fake", IssuerURL: issuer.url, ClientID: "client-id"},
+			idToken:        issuer.idToken(t, nil),
+			wantIdentity:   oauth.Identity{Subject: "subject", Email: "example@email.com", EmailVerified: true, Name: "name"},
+		},
+		"It should accept additional audiences": {
+			providerConfig: config.OIDCProvider{
+				Name:      "fake",
+				IssuerURL: issuer.url,
+				ClientID:  "client-id",
+				Audiences: []string{"another-client-id"},
+			},
+			idToken:      issuer.idToken(t, jwt.MapClaims{"aud": "another-client-id"}),
+			wantIdentity: oauth.Identity{Subject: "subject", Email: "example@email.com", EmailVerified: true, Name: "name"},
+		},
+		"It should map custom claims": {
+			providerConfig: config.OIDCProvider{
+				Name:      "fake",
+				IssuerURL: issuer.url,
+				ClientID:  "client-id",
+				Claims:    config.ClaimMapping{Email: "upn", Name: "preferred_username"},
+			},
+			idToken:      issuer.idToken(t, jwt.MapClaims{"upn": "upn@email.com", "preferred_username": "username"}),
+			wantIdentity: oauth.Identity{Subject: "subject", Email: "upn@email.com", EmailVerified: true, Name: "username"},
+		},
+		"It should accept email_verified claim issued as string": {
+			providerConfig: config.OIDCProvider{Name: "fake", IssuerURL: issuer.url, ClientID: "client-id"},
+			idToken:        issuer.idToken(t, jwt.MapClaims{"email_verified": "true"}),
+			wantIdentity:   oauth.Identity{Subject: "subject", Email: "example@email.com", EmailVerified: true, Name: "name"},
+		},
+		"It should report unverified email": {
+			providerConfig: config.OIDCProvider{Name: "fake", IssuerURL: issuer.url, ClientID: "client-id"},
+			idToken:        issuer.idToken(t, jwt.MapClaims{"email_verified": false}),
+			wantIdentity:   oauth.Identity{Subject: "subject", Email: "example@email.com", EmailVerified: false, Name: "name"},
+		},
+		"It should reject ID token for another audience": {
+			providerConfig: config.OIDCProvider{Name: "fake", IssuerURL: issuer.url, ClientID: "client-id"},
+			idToken:        issuer.idToken(t, jwt.MapClaims{"aud": "another-client-id"}),
+			wantError:      models.ErrInvalidAuthToken,
+		},
+		"It should reject expired ID token": {
+			providerConfig: config.OIDCProvider{Name: "fake", IssuerURL: issuer.url, ClientID: "client-id"},
+			idToken:        issuer.idToken(t, jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}),
+			wantError:      models.ErrInvalidAuthToken,
+		},
+		"It should reject ID token from another issuer": {
+			providerConfig: config.OIDCProvider{Name: "fake", IssuerURL: issuer.url, ClientID: "client-id"},
+			idToken:        issuer.idToken(t, jwt.MapClaims{"iss": "https://another-issuer.example.com"}),
+			wantError:      models.ErrInvalidAuthToken,
+		},
+		"It should reject ID token signed with another key": {
+			providerConfig: config.OIDCProvider{Name: "fake", IssuerURL: issuer.url, ClientID: "client-id"},
+			idToken:        rawForgedToken,
+			wantError:      models.ErrInvalidAuthToken,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			provider, err := oauth.NewProvider(t.Context(), testCase.providerConfig)
+			require.NoError(t, err)
+
+			identity, err := provider.Verify(t.Context(), testCase.idToken)
+			if testCase.wantError != nil {
+				assert.ErrorIs(t, err, testCase.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantIdentity, identity)
+		})
+	}
+}
+
+func TestNewProviders(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	t.Run("It should create configured providers", func(t *testing.T) {
+		providers, err := oauth.NewProviders(t.Context(), config.OAuthConfig{
+			Providers: config.OIDCProviders{
+				{Name: "first", IssuerURL: issuer.url, ClientID: "client-id"},
+				{Name: "second", IssuerURL: issuer.url, ClientID: "client-id"},
+			},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, providers, 2)
+		assert.Equal(t, "first", providers[0].Name())
+		assert.Equal(t, "second", providers[1].Name())
+	})
+
+	t.Run("It should return an error when issuer is not available", func(t *testing.T) {
+		_, err := oauth.NewProviders(t.Context(), config.OAuthConfig{
+			Providers: config.OIDCProviders{{Name: "broken", IssuerURL: issuer.url + "/unknown", ClientID: "client-id"}},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	provider, err := oauth.NewProvider(t.Context(), config.OIDCProvider{
+		Name:         "fake",
+		IssuerURL:    issuer.url,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost/oauth/fake/callback",
+	})
+	require.NoError(t, err)
+
+	verifier := "code-verifier-with-enough-entropy-to-be-valid"
+
+	t.Run("It should exchange authorization code", func(t *testing.T) {
+		code, state := issuer.authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+		assert.Equal(t, "state", state)
+
+		rawIDToken, identity, err := provider.Exchange(t.Context(), code, verifier, "nonce")
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, rawIDToken)
+		assert.Equal(t, oauth.Identity{Subject: "subject", Email: "example@email.com", EmailVerified: true, Name: "name"}, identity)
+	})
+
+	t.Run("It should reject code exchanged with another verifier", func(t *testing.T) {
+		code, _ := issuer.authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+
+		_, _, err := provider.Exchange(t.Context(), code, "another-code-verifier", "nonce")
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should reject ID token with another nonce", func(t *testing.T) {
+		code, _ := issuer.authorize(t, provider.AuthCodeURL("state", "nonce", verifier))
+
+		_, _, err := provider.Exchange(t.Context(), code, verifier, "another-nonce")
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+}
@@ -2,86 +2,341 @@ package oauth
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
 
-	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
 )
 
-type Service struct {
-	idTokenVerifier *oidc.IDTokenVerifier
-	tokenService    tokenService
-	userService     userService
-}
+// stateTTL is how long the user has to complete the authorization code flow at the provider.
+const stateTTL = 10 * time.Minute
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
 
 type userService interface {
 	CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oAuthProvider *models.OAuthProviders) error
+	GetByID(ctx context.Context, id uint) (models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
 }
 
-type tokenService interface {
-	CreateAccessToken(ctx context.Context, user *models.User) (string, int64, error)
-	CreateRefreshToken(ctx context.Context, user *models.User) (string, error)
+type oAuthProviderRepository interface {
+	Create(ctx context.Context, oAuthProvider *models.OAuthProviders) error
+	GetBySubject(ctx context.Context, provider models.Providers, subject string) (models.OAuthProviders, error)
+	GetByUser(ctx context.Context, userID uint) ([]models.OAuthProviders, error)
+	AdoptLegacy(
+		ctx context.Context,
+		userID uint,
+		provider models.Providers,
+		subject string,
+		token string,
+	) (models.OAuthProviders, error)
+	Delete(ctx context.Context, userID, id uint) error
+}
+
+type authenticator interface {
+	LoginUser(
+		ctx context.Context,
+		user *models.User,
+		scopes models.Scopes,
+		client models.Client,
+		provider string,
+	) (*responses.LoginResponse, error)
+}
+
+type stateStore interface {
+	Save(ctx context.Context, state string, oAuthState models.OAuthState) error
+	Take(ctx context.Context, state string) (models.OAuthState, error)
+}
+
+type Service struct {
+	now            func() time.Time
+	providers      map[string]*Provider
+	stateStore     stateStore
+	oAuthProviders oAuthProviderRepository
+	authenticator  authenticator
+	userService    userService
 }
 
-func NewService(idTokenVerifier *oidc.IDTokenVerifier, tokenService tokenService, userService userService) *Service {
-	return &Service{idTokenVerifier: idTokenVerifier, tokenService: tokenService, userService: userService}
+func NewService(
+	now func() time.Time,
+	providers []*Provider,
+	stateStore stateStore,
+	oAuthProviderRepository oAuthProviderRepository,
+	authenticator authenticator,
+	userService userService,
+) *Service {
+	providersByName := make(map[string]*Provider, len(providers))
+	for _, provider := range providers {
+		providersByName[provider.Name()] = provider
+	}
+
+	return &Service{
+		now:            now,
+		providers:      providersByName,
+		stateStore:     stateStore,
+		oAuthProviders: oAuthProviderRepository,
+		authenticator:  authenticator,
+		userService:    userService,
+	}
+}
+
+// Authenticate logs the user in with an ID token issued by the provider, registering the user on the first login.
+// When MFA is enabled for the user, only an MFA token is returned, as with the password login.
+//
+// It returns [models.ErrOAuthProviderNotFound] when the provider is not configured,
+// and [models.ErrInvalidAuthToken] when the ID token is invalid.
+// On the first login it returns [models.ErrOAuthEmailNotVerified] when the provider hasn't verified the email,
+// and [models.ErrUserAlreadyExists] when the email belongs to a user, who has to link the provider first.
+func (s *Service) Authenticate(
+	ctx context.Context,
+	providerName string,
+	idToken string,
+	client models.Client,
+) (*responses.LoginResponse, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := provider.Verify(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify %s id token: %w", providerName, err)
+	}
+
+	return s.login(ctx, providerName, idToken, identity, client)
 }
 
-func (s Service) GoogleOAuth(ctx context.Context, token string) (accessToken, refreshToken string, exp int64, err error) {
-	payload, err := s.idTokenVerifier.Verify(ctx, token)
+// StartAuthorization starts the authorization code flow with PKCE.
+// It returns the URL of the provider consent page and the state the provider redirects the user back with.
+//
+// It returns [models.ErrOAuthProviderNotFound] when the provider is not configured.
+func (s *Service) StartAuthorization(ctx context.Context, providerName string) (authURL, state string, err error) {
+	provider, err := s.provider(providerName)
 	if err != nil {
-		return "", "", 0, fmt.Errorf("verify google token: %w", err)
+		return "", "", err
+	}
+
+	state = rand.Text()
+	oAuthState := models.OAuthState{
+		Provider:     providerName,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		Nonce:        rand.Text(),
+		ExpiresAt:    s.now().Add(stateTTL),
+	}
+
+	if err := s.stateStore.Save(ctx, state, oAuthState); err != nil {
+		return "", "", fmt.Errorf("save oauth state: %w", err)
 	}
 
-	var claims struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
+	return provider.AuthCodeURL(state, oAuthState.Nonce, oAuthState.CodeVerifier), state, nil
+}
+
+// CompleteAuthorization exchanges the authorization code the provider redirected the user back with
+// and logs the user in, registering the user on the first login.
+// When MFA is enabled for the user, only an MFA token is returned, as with the password login.
+//
+// It returns [models.ErrOAuthProviderNotFound] when the provider is not configured,
+// [models.ErrInvalidOAuthState] when the state is unknown, expired, already used or issued for another provider,
+// and [models.ErrInvalidAuthToken] when the provider rejects the code or issues an invalid ID token.
+// On the first login it returns [models.ErrOAuthEmailNotVerified] when the provider hasn't verified the email,
+// and [models.ErrUserAlreadyExists] when the email belongs to a user, who has to link the provider first.
+func (s *Service) CompleteAuthorization(
+	ctx context.Context,
+	providerName string,
+	state string,
+	code string,
+	client models.Client,
+) (*responses.LoginResponse, error) {
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
 	}
 
-	err = payload.Claims(&claims)
+	oAuthState, err := s.stateStore.Take(ctx, state)
 	if err != nil {
-		return "", "", 0, fmt.Errorf("extract claims: %w", err)
+		return nil, fmt.Errorf("take oauth state: %w", err)
 	}
 
-	if claims.Email == "" {
-		return "", "", 0, fmt.Errorf("email is empty")
+	if oAuthState.Provider != providerName {
+		return nil, fmt.Errorf("%w: issued for provider %s", models.ErrInvalidOAuthState, oAuthState.Provider)
 	}
 
-	user, err := s.userService.GetUserByEmail(ctx, claims.Email)
+	idToken, identity, err := provider.Exchange(ctx, code, oAuthState.CodeVerifier, oAuthState.Nonce)
 	if err != nil {
-		if !errors.Is(err, models.ErrUserNotFound) {
-			return "", "", 0, fmt.Errorf("get user: %w", err)
-		}
+		return nil, fmt.Errorf("exchange %s authorization code: %w", providerName, err)
+	}
 
-		user = models.User{
-			Email: claims.Email,
-			Name:  claims.Name,
-		}
+	return s.login(ctx, providerName, idToken, identity, client)
+}
 
-		oAuthProvider := models.OAuthProviders{
-			UserID:   user.ID,
-			Provider: models.GOOGLE,
-			Token:    token,
-		}
+func (s *Service) provider(providerName string) (*Provider, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrOAuthProviderNotFound, providerName)
+	}
 
-		err = s.userService.CreateUserAndOAuthProvider(ctx, &user, &oAuthProvider)
+	return provider, nil
+}
+
+func (s *Service) login(
+	ctx context.Context,
+	providerName string,
+	idToken string,
+	identity Identity,
+	client models.Client,
+) (*responses.LoginResponse, error) {
+	user, err := s.identityUser(ctx, providerName, idToken, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.authenticator.LoginUser(ctx, &user, models.AllScopes(), client, providerName)
+	if err != nil {
+		return nil, fmt.Errorf("login user: %w", err)
+	}
+
+	return response, nil
+}
+
+// identityUser returns the user the identity is linked to, registering a new user on the first login.
+//
+// Users are never matched by email: an existing user has to link the provider explicitly,
+// otherwise whoever controls the email at the provider could take the account over.
+// The only exception is a legacy identity stored before subjects were, which the user has linked already:
+// it's adopted on the first login with the verified email of the user.
+func (s *Service) identityUser(
+	ctx context.Context,
+	providerName string,
+	idToken string,
+	identity Identity,
+) (models.User, error) {
+	oAuthProvider, err := s.oAuthProviders.GetBySubject(ctx, models.Providers(providerName), identity.Subject)
+	switch {
+	case err == nil:
+		user, err := s.userService.GetByID(ctx, oAuthProvider.UserID)
 		if err != nil {
-			return "", "", 0, fmt.Errorf("create user and oauth provider: %w", err)
+			return models.User{}, fmt.Errorf("get user by id: %w", err)
 		}
+
+		return user, nil
+	case !errors.Is(err, models.ErrOAuthIdentityNotFound):
+		return models.User{}, fmt.Errorf("get oauth identity: %w", err)
 	}
 
-	accessToken, exp, err = s.tokenService.CreateAccessToken(ctx, &user)
+	if identity.Email == "" {
+		return models.User{}, errors.Join(models.ErrInvalidAuthToken, errors.New("email is empty"))
+	}
+
+	if !identity.EmailVerified {
+		return models.User{}, models.ErrOAuthEmailNotVerified
+	}
+
+	user, err := s.userService.GetUserByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		_, err := s.oAuthProviders.AdoptLegacy(ctx, user.ID, models.Providers(providerName), identity.Subject, idToken)
+		switch {
+		case err == nil:
+			return user, nil
+		case errors.Is(err, models.ErrOAuthIdentityNotFound):
+			return models.User{}, models.ErrUserAlreadyExists
+		default:
+			return models.User{}, fmt.Errorf("adopt legacy oauth identity: %w", err)
+		}
+	case !errors.Is(err, models.ErrUserNotFound):
+		return models.User{}, fmt.Errorf("get user by email: %w", err)
+	}
+
+	// The provider has verified the email already.
+	verifiedAt := s.now()
+	user = models.User{
+		Email:      identity.Email,
+		Name:       identity.Name,
+		Roles:      models.Roles{models.RoleUser},
+		VerifiedAt: &verifiedAt,
+	}
+
+	oAuthProvider = models.OAuthProviders{
+		Provider: models.Providers(providerName),
+		Subject:  identity.Subject,
+		Token:    idToken,
+	}
+
+	if err := s.userService.CreateUserAndOAuthProvider(ctx, &user, &oAuthProvider); err != nil {
+		return models.User{}, fmt.Errorf("create user and oauth provider: %w", err)
+	}
+
+	return user, nil
+}
+
+// LinkIdentity links the identity from the ID token issued by the provider to the user.
+// Linking an identity already linked to the user is a no-op.
+//
+// It returns [models.ErrOAuthProviderNotFound] when the provider is not configured,
+// [models.ErrInvalidAuthToken] when the ID token is invalid,
+// and [models.ErrOAuthIdentityLinked] when the identity is linked to another user.
+func (s *Service) LinkIdentity(
+	ctx context.Context,
+	userID uint,
+	providerName string,
+	idToken string,
+) (models.OAuthProviders, error) {
+	provider, err := s.provider(providerName)
 	if err != nil {
-		return "", "", 0, fmt.Errorf("create access token: %w", err)
+		return models.OAuthProviders{}, err
+	}
+
+	identity, err := provider.Verify(ctx, idToken)
+	if err != nil {
+		return models.OAuthProviders{}, fmt.Errorf("verify %s id token: %w", providerName, err)
+	}
+
+	oAuthProvider, err := s.oAuthProviders.GetBySubject(ctx, models.Providers(providerName), identity.Subject)
+	switch {
+	case err == nil && oAuthProvider.UserID == userID:
+		return oAuthProvider, nil
+	case err == nil:
+		return models.OAuthProviders{}, models.ErrOAuthIdentityLinked
+	case !errors.Is(err, models.ErrOAuthIdentityNotFound):
+		return models.OAuthProviders{}, fmt.Errorf("get oauth identity: %w", err)
+	}
+
+	oAuthProvider = models.OAuthProviders{
+		UserID:   userID,
+		Provider: models.Providers(providerName),
+		Subject:  identity.Subject,
+		Token:    idToken,
+	}
+
+	if err := s.oAuthProviders.Create(ctx, &oAuthProvider); err != nil {
+		return models.OAuthProviders{}, fmt.Errorf("create oauth identity: %w", err)
 	}
 
-	refreshToken, err = s.tokenService.CreateRefreshToken(ctx, &user)
+	return oAuthProvider, nil
+}
+
+func (s *Service) GetIdentities(ctx context.Context, userID uint) ([]models.OAuthProviders, error) {
+	oAuthProviders, err := s.oAuthProviders.GetByUser(ctx, userID)
 	if err != nil {
-		return "", "", 0, fmt.Errorf("create refresh token: %w", err)
+		return nil, fmt.Errorf("get oauth identities by user: %w", err)
+	}
+
+	return oAuthProviders, nil
+}
+
+// UnlinkIdentity unlinks the identity from the user.
+//
+// It returns [models.ErrOAuthIdentityNotFound] when the user has no such identity,
+// and [models.ErrLastLoginMethod] when the user has no password and the identity is the only one left.
+func (s *Service) UnlinkIdentity(ctx context.Context, userID, id uint) error {
+	if err := s.oAuthProviders.Delete(ctx, userID, id); err != nil {
+		return fmt.Errorf("delete oauth identity: %w", err)
 	}
 
-	return accessToken, refreshToken, exp, nil
+	return nil
 }
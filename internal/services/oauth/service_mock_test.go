@@ -0,0 +1,540 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=oauth_test -typed=true
+//
+
+// Package oauth_test is a generated GoMock package.
+package oauth_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	responses "github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserService is a mock of userService interface.
+type MockuserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockuserServiceMockRecorder is the mock recorder for MockuserService.
+type MockuserServiceMockRecorder struct {
+	mock *MockuserService
+}
+
+// NewMockuserService creates a new mock instance.
+func NewMockuserService(ctrl *gomock.Controller) *MockuserService {
+	mock := &MockuserService{ctrl: ctrl}
+	mock.recorder = &MockuserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserService) EXPECT() *MockuserServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateUserAndOAuthProvider mocks base method.
+func (m *MockuserService) CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oAuthProvider *models.OAuthProviders) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserAndOAuthProvider", ctx, user, oAuthProvider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserAndOAuthProvider indicates an expected call of CreateUserAndOAuthProvider.
+func (mr *MockuserServiceMockRecorder) CreateUserAndOAuthProvider(ctx, user, oAuthProvider any) *MockuserServiceCreateUserAndOAuthProviderCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserAndOAuthProvider", reflect.TypeOf((*MockuserService)(nil).CreateUserAndOAuthProvider), ctx, user, oAuthProvider)
+	return &MockuserServiceCreateUserAndOAuthProviderCall{Call: call}
+}
+
+// MockuserServiceCreateUserAndOAuthProviderCall wrap *gomock.Call
+type MockuserServiceCreateUserAndOAuthProviderCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceCreateUserAndOAuthProviderCall) Return(arg0 error) *MockuserServiceCreateUserAndOAuthProviderCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceCreateUserAndOAuthProviderCall) Do(f func(context.Context, *models.User, *models.OAuthProviders) error) *MockuserServiceCreateUserAndOAuthProviderCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceCreateUserAndOAuthProviderCall) DoAndReturn(f func(context.Context, *models.User, *models.OAuthProviders) error) *MockuserServiceCreateUserAndOAuthProviderCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByID mocks base method.
+func (m *MockuserService) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserServiceMockRecorder) GetByID(ctx, id any) *MockuserServiceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserService)(nil).GetByID), ctx, id)
+	return &MockuserServiceGetByIDCall{Call: call}
+}
+
+// MockuserServiceGetByIDCall wrap *gomock.Call
+type MockuserServiceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockuserService) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockuserServiceMockRecorder) GetUserByEmail(ctx, email any) *MockuserServiceGetUserByEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockuserService)(nil).GetUserByEmail), ctx, email)
+	return &MockuserServiceGetUserByEmailCall{Call: call}
+}
+
+// MockuserServiceGetUserByEmailCall wrap *gomock.Call
+type MockuserServiceGetUserByEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetUserByEmailCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetUserByEmailCall) Do(f func(context.Context, string) (models.User, error)) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetUserByEmailCall) DoAndReturn(f func(context.Context, string) (models.User, error)) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockoAuthProviderRepository is a mock of oAuthProviderRepository interface.
+type MockoAuthProviderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockoAuthProviderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockoAuthProviderRepositoryMockRecorder is the mock recorder for MockoAuthProviderRepository.
+type MockoAuthProviderRepositoryMockRecorder struct {
+	mock *MockoAuthProviderRepository
+}
+
+// NewMockoAuthProviderRepository creates a new mock instance.
+func NewMockoAuthProviderRepository(ctrl *gomock.Controller) *MockoAuthProviderRepository {
+	mock := &MockoAuthProviderRepository{ctrl: ctrl}
+	mock.recorder = &MockoAuthProviderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoAuthProviderRepository) EXPECT() *MockoAuthProviderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AdoptLegacy mocks base method.
+func (m *MockoAuthProviderRepository) AdoptLegacy(ctx context.Context, userID uint, provider models.Providers, subject, token string) (models.OAuthProviders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdoptLegacy", ctx, userID, provider, subject, token)
+	ret0, _ := ret[0].(models.OAuthProviders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdoptLegacy indicates an expected call of AdoptLegacy.
+func (mr *MockoAuthProviderRepositoryMockRecorder) AdoptLegacy(ctx, userID, provider, subject, token any) *MockoAuthProviderRepositoryAdoptLegacyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdoptLegacy", reflect.TypeOf((*MockoAuthProviderRepository)(nil).AdoptLegacy), ctx, userID, provider, subject, token)
+	return &MockoAuthProviderRepositoryAdoptLegacyCall{Call: call}
+}
+
+// MockoAuthProviderRepositoryAdoptLegacyCall wrap *gomock.Call
+type MockoAuthProviderRepositoryAdoptLegacyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoAuthProviderRepositoryAdoptLegacyCall) Return(arg0 models.OAuthProviders, arg1 error) *MockoAuthProviderRepositoryAdoptLegacyCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoAuthProviderRepositoryAdoptLegacyCall) Do(f func(context.Context, uint, models.Providers, string, string) (models.OAuthProviders, error)) *MockoAuthProviderRepositoryAdoptLegacyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoAuthProviderRepositoryAdoptLegacyCall) DoAndReturn(f func(context.Context, uint, models.Providers, string, string) (models.OAuthProviders, error)) *MockoAuthProviderRepositoryAdoptLegacyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockoAuthProviderRepository) Create(ctx context.Context, oAuthProvider *models.OAuthProviders) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, oAuthProvider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockoAuthProviderRepositoryMockRecorder) Create(ctx, oAuthProvider any) *MockoAuthProviderRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockoAuthProviderRepository)(nil).Create), ctx, oAuthProvider)
+	return &MockoAuthProviderRepositoryCreateCall{Call: call}
+}
+
+// MockoAuthProviderRepositoryCreateCall wrap *gomock.Call
+type MockoAuthProviderRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoAuthProviderRepositoryCreateCall) Return(arg0 error) *MockoAuthProviderRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoAuthProviderRepositoryCreateCall) Do(f func(context.Context, *models.OAuthProviders) error) *MockoAuthProviderRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoAuthProviderRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.OAuthProviders) error) *MockoAuthProviderRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Delete mocks base method.
+func (m *MockoAuthProviderRepository) Delete(ctx context.Context, userID, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockoAuthProviderRepositoryMockRecorder) Delete(ctx, userID, id any) *MockoAuthProviderRepositoryDeleteCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockoAuthProviderRepository)(nil).Delete), ctx, userID, id)
+	return &MockoAuthProviderRepositoryDeleteCall{Call: call}
+}
+
+// MockoAuthProviderRepositoryDeleteCall wrap *gomock.Call
+type MockoAuthProviderRepositoryDeleteCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoAuthProviderRepositoryDeleteCall) Return(arg0 error) *MockoAuthProviderRepositoryDeleteCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoAuthProviderRepositoryDeleteCall) Do(f func(context.Context, uint, uint) error) *MockoAuthProviderRepositoryDeleteCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoAuthProviderRepositoryDeleteCall) DoAndReturn(f func(context.Context, uint, uint) error) *MockoAuthProviderRepositoryDeleteCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetBySubject mocks base method.
+func (m *MockoAuthProviderRepository) GetBySubject(ctx context.Context, provider models.Providers, subject string) (models.OAuthProviders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySubject", ctx, provider, subject)
+	ret0, _ := ret[0].(models.OAuthProviders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySubject indicates an expected call of GetBySubject.
+func (mr *MockoAuthProviderRepositoryMockRecorder) GetBySubject(ctx, provider, subject any) *MockoAuthProviderRepositoryGetBySubjectCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySubject", reflect.TypeOf((*MockoAuthProviderRepository)(nil).GetBySubject), ctx, provider, subject)
+	return &MockoAuthProviderRepositoryGetBySubjectCall{Call: call}
+}
+
+// MockoAuthProviderRepositoryGetBySubjectCall wrap *gomock.Call
+type MockoAuthProviderRepositoryGetBySubjectCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoAuthProviderRepositoryGetBySubjectCall) Return(arg0 models.OAuthProviders, arg1 error) *MockoAuthProviderRepositoryGetBySubjectCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoAuthProviderRepositoryGetBySubjectCall) Do(f func(context.Context, models.Providers, string) (models.OAuthProviders, error)) *MockoAuthProviderRepositoryGetBySubjectCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoAuthProviderRepositoryGetBySubjectCall) DoAndReturn(f func(context.Context, models.Providers, string) (models.OAuthProviders, error)) *MockoAuthProviderRepositoryGetBySubjectCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByUser mocks base method.
+func (m *MockoAuthProviderRepository) GetByUser(ctx context.Context, userID uint) ([]models.OAuthProviders, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUser", ctx, userID)
+	ret0, _ := ret[0].([]models.OAuthProviders)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUser indicates an expected call of GetByUser.
+func (mr *MockoAuthProviderRepositoryMockRecorder) GetByUser(ctx, userID any) *MockoAuthProviderRepositoryGetByUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockoAuthProviderRepository)(nil).GetByUser), ctx, userID)
+	return &MockoAuthProviderRepositoryGetByUserCall{Call: call}
+}
+
+// MockoAuthProviderRepositoryGetByUserCall wrap *gomock.Call
+type MockoAuthProviderRepositoryGetByUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockoAuthProviderRepositoryGetByUserCall) Return(arg0 []models.OAuthProviders, arg1 error) *MockoAuthProviderRepositoryGetByUserCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockoAuthProviderRepositoryGetByUserCall) Do(f func(context.Context, uint) ([]models.OAuthProviders, error)) *MockoAuthProviderRepositoryGetByUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockoAuthProviderRepositoryGetByUserCall) DoAndReturn(f func(context.Context, uint) ([]models.OAuthProviders, error)) *MockoAuthProviderRepositoryGetByUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Mockauthenticator is a mock of authenticator interface.
+type Mockauthenticator struct {
+	ctrl     *gomock.Controller
+	recorder *MockauthenticatorMockRecorder
+	isgomock struct{}
+}
+
+// MockauthenticatorMockRecorder is the mock recorder for Mockauthenticator.
+type MockauthenticatorMockRecorder struct {
+	mock *Mockauthenticator
+}
+
+// NewMockauthenticator creates a new mock instance.
+func NewMockauthenticator(ctrl *gomock.Controller) *Mockauthenticator {
+	mock := &Mockauthenticator{ctrl: ctrl}
+	mock.recorder = &MockauthenticatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockauthenticator) EXPECT() *MockauthenticatorMockRecorder {
+	return m.recorder
+}
+
+// LoginUser mocks base method.
+func (m *Mockauthenticator) LoginUser(ctx context.Context, user *models.User, scopes models.Scopes, client models.Client, provider string) (*responses.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", ctx, user, scopes, client, provider)
+	ret0, _ := ret[0].(*responses.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockauthenticatorMockRecorder) LoginUser(ctx, user, scopes, client, provider any) *MockauthenticatorLoginUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*Mockauthenticator)(nil).LoginUser), ctx, user, scopes, client, provider)
+	return &MockauthenticatorLoginUserCall{Call: call}
+}
+
+// MockauthenticatorLoginUserCall wrap *gomock.Call
+type MockauthenticatorLoginUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockauthenticatorLoginUserCall) Return(arg0 *responses.LoginResponse, arg1 error) *MockauthenticatorLoginUserCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockauthenticatorLoginUserCall) Do(f func(context.Context, *models.User, models.Scopes, models.Client, string) (*responses.LoginResponse, error)) *MockauthenticatorLoginUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockauthenticatorLoginUserCall) DoAndReturn(f func(context.Context, *models.User, models.Scopes, models.Client, string) (*responses.LoginResponse, error)) *MockauthenticatorLoginUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockstateStore is a mock of stateStore interface.
+type MockstateStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockstateStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockstateStoreMockRecorder is the mock recorder for MockstateStore.
+type MockstateStoreMockRecorder struct {
+	mock *MockstateStore
+}
+
+// NewMockstateStore creates a new mock instance.
+func NewMockstateStore(ctrl *gomock.Controller) *MockstateStore {
+	mock := &MockstateStore{ctrl: ctrl}
+	mock.recorder = &MockstateStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstateStore) EXPECT() *MockstateStoreMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockstateStore) Save(ctx context.Context, state string, oAuthState models.OAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, state, oAuthState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockstateStoreMockRecorder) Save(ctx, state, oAuthState any) *MockstateStoreSaveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockstateStore)(nil).Save), ctx, state, oAuthState)
+	return &MockstateStoreSaveCall{Call: call}
+}
+
+// MockstateStoreSaveCall wrap *gomock.Call
+type MockstateStoreSaveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockstateStoreSaveCall) Return(arg0 error) *MockstateStoreSaveCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockstateStoreSaveCall) Do(f func(context.Context, string, models.OAuthState) error) *MockstateStoreSaveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockstateStoreSaveCall) DoAndReturn(f func(context.Context, string, models.OAuthState) error) *MockstateStoreSaveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Take mocks base method.
+func (m *MockstateStore) Take(ctx context.Context, state string) (models.OAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, state)
+	ret0, _ := ret[0].(models.OAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockstateStoreMockRecorder) Take(ctx, state any) *MockstateStoreTakeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockstateStore)(nil).Take), ctx, state)
+	return &MockstateStoreTakeCall{Call: call}
+}
+
+// MockstateStoreTakeCall wrap *gomock.Call
+type MockstateStoreTakeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockstateStoreTakeCall) Return(arg0 models.OAuthState, arg1 error) *MockstateStoreTakeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockstateStoreTakeCall) Do(f func(context.Context, string) (models.OAuthState, error)) *MockstateStoreTakeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockstateStoreTakeCall) DoAndReturn(f func(context.Context, string) (models.OAuthState, error)) *MockstateStoreTakeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
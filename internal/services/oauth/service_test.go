@@ -0,0 +1,462 @@
+package oauth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/config"
+	"github.com/nix-united/golang-echo-boilerplate/internal/memstore"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/responses"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/oauth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type serviceMocks struct {
+	oAuthProviderRepository *MockoAuthProviderRepository
+	authenticator           *Mockauthenticator
+	userService             *MockuserService
+}
+
+func newService(t *testing.T, providers []*oauth.Provider) (*oauth.Service, serviceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := serviceMocks{
+		oAuthProviderRepository: NewMockoAuthProviderRepository(ctrl),
+		authenticator:           NewMockauthenticator(ctrl),
+		userService:             NewMockuserService(ctrl),
+	}
+
+	service := oauth.NewService(
+		time.Now,
+		providers,
+		memstore.NewOAuthStates(time.Now, 100),
+		mocks.oAuthProviderRepository,
+		mocks.authenticator,
+		mocks.userService,
+	)
+
+	return service, mocks
+}
+
+func newFakeProvider(t *testing.T, issuer *fakeIssuer, name string) *oauth.Provider {
+	t.Helper()
+
+	provider, err := oauth.NewProvider(t.Context(), config.OIDCProvider{
+		Name:         name,
+		IssuerURL:    issuer.url,
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "http://localhost/oauth/" + name + "/callback",
+	})
+	require.NoError(t, err)
+
+	return provider
+}
+
+var client = models.Client{IPAddress: "192.0.2.1", UserAgent: "Mozilla/5.0"}
+
+func (m serviceMocks) expectLogin(user *models.User) {
+	m.authenticator.EXPECT().
+		LoginUser(gomock.Any(), user, models.AllScopes(), client, "fake").
+		Return(responses.NewLoginResponse("accessToken", "refreshToken", 100), nil)
+}
+
+func TestService_Authenticate(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	providers := []*oauth.Provider{newFakeProvider(t, issuer, "fake")}
+
+	idToken := issuer.idToken(t, nil)
+
+	existingUser := models.User{
+		Model: gorm.Model{ID: 100},
+		Email: "example@email.com",
+		Name:  "name",
+		Roles: models.Roles{models.RoleUser},
+	}
+
+	linkedIdentity := models.OAuthProviders{
+		Model:    gorm.Model{ID: 10},
+		UserID:   existingUser.ID,
+		Provider: "fake",
+		Subject:  "subject",
+	}
+
+	t.Run("It should log in user linked to the identity", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(linkedIdentity, nil)
+
+		mocks.userService.EXPECT().
+			GetByID(gomock.Any(), existingUser.ID).
+			Return(existingUser, nil)
+
+		mocks.expectLogin(&existingUser)
+
+		response, err := service.Authenticate(t.Context(), "fake", idToken, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, responses.NewLoginResponse("accessToken", "refreshToken", 100), response)
+	})
+
+	t.Run("It should log in linked user even if email is not verified", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(linkedIdentity, nil)
+
+		mocks.userService.EXPECT().
+			GetByID(gomock.Any(), existingUser.ID).
+			Return(existingUser, nil)
+
+		mocks.expectLogin(&existingUser)
+
+		_, err := service.Authenticate(t.Context(), "fake", issuer.idToken(t, jwt.MapClaims{"email_verified": false}), client)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should return MFA challenge for user with MFA enabled", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(linkedIdentity, nil)
+
+		mocks.userService.EXPECT().
+			GetByID(gomock.Any(), existingUser.ID).
+			Return(existingUser, nil)
+
+		mocks.authenticator.EXPECT().
+			LoginUser(gomock.Any(), &existingUser, models.AllScopes(), client, "fake").
+			Return(responses.NewMFAChallengeResponse("mfaToken"), nil)
+
+		response, err := service.Authenticate(t.Context(), "fake", idToken, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, "mfaToken", response.MFAToken)
+		assert.Empty(t, response.AccessToken)
+		assert.Empty(t, response.RefreshToken)
+	})
+
+	t.Run("It should register new user with the identity", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		mocks.userService.EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		mocks.userService.EXPECT().
+			CreateUserAndOAuthProvider(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *models.User, oAuthProvider *models.OAuthProviders) error {
+				assert.Equal(t, "example@email.com", user.Email)
+				assert.Equal(t, "name", user.Name)
+				assert.Equal(t, models.Roles{models.RoleUser}, user.Roles)
+				assert.NotNil(t, user.VerifiedAt)
+				assert.Equal(t, models.Providers("fake"), oAuthProvider.Provider)
+				assert.Equal(t, "subject", oAuthProvider.Subject)
+
+				user.ID = 100
+				return nil
+			})
+
+		mocks.authenticator.EXPECT().
+			LoginUser(gomock.Any(), gomock.Any(), models.AllScopes(), client, "fake").
+			Return(responses.NewLoginResponse("accessToken", "refreshToken", 100), nil)
+
+		_, err := service.Authenticate(t.Context(), "fake", idToken, client)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should not log in user with the same email without linking", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		mocks.userService.EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(existingUser, nil)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			AdoptLegacy(gomock.Any(), existingUser.ID, models.Providers("fake"), "subject", idToken).
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		_, err := service.Authenticate(t.Context(), "fake", idToken, client)
+		assert.ErrorIs(t, err, models.ErrUserAlreadyExists)
+	})
+
+	t.Run("It should log in user with legacy identity and adopt the subject", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		mocks.userService.EXPECT().
+			GetUserByEmail(gomock.Any(), "example@email.com").
+			Return(existingUser, nil)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			AdoptLegacy(gomock.Any(), existingUser.ID, models.Providers("fake"), "subject", idToken).
+			Return(linkedIdentity, nil)
+
+		mocks.expectLogin(&existingUser)
+
+		_, err := service.Authenticate(t.Context(), "fake", idToken, client)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should not adopt legacy identity with unverified email", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		_, err := service.Authenticate(t.Context(), "fake", issuer.idToken(t, jwt.MapClaims{"email_verified": false}), client)
+		assert.ErrorIs(t, err, models.ErrOAuthEmailNotVerified)
+	})
+
+	t.Run("It should not register user with unverified email", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		_, err := service.Authenticate(t.Context(), "fake", issuer.idToken(t, jwt.MapClaims{"email_verified": false}), client)
+		assert.ErrorIs(t, err, models.ErrOAuthEmailNotVerified)
+	})
+
+	t.Run("It should not register user without email", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		_, err := service.Authenticate(t.Context(), "fake", issuer.idToken(t, jwt.MapClaims{"email": ""}), client)
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should return an error for unknown provider", func(t *testing.T) {
+		service, _ := newService(t, providers)
+
+		_, err := service.Authenticate(t.Context(), "unknown", idToken, client)
+		assert.ErrorIs(t, err, models.ErrOAuthProviderNotFound)
+	})
+}
+
+func TestService_AuthorizationCodeFlow(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	providers := []*oauth.Provider{newFakeProvider(t, issuer, "fake"), newFakeProvider(t, issuer, "another")}
+
+	existingUser := models.User{
+		Model: gorm.Model{ID: 100},
+		Email: "example@email.com",
+		Name:  "name",
+		Roles: models.Roles{models.RoleUser},
+	}
+
+	expectLinkedIdentity := func(mocks serviceMocks) {
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{UserID: existingUser.ID, Provider: "fake", Subject: "subject"}, nil)
+
+		mocks.userService.EXPECT().
+			GetByID(gomock.Any(), existingUser.ID).
+			Return(existingUser, nil)
+	}
+
+	t.Run("It should log in user with authorization code", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		expectLinkedIdentity(mocks)
+		mocks.expectLogin(&existingUser)
+
+		authURL, state, err := service.StartAuthorization(t.Context(), "fake")
+		require.NoError(t, err)
+
+		code, redirectState := issuer.authorize(t, authURL)
+		assert.Equal(t, state, redirectState)
+
+		response, err := service.CompleteAuthorization(t.Context(), "fake", state, code, client)
+		require.NoError(t, err)
+
+		assert.Equal(t, responses.NewLoginResponse("accessToken", "refreshToken", 100), response)
+	})
+
+	t.Run("It should reject state used twice", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		expectLinkedIdentity(mocks)
+		mocks.expectLogin(&existingUser)
+
+		authURL, state, err := service.StartAuthorization(t.Context(), "fake")
+		require.NoError(t, err)
+
+		code, _ := issuer.authorize(t, authURL)
+
+		_, err = service.CompleteAuthorization(t.Context(), "fake", state, code, client)
+		require.NoError(t, err)
+
+		_, err = service.CompleteAuthorization(t.Context(), "fake", state, code, client)
+		assert.ErrorIs(t, err, models.ErrInvalidOAuthState)
+	})
+
+	testCases := map[string]struct {
+		completeProvider string
+		state            func(state string) string
+		code             func(code string) string
+		wantErr          error
+	}{
+		"It should reject unknown state": {
+			completeProvider: "fake",
+			state:            func(string) string { return "unknown" },
+			code:             func(code string) string { return code },
+			wantErr:          models.ErrInvalidOAuthState,
+		},
+		"It should reject state issued for another provider": {
+			completeProvider: "another",
+			state:            func(state string) string { return state },
+			code:             func(code string) string { return code },
+			wantErr:          models.ErrInvalidOAuthState,
+		},
+		"It should reject unknown authorization code": {
+			completeProvider: "fake",
+			state:            func(state string) string { return state },
+			code:             func(string) string { return "unknown" },
+			wantErr:          models.ErrInvalidAuthToken,
+		},
+		"It should return an error for unknown provider": {
+			completeProvider: "unknown",
+			state:            func(state string) string { return state },
+			code:             func(code string) string { return code },
+			wantErr:          models.ErrOAuthProviderNotFound,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, _ := newService(t, providers)
+
+			authURL, state, err := service.StartAuthorization(t.Context(), "fake")
+			require.NoError(t, err)
+
+			code, _ := issuer.authorize(t, authURL)
+
+			_, err = service.CompleteAuthorization(
+				t.Context(),
+				testCase.completeProvider,
+				testCase.state(state),
+				testCase.code(code),
+				client,
+			)
+			assert.ErrorIs(t, err, testCase.wantErr)
+		})
+	}
+
+	t.Run("It should return an error when starting with unknown provider", func(t *testing.T) {
+		service, _ := newService(t, providers)
+
+		_, _, err := service.StartAuthorization(t.Context(), "unknown")
+		assert.ErrorIs(t, err, models.ErrOAuthProviderNotFound)
+	})
+}
+
+func TestService_LinkIdentity(t *testing.T) {
+	issuer := newFakeIssuer(t)
+	providers := []*oauth.Provider{newFakeProvider(t, issuer, "fake")}
+
+	idToken := issuer.idToken(t, jwt.MapClaims{"email": "another@email.com", "email_verified": false})
+
+	t.Run("It should link identity to the user", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{}, models.ErrOAuthIdentityNotFound)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			Create(gomock.Any(), &models.OAuthProviders{
+				UserID:   100,
+				Provider: "fake",
+				Subject:  "subject",
+				Token:    idToken,
+			}).
+			Return(nil)
+
+		oAuthProvider, err := service.LinkIdentity(t.Context(), 100, "fake", idToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, "subject", oAuthProvider.Subject)
+	})
+
+	t.Run("It should not link identity twice", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		linkedIdentity := models.OAuthProviders{Model: gorm.Model{ID: 10}, UserID: 100, Provider: "fake", Subject: "subject"}
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(linkedIdentity, nil)
+
+		oAuthProvider, err := service.LinkIdentity(t.Context(), 100, "fake", idToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, linkedIdentity, oAuthProvider)
+	})
+
+	t.Run("It should return an error for identity linked to another user", func(t *testing.T) {
+		service, mocks := newService(t, providers)
+
+		mocks.oAuthProviderRepository.EXPECT().
+			GetBySubject(gomock.Any(), models.Providers("fake"), "subject").
+			Return(models.OAuthProviders{UserID: 200, Provider: "fake", Subject: "subject"}, nil)
+
+		_, err := service.LinkIdentity(t.Context(), 100, "fake", idToken)
+		assert.ErrorIs(t, err, models.ErrOAuthIdentityLinked)
+	})
+
+	t.Run("It should return an error for invalid ID token", func(t *testing.T) {
+		service, _ := newService(t, providers)
+
+		_, err := service.LinkIdentity(t.Context(), 100, "fake", issuer.idToken(t, jwt.MapClaims{"aud": "another-client-id"}))
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+}
+
+func TestService_UnlinkIdentity(t *testing.T) {
+	t.Run("It should unlink identity", func(t *testing.T) {
+		service, mocks := newService(t, nil)
+
+		mocks.oAuthProviderRepository.EXPECT().Delete(gomock.Any(), uint(100), uint(10)).Return(nil)
+
+		err := service.UnlinkIdentity(t.Context(), 100, 10)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should return an error for the last login method", func(t *testing.T) {
+		service, mocks := newService(t, nil)
+
+		mocks.oAuthProviderRepository.EXPECT().Delete(gomock.Any(), uint(100), uint(10)).Return(models.ErrLastLoginMethod)
+
+		err := service.UnlinkIdentity(t.Context(), 100, 10)
+		assert.ErrorIs(t, err, models.ErrLastLoginMethod)
+	})
+}
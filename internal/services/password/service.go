@@ -0,0 +1,221 @@
+package password
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+const (
+	// At most resetLimit reset emails are sent to a user within resetLimitWindow,
+	// so that the forgot password endpoint can't be used to flood an inbox.
+	resetLimit       = 3
+	resetLimitWindow = time.Hour
+)
+
+type passwordResetRepository interface {
+	Create(ctx context.Context, passwordReset *models.PasswordReset) error
+	CountCreatedSince(ctx context.Context, userID uint, since time.Time) (int64, error)
+	Get(ctx context.Context, tokenHash string) (models.PasswordReset, error)
+	Consume(ctx context.Context, tokenHash string) (models.PasswordReset, error)
+}
+
+type userService interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	UpdatePassword(ctx context.Context, id uint, password string) error
+	CheckPasswordPolicy(user *models.User, password string) error
+	ComparePassword(ctx context.Context, user *models.User, password string) error
+}
+
+type sessionService interface {
+	RevokeAll(ctx context.Context, userID uint) error
+	RevokeOthers(ctx context.Context, userID uint, sessionID string) error
+}
+
+type mailSender interface {
+	Send(ctx context.Context, message mail.Message) error
+}
+
+// Service changes user passwords. Users who forgot their password can set a new one
+// with a single-use token sent to their email.
+type Service struct {
+	now                     func() time.Time
+	passwordResetRepository passwordResetRepository
+	userService             userService
+	sessionService          sessionService
+	mailSender              mailSender
+	mailFrom                string
+	linkBaseURL             string
+	resetDuration           time.Duration
+}
+
+func NewService(
+	now func() time.Time,
+	passwordResetRepository passwordResetRepository,
+	userService userService,
+	sessionService sessionService,
+	mailSender mailSender,
+	mailFrom string,
+	linkBaseURL string,
+	resetDuration time.Duration,
+) *Service {
+	return &Service{
+		now:                     now,
+		passwordResetRepository: passwordResetRepository,
+		userService:             userService,
+		sessionService:          sessionService,
+		mailSender:              mailSender,
+		mailFrom:                mailFrom,
+		linkBaseURL:             linkBaseURL,
+		resetDuration:           resetDuration,
+	}
+}
+
+// ForgotPassword emails a password reset link to the user with the email.
+// It succeeds for unknown emails and throttled users as well, so that it can't be used to check who is registered.
+func (s *Service) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get user by email: %w", err)
+	}
+
+	now := s.now()
+
+	sent, err := s.passwordResetRepository.CountCreatedSince(ctx, user.ID, now.Add(-resetLimitWindow))
+	if err != nil {
+		return fmt.Errorf("count recent password resets: %w", err)
+	}
+
+	if sent >= resetLimit {
+		return nil
+	}
+
+	resetToken := rand.Text()
+
+	passwordReset := models.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: hashToken(resetToken),
+		ExpiresAt: now.Add(s.resetDuration),
+	}
+
+	if err := s.passwordResetRepository.Create(ctx, &passwordReset); err != nil {
+		return fmt.Errorf("create password reset in repository: %w", err)
+	}
+
+	link, err := url.JoinPath(s.linkBaseURL, "password", "reset")
+	if err != nil {
+		return fmt.Errorf("join password reset link path: %w", err)
+	}
+
+	message := mail.Message{
+		From:    s.mailFrom,
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nSet a new password by following the link, it is valid for %s:\n%s?token=%s\n\n"+
+				"If you didn't ask to reset your password, ignore this email.\n",
+			user.Name,
+			s.resetDuration,
+			link,
+			url.QueryEscape(resetToken),
+		),
+	}
+
+	if err := s.mailSender.Send(ctx, message); err != nil {
+		return fmt.Errorf("send password reset email: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets the new password of the user the token was issued for and revokes all sessions of the user,
+// along with the access tokens issued for them.
+// It returns [models.ErrInvalidResetToken] when the token is unknown, already used or expired,
+// and [*models.PasswordPolicyError] when the new password breaks the password policy, the token stays valid then.
+func (s *Service) ResetPassword(ctx context.Context, resetToken, newPassword string) error {
+	passwordReset, err := s.passwordResetRepository.Get(ctx, hashToken(resetToken))
+	if err != nil {
+		return fmt.Errorf("get password reset: %w", err)
+	}
+
+	user, err := s.userService.GetByID(ctx, passwordReset.UserID)
+	if err != nil {
+		return fmt.Errorf("get user by id: %w", err)
+	}
+
+	if err := s.userService.CheckPasswordPolicy(&user, newPassword); err != nil {
+		return fmt.Errorf("check new password: %w", err)
+	}
+
+	// The token is consumed only now, so that it can be used again with a better password.
+	passwordReset, err = s.passwordResetRepository.Consume(ctx, hashToken(resetToken))
+	if err != nil {
+		return fmt.Errorf("consume password reset: %w", err)
+	}
+
+	if err := s.userService.UpdatePassword(ctx, passwordReset.UserID, newPassword); err != nil {
+		return fmt.Errorf("update password: %w", err)
+	}
+
+	if err := s.sessionService.RevokeAll(ctx, passwordReset.UserID); err != nil {
+		return fmt.Errorf("revoke all sessions: %w", err)
+	}
+
+	return nil
+}
+
+// ChangePassword sets the new password of the user after checking the current one,
+// and revokes all sessions of the user except the one the request is made from.
+// It returns [models.ErrInvalidPassword] when the current password doesn't match,
+// and [*models.PasswordPolicyError] when the new password breaks the password policy.
+func (s *Service) ChangePassword(ctx context.Context, userID uint, sessionID, currentPassword, newPassword string) error {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by id: %w", err)
+	}
+
+	if err := s.userService.ComparePassword(ctx, &user, currentPassword); err != nil {
+		return fmt.Errorf("compare current password: %w", err)
+	}
+
+	if err := s.userService.CheckPasswordPolicy(&user, newPassword); err != nil {
+		return fmt.Errorf("check new password: %w", err)
+	}
+
+	if err := s.userService.UpdatePassword(ctx, user.ID, newPassword); err != nil {
+		return fmt.Errorf("update password: %w", err)
+	}
+
+	// Requests authenticated with an API key don't belong to a session, so every session is revoked.
+	if sessionID == "" {
+		if err := s.sessionService.RevokeAll(ctx, user.ID); err != nil {
+			return fmt.Errorf("revoke all sessions: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := s.sessionService.RevokeOthers(ctx, user.ID, sessionID); err != nil {
+		return fmt.Errorf("revoke other sessions: %w", err)
+	}
+
+	return nil
+}
+
+func hashToken(resetToken string) string {
+	hash := sha256.Sum256([]byte(resetToken))
+	return hex.EncodeToString(hash[:])
+}
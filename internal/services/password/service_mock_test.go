@@ -0,0 +1,577 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=password_test -typed=true
+//
+
+// Package password_test is a generated GoMock package.
+package password_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	mail "github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpasswordResetRepository is a mock of passwordResetRepository interface.
+type MockpasswordResetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordResetRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockpasswordResetRepositoryMockRecorder is the mock recorder for MockpasswordResetRepository.
+type MockpasswordResetRepositoryMockRecorder struct {
+	mock *MockpasswordResetRepository
+}
+
+// NewMockpasswordResetRepository creates a new mock instance.
+func NewMockpasswordResetRepository(ctrl *gomock.Controller) *MockpasswordResetRepository {
+	mock := &MockpasswordResetRepository{ctrl: ctrl}
+	mock.recorder = &MockpasswordResetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordResetRepository) EXPECT() *MockpasswordResetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockpasswordResetRepository) Consume(ctx context.Context, tokenHash string) (models.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, tokenHash)
+	ret0, _ := ret[0].(models.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockpasswordResetRepositoryMockRecorder) Consume(ctx, tokenHash any) *MockpasswordResetRepositoryConsumeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockpasswordResetRepository)(nil).Consume), ctx, tokenHash)
+	return &MockpasswordResetRepositoryConsumeCall{Call: call}
+}
+
+// MockpasswordResetRepositoryConsumeCall wrap *gomock.Call
+type MockpasswordResetRepositoryConsumeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordResetRepositoryConsumeCall) Return(arg0 models.PasswordReset, arg1 error) *MockpasswordResetRepositoryConsumeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordResetRepositoryConsumeCall) Do(f func(context.Context, string) (models.PasswordReset, error)) *MockpasswordResetRepositoryConsumeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordResetRepositoryConsumeCall) DoAndReturn(f func(context.Context, string) (models.PasswordReset, error)) *MockpasswordResetRepositoryConsumeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CountCreatedSince mocks base method.
+func (m *MockpasswordResetRepository) CountCreatedSince(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreatedSince", ctx, userID, since)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreatedSince indicates an expected call of CountCreatedSince.
+func (mr *MockpasswordResetRepositoryMockRecorder) CountCreatedSince(ctx, userID, since any) *MockpasswordResetRepositoryCountCreatedSinceCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreatedSince", reflect.TypeOf((*MockpasswordResetRepository)(nil).CountCreatedSince), ctx, userID, since)
+	return &MockpasswordResetRepositoryCountCreatedSinceCall{Call: call}
+}
+
+// MockpasswordResetRepositoryCountCreatedSinceCall wrap *gomock.Call
+type MockpasswordResetRepositoryCountCreatedSinceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordResetRepositoryCountCreatedSinceCall) Return(arg0 int64, arg1 error) *MockpasswordResetRepositoryCountCreatedSinceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordResetRepositoryCountCreatedSinceCall) Do(f func(context.Context, uint, time.Time) (int64, error)) *MockpasswordResetRepositoryCountCreatedSinceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordResetRepositoryCountCreatedSinceCall) DoAndReturn(f func(context.Context, uint, time.Time) (int64, error)) *MockpasswordResetRepositoryCountCreatedSinceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockpasswordResetRepository) Create(ctx context.Context, passwordReset *models.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, passwordReset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockpasswordResetRepositoryMockRecorder) Create(ctx, passwordReset any) *MockpasswordResetRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockpasswordResetRepository)(nil).Create), ctx, passwordReset)
+	return &MockpasswordResetRepositoryCreateCall{Call: call}
+}
+
+// MockpasswordResetRepositoryCreateCall wrap *gomock.Call
+type MockpasswordResetRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordResetRepositoryCreateCall) Return(arg0 error) *MockpasswordResetRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordResetRepositoryCreateCall) Do(f func(context.Context, *models.PasswordReset) error) *MockpasswordResetRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordResetRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.PasswordReset) error) *MockpasswordResetRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Get mocks base method.
+func (m *MockpasswordResetRepository) Get(ctx context.Context, tokenHash string) (models.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tokenHash)
+	ret0, _ := ret[0].(models.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockpasswordResetRepositoryMockRecorder) Get(ctx, tokenHash any) *MockpasswordResetRepositoryGetCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockpasswordResetRepository)(nil).Get), ctx, tokenHash)
+	return &MockpasswordResetRepositoryGetCall{Call: call}
+}
+
+// MockpasswordResetRepositoryGetCall wrap *gomock.Call
+type MockpasswordResetRepositoryGetCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordResetRepositoryGetCall) Return(arg0 models.PasswordReset, arg1 error) *MockpasswordResetRepositoryGetCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordResetRepositoryGetCall) Do(f func(context.Context, string) (models.PasswordReset, error)) *MockpasswordResetRepositoryGetCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordResetRepositoryGetCall) DoAndReturn(f func(context.Context, string) (models.PasswordReset, error)) *MockpasswordResetRepositoryGetCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockuserService is a mock of userService interface.
+type MockuserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockuserServiceMockRecorder is the mock recorder for MockuserService.
+type MockuserServiceMockRecorder struct {
+	mock *MockuserService
+}
+
+// NewMockuserService creates a new mock instance.
+func NewMockuserService(ctrl *gomock.Controller) *MockuserService {
+	mock := &MockuserService{ctrl: ctrl}
+	mock.recorder = &MockuserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserService) EXPECT() *MockuserServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckPasswordPolicy mocks base method.
+func (m *MockuserService) CheckPasswordPolicy(user *models.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPasswordPolicy", user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPasswordPolicy indicates an expected call of CheckPasswordPolicy.
+func (mr *MockuserServiceMockRecorder) CheckPasswordPolicy(user, password any) *MockuserServiceCheckPasswordPolicyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPasswordPolicy", reflect.TypeOf((*MockuserService)(nil).CheckPasswordPolicy), user, password)
+	return &MockuserServiceCheckPasswordPolicyCall{Call: call}
+}
+
+// MockuserServiceCheckPasswordPolicyCall wrap *gomock.Call
+type MockuserServiceCheckPasswordPolicyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceCheckPasswordPolicyCall) Return(arg0 error) *MockuserServiceCheckPasswordPolicyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceCheckPasswordPolicyCall) Do(f func(*models.User, string) error) *MockuserServiceCheckPasswordPolicyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceCheckPasswordPolicyCall) DoAndReturn(f func(*models.User, string) error) *MockuserServiceCheckPasswordPolicyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ComparePassword mocks base method.
+func (m *MockuserService) ComparePassword(ctx context.Context, user *models.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComparePassword", ctx, user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ComparePassword indicates an expected call of ComparePassword.
+func (mr *MockuserServiceMockRecorder) ComparePassword(ctx, user, password any) *MockuserServiceComparePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComparePassword", reflect.TypeOf((*MockuserService)(nil).ComparePassword), ctx, user, password)
+	return &MockuserServiceComparePasswordCall{Call: call}
+}
+
+// MockuserServiceComparePasswordCall wrap *gomock.Call
+type MockuserServiceComparePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceComparePasswordCall) Return(arg0 error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceComparePasswordCall) Do(f func(context.Context, *models.User, string) error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceComparePasswordCall) DoAndReturn(f func(context.Context, *models.User, string) error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByID mocks base method.
+func (m *MockuserService) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserServiceMockRecorder) GetByID(ctx, id any) *MockuserServiceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserService)(nil).GetByID), ctx, id)
+	return &MockuserServiceGetByIDCall{Call: call}
+}
+
+// MockuserServiceGetByIDCall wrap *gomock.Call
+type MockuserServiceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockuserService) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockuserServiceMockRecorder) GetUserByEmail(ctx, email any) *MockuserServiceGetUserByEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockuserService)(nil).GetUserByEmail), ctx, email)
+	return &MockuserServiceGetUserByEmailCall{Call: call}
+}
+
+// MockuserServiceGetUserByEmailCall wrap *gomock.Call
+type MockuserServiceGetUserByEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetUserByEmailCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetUserByEmailCall) Do(f func(context.Context, string) (models.User, error)) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetUserByEmailCall) DoAndReturn(f func(context.Context, string) (models.User, error)) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdatePassword mocks base method.
+func (m *MockuserService) UpdatePassword(ctx context.Context, id uint, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockuserServiceMockRecorder) UpdatePassword(ctx, id, password any) *MockuserServiceUpdatePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockuserService)(nil).UpdatePassword), ctx, id, password)
+	return &MockuserServiceUpdatePasswordCall{Call: call}
+}
+
+// MockuserServiceUpdatePasswordCall wrap *gomock.Call
+type MockuserServiceUpdatePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceUpdatePasswordCall) Return(arg0 error) *MockuserServiceUpdatePasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceUpdatePasswordCall) Do(f func(context.Context, uint, string) error) *MockuserServiceUpdatePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceUpdatePasswordCall) DoAndReturn(f func(context.Context, uint, string) error) *MockuserServiceUpdatePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocksessionService is a mock of sessionService interface.
+type MocksessionService struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionServiceMockRecorder is the mock recorder for MocksessionService.
+type MocksessionServiceMockRecorder struct {
+	mock *MocksessionService
+}
+
+// NewMocksessionService creates a new mock instance.
+func NewMocksessionService(ctrl *gomock.Controller) *MocksessionService {
+	mock := &MocksessionService{ctrl: ctrl}
+	mock.recorder = &MocksessionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionService) EXPECT() *MocksessionServiceMockRecorder {
+	return m.recorder
+}
+
+// RevokeAll mocks base method.
+func (m *MocksessionService) RevokeAll(ctx context.Context, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MocksessionServiceMockRecorder) RevokeAll(ctx, userID any) *MocksessionServiceRevokeAllCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MocksessionService)(nil).RevokeAll), ctx, userID)
+	return &MocksessionServiceRevokeAllCall{Call: call}
+}
+
+// MocksessionServiceRevokeAllCall wrap *gomock.Call
+type MocksessionServiceRevokeAllCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRevokeAllCall) Return(arg0 error) *MocksessionServiceRevokeAllCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRevokeAllCall) Do(f func(context.Context, uint) error) *MocksessionServiceRevokeAllCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceRevokeAllCall) DoAndReturn(f func(context.Context, uint) error) *MocksessionServiceRevokeAllCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeOthers mocks base method.
+func (m *MocksessionService) RevokeOthers(ctx context.Context, userID uint, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOthers", ctx, userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOthers indicates an expected call of RevokeOthers.
+func (mr *MocksessionServiceMockRecorder) RevokeOthers(ctx, userID, sessionID any) *MocksessionServiceRevokeOthersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOthers", reflect.TypeOf((*MocksessionService)(nil).RevokeOthers), ctx, userID, sessionID)
+	return &MocksessionServiceRevokeOthersCall{Call: call}
+}
+
+// MocksessionServiceRevokeOthersCall wrap *gomock.Call
+type MocksessionServiceRevokeOthersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionServiceRevokeOthersCall) Return(arg0 error) *MocksessionServiceRevokeOthersCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionServiceRevokeOthersCall) Do(f func(context.Context, uint, string) error) *MocksessionServiceRevokeOthersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionServiceRevokeOthersCall) DoAndReturn(f func(context.Context, uint, string) error) *MocksessionServiceRevokeOthersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockmailSender is a mock of mailSender interface.
+type MockmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockmailSenderMockRecorder is the mock recorder for MockmailSender.
+type MockmailSenderMockRecorder struct {
+	mock *MockmailSender
+}
+
+// NewMockmailSender creates a new mock instance.
+func NewMockmailSender(ctrl *gomock.Controller) *MockmailSender {
+	mock := &MockmailSender{ctrl: ctrl}
+	mock.recorder = &MockmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmailSender) EXPECT() *MockmailSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockmailSender) Send(ctx context.Context, message mail.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailSenderMockRecorder) Send(ctx, message any) *MockmailSenderSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockmailSender)(nil).Send), ctx, message)
+	return &MockmailSenderSendCall{Call: call}
+}
+
+// MockmailSenderSendCall wrap *gomock.Call
+type MockmailSenderSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmailSenderSendCall) Return(arg0 error) *MockmailSenderSendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmailSenderSendCall) Do(f func(context.Context, mail.Message) error) *MockmailSenderSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmailSenderSendCall) DoAndReturn(f func(context.Context, mail.Message) error) *MockmailSenderSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
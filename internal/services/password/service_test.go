@@ -0,0 +1,262 @@
+package password_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/password"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var currentTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type serviceMocks struct {
+	passwordResetRepository *MockpasswordResetRepository
+	userService             *MockuserService
+	sessionService          *MocksessionService
+	mailSender              *mail.MemorySender
+}
+
+func newService(t *testing.T) (*password.Service, serviceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	mocks := serviceMocks{
+		passwordResetRepository: NewMockpasswordResetRepository(ctrl),
+		userService:             NewMockuserService(ctrl),
+		sessionService:          NewMocksessionService(ctrl),
+		mailSender:              mail.NewMemorySender(),
+	}
+
+	service := password.NewService(
+		func() time.Time { return currentTime },
+		mocks.passwordResetRepository,
+		mocks.userService,
+		mocks.sessionService,
+		mocks.mailSender,
+		"no-reply@example.com",
+		"https://app.example.com",
+		time.Hour,
+	)
+
+	return service, mocks
+}
+
+func hash(resetToken string) string {
+	hash := sha256.Sum256([]byte(resetToken))
+	return hex.EncodeToString(hash[:])
+}
+
+func TestService_ForgotPassword(t *testing.T) {
+	user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", Name: "name"}
+
+	t.Run("It should email password reset link with a token stored as hash", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().GetUserByEmail(gomock.Any(), "user@example.com").Return(user, nil)
+		mocks.passwordResetRepository.EXPECT().
+			CountCreatedSince(gomock.Any(), uint(100), currentTime.Add(-time.Hour)).
+			Return(int64(2), nil)
+
+		var storedPasswordReset *models.PasswordReset
+		mocks.passwordResetRepository.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, passwordReset *models.PasswordReset) error {
+				storedPasswordReset = passwordReset
+				return nil
+			})
+
+		err := service.ForgotPassword(t.Context(), "user@example.com")
+		require.NoError(t, err)
+
+		messages := mocks.mailSender.Messages()
+		require.Len(t, messages, 1)
+		assert.Equal(t, "user@example.com", messages[0].To)
+
+		const linkPrefix = "https://app.example.com/password/reset?token="
+		start := strings.Index(messages[0].Body, linkPrefix)
+		require.NotEqual(t, -1, start)
+
+		rawToken, _, _ := strings.Cut(messages[0].Body[start+len(linkPrefix):], "\n")
+		resetToken, err := url.QueryUnescape(rawToken)
+		require.NoError(t, err)
+
+		require.NotNil(t, storedPasswordReset)
+		assert.Equal(t, uint(100), storedPasswordReset.UserID)
+		assert.Equal(t, hash(resetToken), storedPasswordReset.TokenHash)
+		assert.Equal(t, currentTime.Add(time.Hour), storedPasswordReset.ExpiresAt)
+	})
+
+	t.Run("It should not email unknown user", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().
+			GetUserByEmail(gomock.Any(), "user@example.com").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		err := service.ForgotPassword(t.Context(), "user@example.com")
+		require.NoError(t, err)
+
+		assert.Empty(t, mocks.mailSender.Messages())
+	})
+
+	t.Run("It should not email user who requested too many resets", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().GetUserByEmail(gomock.Any(), "user@example.com").Return(user, nil)
+		mocks.passwordResetRepository.EXPECT().
+			CountCreatedSince(gomock.Any(), uint(100), currentTime.Add(-time.Hour)).
+			Return(int64(3), nil)
+
+		err := service.ForgotPassword(t.Context(), "user@example.com")
+		require.NoError(t, err)
+
+		assert.Empty(t, mocks.mailSender.Messages())
+	})
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	errTest := errors.New("test error")
+	user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", Name: "name"}
+	policyErr := &models.PasswordPolicyError{Violations: []string{"must contain a digit"}}
+
+	t.Run("It should return ErrInvalidResetToken error when token is invalid", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.passwordResetRepository.EXPECT().
+			Get(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{}, models.ErrInvalidResetToken)
+
+		err := service.ResetPassword(t.Context(), "reset-token", "new-password")
+		assert.ErrorIs(t, err, models.ErrInvalidResetToken)
+	})
+
+	t.Run("It should not consume token when new password breaks the policy", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.passwordResetRepository.EXPECT().
+			Get(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{UserID: 100}, nil)
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().CheckPasswordPolicy(&user, "new-password").Return(policyErr)
+
+		err := service.ResetPassword(t.Context(), "reset-token", "new-password")
+		assert.ErrorIs(t, err, models.ErrWeakPassword)
+	})
+
+	t.Run("It should return ErrInvalidResetToken error when token is consumed concurrently", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.passwordResetRepository.EXPECT().
+			Get(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{UserID: 100}, nil)
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().CheckPasswordPolicy(&user, "new-password").Return(nil)
+		mocks.passwordResetRepository.EXPECT().
+			Consume(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{}, models.ErrInvalidResetToken)
+
+		err := service.ResetPassword(t.Context(), "reset-token", "new-password")
+		assert.ErrorIs(t, err, models.ErrInvalidResetToken)
+	})
+
+	t.Run("It should return an error when password update fails", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.passwordResetRepository.EXPECT().
+			Get(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{UserID: 100}, nil)
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().CheckPasswordPolicy(&user, "new-password").Return(nil)
+		mocks.passwordResetRepository.EXPECT().
+			Consume(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{UserID: 100}, nil)
+		mocks.userService.EXPECT().UpdatePassword(gomock.Any(), uint(100), "new-password").Return(errTest)
+
+		err := service.ResetPassword(t.Context(), "reset-token", "new-password")
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("It should update password and revoke all sessions", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.passwordResetRepository.EXPECT().
+			Get(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{UserID: 100}, nil)
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().CheckPasswordPolicy(&user, "new-password").Return(nil)
+		mocks.passwordResetRepository.EXPECT().
+			Consume(gomock.Any(), hash("reset-token")).
+			Return(models.PasswordReset{UserID: 100}, nil)
+		mocks.userService.EXPECT().UpdatePassword(gomock.Any(), uint(100), "new-password").Return(nil)
+		mocks.sessionService.EXPECT().RevokeAll(gomock.Any(), uint(100)).Return(nil)
+
+		err := service.ResetPassword(t.Context(), "reset-token", "new-password")
+		require.NoError(t, err)
+	})
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	user := models.User{Model: gorm.Model{ID: 100}, Password: "password-hash"}
+
+	t.Run("It should return ErrInvalidPassword error when current password is invalid", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().ComparePassword(gomock.Any(), &user, "current-password").Return(models.ErrInvalidPassword)
+
+		err := service.ChangePassword(t.Context(), 100, "session-id", "current-password", "new-password")
+		assert.ErrorIs(t, err, models.ErrInvalidPassword)
+	})
+
+	t.Run("It should return PasswordPolicyError when new password breaks the policy", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().ComparePassword(gomock.Any(), &user, "current-password").Return(nil)
+		mocks.userService.EXPECT().
+			CheckPasswordPolicy(&user, "new-password").
+			Return(&models.PasswordPolicyError{Violations: []string{"must contain a digit"}})
+
+		err := service.ChangePassword(t.Context(), 100, "session-id", "current-password", "new-password")
+		assert.ErrorIs(t, err, models.ErrWeakPassword)
+	})
+
+	t.Run("It should update password and revoke other sessions", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().ComparePassword(gomock.Any(), &user, "current-password").Return(nil)
+		mocks.userService.EXPECT().CheckPasswordPolicy(&user, "new-password").Return(nil)
+		mocks.userService.EXPECT().UpdatePassword(gomock.Any(), uint(100), "new-password").Return(nil)
+		mocks.sessionService.EXPECT().RevokeOthers(gomock.Any(), uint(100), "session-id").Return(nil)
+
+		err := service.ChangePassword(t.Context(), 100, "session-id", "current-password", "new-password")
+		require.NoError(t, err)
+	})
+
+	t.Run("It should revoke all sessions when request doesn't belong to a session", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		mocks.userService.EXPECT().ComparePassword(gomock.Any(), &user, "current-password").Return(nil)
+		mocks.userService.EXPECT().CheckPasswordPolicy(&user, "new-password").Return(nil)
+		mocks.userService.EXPECT().UpdatePassword(gomock.Any(), uint(100), "new-password").Return(nil)
+		mocks.sessionService.EXPECT().RevokeAll(gomock.Any(), uint(100)).Return(nil)
+
+		err := service.ChangePassword(t.Context(), 100, "", "current-password", "new-password")
+		require.NoError(t, err)
+	})
+}
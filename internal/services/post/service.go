@@ -2,28 +2,45 @@ package post
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/authz"
 	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 )
 
 //go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
 
+const (
+	defaultPageSize = 20
+
+	// maxPageSize bounds the page size regardless of the requested limit.
+	maxPageSize = 100
+)
+
 type postRepository interface {
 	Create(ctx context.Context, post *models.Post) error
-	GetPosts(ctx context.Context) ([]models.Post, error)
+	GetPosts(ctx context.Context, query domain.PostsQuery) ([]models.Post, error)
 	GetPost(ctx context.Context, id uint) (models.Post, error)
 	Update(ctx context.Context, post *models.Post) error
 	Delete(ctx context.Context, post *models.Post) error
 }
 
+type authorizer interface {
+	Can(ctx context.Context, actor domain.Actor, action authz.Action, resource authz.Resource) error
+}
+
 type Service struct {
 	postRepository postRepository
+	authorizer     authorizer
 }
 
-func NewService(postRepository postRepository) *Service {
-	return &Service{postRepository: postRepository}
+func NewService(postRepository postRepository, authorizer authorizer) *Service {
+	return &Service{postRepository: postRepository, authorizer: authorizer}
 }
 
 func (s *Service) Create(ctx context.Context, post *models.Post) error {
@@ -34,13 +51,57 @@ func (s *Service) Create(ctx context.Context, post *models.Post) error {
 	return nil
 }
 
-func (s *Service) GetPosts(ctx context.Context) ([]models.Post, error) {
-	posts, err := s.postRepository.GetPosts(ctx)
+// GetPosts returns a page of posts. Pages are selected by keyset, so posts created while paging
+// don't shift the following pages.
+//
+// It returns an error wrapping [models.ErrInvalidCursor] when the cursor is malformed
+// or was issued for another sort order.
+func (s *Service) GetPosts(ctx context.Context, request domain.GetPostsRequest) (domain.PostsPage, error) {
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	limit = min(limit, maxPageSize)
+
+	sort := request.Sort
+	if sort.Field == "" {
+		sort.Field = domain.PostSortCreatedAt
+	}
+
+	// One more post is selected to find out whether there is a next page.
+	query := domain.PostsQuery{Filter: request.Filter, Sort: sort, Limit: limit + 1}
+
+	if request.Cursor != "" {
+		after, err := decodeCursor(request.Cursor)
+		if err != nil {
+			return domain.PostsPage{}, errors.Join(models.ErrInvalidCursor, err)
+		}
+
+		if after.Sort != sort {
+			return domain.PostsPage{}, fmt.Errorf("%w: cursor was issued for another sort order", models.ErrInvalidCursor)
+		}
+
+		query.After = &after
+	}
+
+	posts, err := s.postRepository.GetPosts(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("get posts from repository: %w", err)
+		return domain.PostsPage{}, fmt.Errorf("get posts from repository: %w", err)
+	}
+
+	if len(posts) <= limit {
+		return domain.PostsPage{Posts: posts}, nil
 	}
 
-	return posts, nil
+	posts = posts[:limit]
+
+	nextCursor, err := encodeCursor(newCursor(sort, posts[limit-1]))
+	if err != nil {
+		return domain.PostsPage{}, fmt.Errorf("encode next cursor: %w", err)
+	}
+
+	return domain.PostsPage{Posts: posts, NextCursor: nextCursor}, nil
 }
 
 func (s *Service) GetPost(ctx context.Context, id uint) (models.Post, error) {
@@ -59,8 +120,8 @@ func (s *Service) UpdateByUser(ctx context.Context, request domain.UpdatePostReq
 		return nil, fmt.Errorf("get stored post from repository: %w", err)
 	}
 
-	if post.UserID != request.UserID {
-		return nil, models.ErrForbidden
+	if err := s.authorizer.Can(ctx, request.Actor, authz.ActionUpdate, resource(post)); err != nil {
+		return nil, fmt.Errorf("authorize post update: %w", err)
 	}
 
 	post.Title = request.Title
@@ -80,8 +141,8 @@ func (s *Service) DeleteByUser(ctx context.Context, request domain.DeletePostReq
 		return fmt.Errorf("get stored post from repository: %w", err)
 	}
 
-	if post.UserID != request.UserID {
-		return models.ErrForbidden
+	if err := s.authorizer.Can(ctx, request.Actor, authz.ActionDelete, resource(post)); err != nil {
+		return fmt.Errorf("authorize post deletion: %w", err)
 	}
 
 	if err := s.postRepository.Delete(ctx, &post); err != nil {
@@ -90,3 +151,79 @@ func (s *Service) DeleteByUser(ctx context.Context, request domain.DeletePostReq
 
 	return nil
 }
+
+func resource(post models.Post) authz.Resource {
+	return authz.Resource{
+		Type:      authz.ResourcePost,
+		OwnerID:   post.UserID,
+		CreatedAt: post.CreatedAt,
+	}
+}
+
+// cursor is an encoded [domain.PostCursor]. Cursors are opaque to clients, the encoding may change.
+type cursor struct {
+	Field      domain.PostSortField `json:"f"`
+	Descending bool                 `json:"d,omitempty"`
+	Time       *time.Time           `json:"t,omitempty"`
+	Title      string               `json:"v,omitempty"`
+	ID         uint                 `json:"id"`
+}
+
+func newCursor(sort domain.PostSort, post models.Post) domain.PostCursor {
+	after := domain.PostCursor{Sort: sort, ID: post.ID}
+
+	switch sort.Field {
+	case domain.PostSortCreatedAt:
+		after.Time = post.CreatedAt
+	case domain.PostSortUpdatedAt:
+		after.Time = post.UpdatedAt
+	case domain.PostSortTitle:
+		after.Title = post.Title
+	}
+
+	return after
+}
+
+func encodeCursor(after domain.PostCursor) (string, error) {
+	encoded := cursor{
+		Field:      after.Sort.Field,
+		Descending: after.Sort.Descending,
+		Title:      after.Title,
+		ID:         after.ID,
+	}
+
+	if after.Sort.Field != domain.PostSortTitle {
+		encoded.Time = &after.Time
+	}
+
+	rawCursor, err := json.Marshal(encoded)
+	if err != nil {
+		return "", fmt.Errorf("marshal cursor: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(rawCursor), nil
+}
+
+func decodeCursor(value string) (domain.PostCursor, error) {
+	rawCursor, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return domain.PostCursor{}, fmt.Errorf("decode cursor: %w", err)
+	}
+
+	var decoded cursor
+	if err := json.Unmarshal(rawCursor, &decoded); err != nil {
+		return domain.PostCursor{}, fmt.Errorf("unmarshal cursor: %w", err)
+	}
+
+	after := domain.PostCursor{
+		Sort:  domain.PostSort{Field: decoded.Field, Descending: decoded.Descending},
+		Title: decoded.Title,
+		ID:    decoded.ID,
+	}
+
+	if decoded.Time != nil {
+		after.Time = *decoded.Time
+	}
+
+	return after, nil
+}
@@ -13,6 +13,8 @@ import (
 	context "context"
 	reflect "reflect"
 
+	authz "github.com/nix-united/golang-echo-boilerplate/internal/authz"
+	domain "github.com/nix-united/golang-echo-boilerplate/internal/domain"
 	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -157,18 +159,18 @@ func (c *MockpostRepositoryGetPostCall) DoAndReturn(f func(context.Context, uint
 }
 
 // GetPosts mocks base method.
-func (m *MockpostRepository) GetPosts(ctx context.Context) ([]models.Post, error) {
+func (m *MockpostRepository) GetPosts(ctx context.Context, query domain.PostsQuery) ([]models.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPosts", ctx)
+	ret := m.ctrl.Call(m, "GetPosts", ctx, query)
 	ret0, _ := ret[0].([]models.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPosts indicates an expected call of GetPosts.
-func (mr *MockpostRepositoryMockRecorder) GetPosts(ctx any) *MockpostRepositoryGetPostsCall {
+func (mr *MockpostRepositoryMockRecorder) GetPosts(ctx, query any) *MockpostRepositoryGetPostsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*MockpostRepository)(nil).GetPosts), ctx)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*MockpostRepository)(nil).GetPosts), ctx, query)
 	return &MockpostRepositoryGetPostsCall{Call: call}
 }
 
@@ -184,13 +186,13 @@ func (c *MockpostRepositoryGetPostsCall) Return(arg0 []models.Post, arg1 error)
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockpostRepositoryGetPostsCall) Do(f func(context.Context) ([]models.Post, error)) *MockpostRepositoryGetPostsCall {
+func (c *MockpostRepositoryGetPostsCall) Do(f func(context.Context, domain.PostsQuery) ([]models.Post, error)) *MockpostRepositoryGetPostsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockpostRepositoryGetPostsCall) DoAndReturn(f func(context.Context) ([]models.Post, error)) *MockpostRepositoryGetPostsCall {
+func (c *MockpostRepositoryGetPostsCall) DoAndReturn(f func(context.Context, domain.PostsQuery) ([]models.Post, error)) *MockpostRepositoryGetPostsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -232,3 +234,65 @@ func (c *MockpostRepositoryUpdateCall) DoAndReturn(f func(context.Context, *mode
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// Mockauthorizer is a mock of authorizer interface.
+type Mockauthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockauthorizerMockRecorder
+	isgomock struct{}
+}
+
+// MockauthorizerMockRecorder is the mock recorder for Mockauthorizer.
+type MockauthorizerMockRecorder struct {
+	mock *Mockauthorizer
+}
+
+// NewMockauthorizer creates a new mock instance.
+func NewMockauthorizer(ctrl *gomock.Controller) *Mockauthorizer {
+	mock := &Mockauthorizer{ctrl: ctrl}
+	mock.recorder = &MockauthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockauthorizer) EXPECT() *MockauthorizerMockRecorder {
+	return m.recorder
+}
+
+// Can mocks base method.
+func (m *Mockauthorizer) Can(ctx context.Context, actor domain.Actor, action authz.Action, resource authz.Resource) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Can", ctx, actor, action, resource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Can indicates an expected call of Can.
+func (mr *MockauthorizerMockRecorder) Can(ctx, actor, action, resource any) *MockauthorizerCanCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Can", reflect.TypeOf((*Mockauthorizer)(nil).Can), ctx, actor, action, resource)
+	return &MockauthorizerCanCall{Call: call}
+}
+
+// MockauthorizerCanCall wrap *gomock.Call
+type MockauthorizerCanCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockauthorizerCanCall) Return(arg0 error) *MockauthorizerCanCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockauthorizerCanCall) Do(f func(context.Context, domain.Actor, authz.Action, authz.Resource) error) *MockauthorizerCanCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockauthorizerCanCall) DoAndReturn(f func(context.Context, domain.Actor, authz.Action, authz.Resource) error) *MockauthorizerCanCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -2,7 +2,9 @@ package post_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/authz"
 	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/post"
@@ -22,7 +24,7 @@ func TestService_Create(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	postService := post.NewService(postRepository, NewMockauthorizer(ctrl))
 
 	postRepository.
 		EXPECT().
@@ -34,25 +36,119 @@ func TestService_Create(t *testing.T) {
 }
 
 func TestService_GetPosts(t *testing.T) {
-	wantPosts := []models.Post{{
-		Title:   "title",
-		Content: "conent",
-		UserID:  111,
-	}}
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
 
-	ctrl := gomock.NewController(t)
-	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	newPosts := func(count int) []models.Post {
+		posts := make([]models.Post, 0, count)
+		for i := range count {
+			posts = append(posts, models.Post{
+				Model: gorm.Model{ID: uint(100 - i), CreatedAt: createdAt.Add(-time.Duration(i) * time.Minute)},
+				Title: "title",
+			})
+		}
 
-	postRepository.
-		EXPECT().
-		GetPosts(gomock.Any()).
-		Return(wantPosts, nil)
+		return posts
+	}
 
-	gotPosts, err := postService.GetPosts(t.Context())
-	require.NoError(t, err)
+	newestFirst := domain.PostSort{Field: domain.PostSortCreatedAt, Descending: true}
+
+	t.Run("It should return the first page with default limit and sort", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		postService := post.NewService(postRepository, NewMockauthorizer(ctrl))
+
+		postRepository.
+			EXPECT().
+			GetPosts(gomock.Any(), domain.PostsQuery{Sort: newestFirst, Limit: 21}).
+			Return(newPosts(3), nil)
+
+		page, err := postService.GetPosts(t.Context(), domain.GetPostsRequest{Sort: domain.PostSort{Descending: true}})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.PostsPage{Posts: newPosts(3)}, page)
+	})
+
+	t.Run("It should cap the limit at the maximal page size", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		postService := post.NewService(postRepository, NewMockauthorizer(ctrl))
+
+		postRepository.
+			EXPECT().
+			GetPosts(gomock.Any(), domain.PostsQuery{Sort: newestFirst, Limit: 101}).
+			Return(nil, nil)
+
+		_, err := postService.GetPosts(t.Context(), domain.GetPostsRequest{Sort: newestFirst, Limit: 1000})
+		require.NoError(t, err)
+	})
 
-	assert.Equal(t, wantPosts, gotPosts)
+	t.Run("It should return the next page by the cursor of the previous page", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		postService := post.NewService(postRepository, NewMockauthorizer(ctrl))
+
+		authorID := uint(200)
+		filter := domain.PostFilter{AuthorID: &authorID}
+
+		postRepository.
+			EXPECT().
+			GetPosts(gomock.Any(), domain.PostsQuery{Filter: filter, Sort: newestFirst, Limit: 3}).
+			Return(newPosts(3), nil)
+
+		firstPage, err := postService.GetPosts(t.Context(), domain.GetPostsRequest{Filter: filter, Sort: newestFirst, Limit: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, newPosts(2), firstPage.Posts)
+		require.NotEmpty(t, firstPage.NextCursor)
+
+		postRepository.
+			EXPECT().
+			GetPosts(gomock.Any(), domain.PostsQuery{
+				Filter: filter,
+				Sort:   newestFirst,
+				Limit:  3,
+				After:  &domain.PostCursor{Sort: newestFirst, Time: createdAt.Add(-time.Minute), ID: 99},
+			}).
+			Return(newPosts(1), nil)
+
+		secondPage, err := postService.GetPosts(t.Context(), domain.GetPostsRequest{
+			Filter: filter,
+			Sort:   newestFirst,
+			Limit:  2,
+			Cursor: firstPage.NextCursor,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, domain.PostsPage{Posts: newPosts(1)}, secondPage)
+	})
+
+	t.Run("It should reject the cursor of another sort order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		postService := post.NewService(postRepository, NewMockauthorizer(ctrl))
+
+		postRepository.
+			EXPECT().
+			GetPosts(gomock.Any(), gomock.Any()).
+			Return(newPosts(2), nil)
+
+		firstPage, err := postService.GetPosts(t.Context(), domain.GetPostsRequest{Sort: newestFirst, Limit: 1})
+		require.NoError(t, err)
+
+		_, err = postService.GetPosts(t.Context(), domain.GetPostsRequest{
+			Sort:   domain.PostSort{Field: domain.PostSortTitle},
+			Cursor: firstPage.NextCursor,
+		})
+		assert.ErrorIs(t, err, models.ErrInvalidCursor)
+	})
+
+	t.Run("It should reject malformed cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postService := post.NewService(NewMockpostRepository(ctrl), NewMockauthorizer(ctrl))
+
+		_, err := postService.GetPosts(t.Context(), domain.GetPostsRequest{Sort: newestFirst, Cursor: "not a cursor"})
+		assert.ErrorIs(t, err, models.ErrInvalidCursor)
+	})
 }
 
 func TestService_GetPost(t *testing.T) {
@@ -64,7 +160,7 @@ func TestService_GetPost(t *testing.T) {
 
 	ctrl := gomock.NewController(t)
 	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	postService := post.NewService(postRepository, NewMockauthorizer(ctrl))
 
 	postRepository.
 		EXPECT().
@@ -93,30 +189,60 @@ func TestService_UpdateByUser(t *testing.T) {
 	}
 
 	request := domain.UpdatePostRequest{
-		UserID:  111,
+		Actor:   domain.Actor{UserID: 111, Roles: models.Roles{models.RoleUser}},
 		PostID:  222,
 		Title:   "new title",
 		Content: "new content",
 	}
 
-	ctrl := gomock.NewController(t)
-	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	wantResource := authz.Resource{Type: authz.ResourcePost, OwnerID: 111}
 
-	postRepository.
-		EXPECT().
-		GetPost(gomock.Any(), request.PostID).
-		Return(oldPost, nil)
+	t.Run("It should update a post", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		authorizer := NewMockauthorizer(ctrl)
+		postService := post.NewService(postRepository, authorizer)
 
-	postRepository.
-		EXPECT().
-		Update(gomock.Any(), wantPost).
-		Return(nil)
+		postRepository.
+			EXPECT().
+			GetPost(gomock.Any(), request.PostID).
+			Return(oldPost, nil)
 
-	newPost, err := postService.UpdateByUser(t.Context(), request)
-	require.NoError(t, err)
+		authorizer.
+			EXPECT().
+			Can(gomock.Any(), request.Actor, authz.ActionUpdate, wantResource).
+			Return(nil)
+
+		postRepository.
+			EXPECT().
+			Update(gomock.Any(), wantPost).
+			Return(nil)
 
-	assert.Equal(t, wantPost, newPost)
+		newPost, err := postService.UpdateByUser(t.Context(), request)
+		require.NoError(t, err)
+
+		assert.Equal(t, wantPost, newPost)
+	})
+
+	t.Run("It should not update a post when it is forbidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		authorizer := NewMockauthorizer(ctrl)
+		postService := post.NewService(postRepository, authorizer)
+
+		postRepository.
+			EXPECT().
+			GetPost(gomock.Any(), request.PostID).
+			Return(oldPost, nil)
+
+		authorizer.
+			EXPECT().
+			Can(gomock.Any(), request.Actor, authz.ActionUpdate, wantResource).
+			Return(models.ErrForbidden)
+
+		_, err := postService.UpdateByUser(t.Context(), request)
+		assert.ErrorIs(t, err, models.ErrForbidden)
+	})
 }
 
 func TestService_DeleteByUser(t *testing.T) {
@@ -127,23 +253,55 @@ func TestService_DeleteByUser(t *testing.T) {
 		UserID:  111,
 	}
 
-	ctrl := gomock.NewController(t)
-	postRepository := NewMockpostRepository(ctrl)
-	postService := post.NewService(postRepository)
+	request := domain.DeletePostRequest{
+		Actor:  domain.Actor{UserID: 111, Roles: models.Roles{models.RoleUser}},
+		PostID: wantPost.ID,
+	}
 
-	postRepository.
-		EXPECT().
-		GetPost(gomock.Any(), wantPost.ID).
-		Return(*wantPost, nil)
+	wantResource := authz.Resource{Type: authz.ResourcePost, OwnerID: 111}
 
-	postRepository.
-		EXPECT().
-		Delete(gomock.Any(), wantPost).
-		Return(nil)
+	t.Run("It should delete a post", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		authorizer := NewMockauthorizer(ctrl)
+		postService := post.NewService(postRepository, authorizer)
 
-	err := postService.DeleteByUser(t.Context(), domain.DeletePostRequest{
-		UserID: wantPost.UserID,
-		PostID: wantPost.ID,
+		postRepository.
+			EXPECT().
+			GetPost(gomock.Any(), wantPost.ID).
+			Return(*wantPost, nil)
+
+		authorizer.
+			EXPECT().
+			Can(gomock.Any(), request.Actor, authz.ActionDelete, wantResource).
+			Return(nil)
+
+		postRepository.
+			EXPECT().
+			Delete(gomock.Any(), wantPost).
+			Return(nil)
+
+		err := postService.DeleteByUser(t.Context(), request)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should not delete a post when it is forbidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		postRepository := NewMockpostRepository(ctrl)
+		authorizer := NewMockauthorizer(ctrl)
+		postService := post.NewService(postRepository, authorizer)
+
+		postRepository.
+			EXPECT().
+			GetPost(gomock.Any(), wantPost.ID).
+			Return(*wantPost, nil)
+
+		authorizer.
+			EXPECT().
+			Can(gomock.Any(), request.Actor, authz.ActionDelete, wantResource).
+			Return(models.ErrForbidden)
+
+		err := postService.DeleteByUser(t.Context(), request)
+		assert.ErrorIs(t, err, models.ErrForbidden)
 	})
-	require.NoError(t, err)
 }
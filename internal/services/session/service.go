@@ -0,0 +1,338 @@
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+// maxUserAgentLength is the length of the longest user agent stored with a session, longer ones are truncated.
+const maxUserAgentLength = 512
+
+type refreshTokenRepository interface {
+	Create(ctx context.Context, refreshToken *models.RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error)
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	RevokeUserFamily(ctx context.Context, userID uint, familyID string, revokedAt time.Time) error
+	RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) error
+	RevokeByUserExcept(ctx context.Context, userID uint, familyID string, revokedAt time.Time) error
+}
+
+type sessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	GetByID(ctx context.Context, id string) (models.Session, error)
+	GetActiveByUser(ctx context.Context, userID uint, now time.Time) ([]models.Session, error)
+	Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error
+	Revoke(ctx context.Context, userID uint, id string, revokedAt time.Time) error
+	RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) error
+	RevokeByUserExcept(ctx context.Context, userID uint, id string, revokedAt time.Time) error
+}
+
+type tokenService interface {
+	CreateRefreshToken(ctx context.Context, user *models.User, scopes models.Scopes) (string, int64, error)
+}
+
+// Service keeps track of issued refresh tokens.
+//
+// Each login starts a new family of refresh tokens, and the family ID identifies the session.
+// A refresh token can be rotated exactly once; presenting it again means it has leaked,
+// so the whole family gets revoked.
+//
+// Every session is recorded along with the client it was started from, so that users can see
+// where they are logged in and revoke the sessions they don't recognize.
+type Service struct {
+	now                    func() time.Time
+	newUUID                func() (uuid.UUID, error)
+	refreshTokenRepository refreshTokenRepository
+	sessionRepository      sessionRepository
+	tokenService           tokenService
+}
+
+func NewService(
+	now func() time.Time,
+	newUUID func() (uuid.UUID, error),
+	refreshTokenRepository refreshTokenRepository,
+	sessionRepository sessionRepository,
+	tokenService tokenService,
+) *Service {
+	return &Service{
+		now:                    now,
+		newUUID:                newUUID,
+		refreshTokenRepository: refreshTokenRepository,
+		sessionRepository:      sessionRepository,
+		tokenService:           tokenService,
+	}
+}
+
+// Create starts a new session with the granted scopes for the user and returns its first refresh token.
+// The provider is the login method used to start the session, e.g. [models.LoginProviderPassword].
+func (s *Service) Create(
+	ctx context.Context,
+	user *models.User,
+	scopes models.Scopes,
+	client models.Client,
+	provider string,
+) (refreshToken, sessionID string, err error) {
+	familyID, err := s.newUUID()
+	if err != nil {
+		return "", "", fmt.Errorf("new refresh token family id: %w", err)
+	}
+
+	refreshToken, expiresAt, err := s.issue(ctx, user, familyID.String(), scopes)
+	if err != nil {
+		return "", "", fmt.Errorf("issue refresh token: %w", err)
+	}
+
+	now := s.now()
+
+	err = s.sessionRepository.Create(ctx, &models.Session{
+		ID:         familyID.String(),
+		UserID:     user.ID,
+		UserAgent:  truncate(client.UserAgent, maxUserAgentLength),
+		IPAddress:  client.IPAddress,
+		Provider:   provider,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("create session in repository: %w", err)
+	}
+
+	return refreshToken, familyID.String(), nil
+}
+
+// List returns the active sessions of the user, most recently used first.
+func (s *Service) List(ctx context.Context, userID uint) ([]models.Session, error) {
+	sessions, err := s.sessionRepository.GetActiveByUser(ctx, userID, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("get active sessions from repository: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// Rotate exchanges a valid refresh token for a new one within the same family.
+// The new token keeps the scopes granted to the session.
+//
+// It returns [models.ErrRefreshTokenReused] and revokes the family when the token has already been used,
+// and [models.ErrInvalidAuthToken] when the token is unknown, revoked, belongs to another user
+// or its session has been revoked.
+func (s *Service) Rotate(
+	ctx context.Context,
+	user *models.User,
+	refreshToken string,
+	scopes models.Scopes,
+) (newRefreshToken, sessionID string, err error) {
+	storedToken, err := s.refreshTokenRepository.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, models.ErrRefreshTokenNotFound) {
+		return "", "", errors.Join(err, models.ErrInvalidAuthToken)
+	} else if err != nil {
+		return "", "", fmt.Errorf("get refresh token from repository: %w", err)
+	}
+
+	if storedToken.UserID != user.ID || storedToken.RevokedAt != nil {
+		return "", "", models.ErrInvalidAuthToken
+	}
+
+	session, err := s.sessionRepository.GetByID(ctx, storedToken.FamilyID)
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return "", "", errors.Join(err, models.ErrInvalidAuthToken)
+	} else if err != nil {
+		return "", "", fmt.Errorf("get session from repository: %w", err)
+	}
+
+	if session.RevokedAt != nil {
+		return "", "", models.ErrInvalidAuthToken
+	}
+
+	if storedToken.UsedAt != nil {
+		return "", "", s.revokeReusedFamily(ctx, user.ID, storedToken.FamilyID)
+	}
+
+	err = s.refreshTokenRepository.MarkUsed(ctx, storedToken.ID, s.now())
+	if errors.Is(err, models.ErrRefreshTokenReused) {
+		// Another request rotated the same token concurrently.
+		return "", "", s.revokeReusedFamily(ctx, user.ID, storedToken.FamilyID)
+	} else if err != nil {
+		return "", "", fmt.Errorf("mark refresh token as used in repository: %w", err)
+	}
+
+	newRefreshToken, expiresAt, err := s.issue(ctx, user, storedToken.FamilyID, scopes)
+	if err != nil {
+		return "", "", fmt.Errorf("issue refresh token: %w", err)
+	}
+
+	if err := s.sessionRepository.Touch(ctx, session.ID, s.now(), expiresAt); err != nil {
+		return "", "", fmt.Errorf("touch session in repository: %w", err)
+	}
+
+	return newRefreshToken, storedToken.FamilyID, nil
+}
+
+// Revoke revokes a single session of the user.
+// It returns [models.ErrSessionNotFound] when the user has no such active session.
+func (s *Service) Revoke(ctx context.Context, userID uint, sessionID string) error {
+	if err := s.sessionRepository.Revoke(ctx, userID, sessionID, s.now()); err != nil {
+		return fmt.Errorf("revoke session in repository: %w", err)
+	}
+
+	if err := s.refreshTokenRepository.RevokeUserFamily(ctx, userID, sessionID, s.now()); err != nil {
+		return fmt.Errorf("revoke refresh token family in repository: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAll revokes every session of the user.
+func (s *Service) RevokeAll(ctx context.Context, userID uint) error {
+	if err := s.sessionRepository.RevokeByUser(ctx, userID, s.now()); err != nil {
+		return fmt.Errorf("revoke user sessions in repository: %w", err)
+	}
+
+	if err := s.refreshTokenRepository.RevokeByUser(ctx, userID, s.now()); err != nil {
+		return fmt.Errorf("revoke user refresh tokens in repository: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOthers revokes every session of the user except the given one.
+func (s *Service) RevokeOthers(ctx context.Context, userID uint, sessionID string) error {
+	if err := s.sessionRepository.RevokeByUserExcept(ctx, userID, sessionID, s.now()); err != nil {
+		return fmt.Errorf("revoke other user sessions in repository: %w", err)
+	}
+
+	if err := s.refreshTokenRepository.RevokeByUserExcept(ctx, userID, sessionID, s.now()); err != nil {
+		return fmt.Errorf("revoke other user refresh tokens in repository: %w", err)
+	}
+
+	return nil
+}
+
+// IsRevoked reports whether the session has been revoked, so that access tokens issued for it must be rejected.
+// Unknown sessions are reported as revoked.
+func (s *Service) IsRevoked(ctx context.Context, sessionID string) (bool, error) {
+	session, err := s.sessionRepository.GetByID(ctx, sessionID)
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("get session from repository: %w", err)
+	}
+
+	return session.RevokedAt != nil, nil
+}
+
+// IsActive reports whether the refresh token can still be rotated: it is known, neither used nor revoked,
+// and its session has not been revoked. The signature and expiration of the token must be verified beforehand.
+func (s *Service) IsActive(ctx context.Context, refreshToken string) (bool, error) {
+	storedToken, err := s.refreshTokenRepository.GetByHash(ctx, hashToken(refreshToken))
+	if errors.Is(err, models.ErrRefreshTokenNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("get refresh token from repository: %w", err)
+	}
+
+	if storedToken.UsedAt != nil || storedToken.RevokedAt != nil || !s.now().Before(storedToken.ExpiresAt) {
+		return false, nil
+	}
+
+	session, err := s.sessionRepository.GetByID(ctx, storedToken.FamilyID)
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("get session from repository: %w", err)
+	}
+
+	return session.RevokedAt == nil, nil
+}
+
+// RevokeByRefreshToken revokes the session the refresh token belongs to.
+// It returns [models.ErrRefreshTokenNotFound] when the token is unknown.
+func (s *Service) RevokeByRefreshToken(ctx context.Context, refreshToken string) error {
+	storedToken, err := s.refreshTokenRepository.GetByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return fmt.Errorf("get refresh token from repository: %w", err)
+	}
+
+	err = s.sessionRepository.Revoke(ctx, storedToken.UserID, storedToken.FamilyID, s.now())
+	if err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return fmt.Errorf("revoke session in repository: %w", err)
+	}
+
+	if err := s.refreshTokenRepository.RevokeFamily(ctx, storedToken.FamilyID, s.now()); err != nil {
+		return fmt.Errorf("revoke refresh token family in repository: %w", err)
+	}
+
+	return nil
+}
+
+// issue creates a refresh token of the family and returns it along with its expiration time.
+func (s *Service) issue(
+	ctx context.Context,
+	user *models.User,
+	familyID string,
+	scopes models.Scopes,
+) (string, time.Time, error) {
+	refreshToken, exp, err := s.tokenService.CreateRefreshToken(ctx, user, scopes)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("create refresh token: %w", err)
+	}
+
+	expiresAt := time.Unix(exp, 0)
+
+	err = s.refreshTokenRepository.Create(ctx, &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("create refresh token in repository: %w", err)
+	}
+
+	return refreshToken, expiresAt, nil
+}
+
+func (s *Service) revokeReusedFamily(ctx context.Context, userID uint, familyID string) error {
+	slog.WarnContext(ctx, "Refresh token reuse detected, revoking refresh token family", "family_id", familyID)
+
+	err := s.sessionRepository.Revoke(ctx, userID, familyID, s.now())
+	if err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+		return fmt.Errorf("revoke session in repository: %w", err)
+	}
+
+	if err := s.refreshTokenRepository.RevokeFamily(ctx, familyID, s.now()); err != nil {
+		return fmt.Errorf("revoke refresh token family in repository: %w", err)
+	}
+
+	return models.ErrRefreshTokenReused
+}
+
+// truncate cuts the string to at most limit characters.
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	return string([]rune(s)[:limit])
+}
+
+// hashToken returns a hex encoded SHA-256 hash of the token.
+// Refresh tokens are signed high-entropy strings, so a fast hash is sufficient.
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,666 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=session_test -typed=true
+//
+
+// Package session_test is a generated GoMock package.
+package session_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockrefreshTokenRepository is a mock of refreshTokenRepository interface.
+type MockrefreshTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrefreshTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockrefreshTokenRepositoryMockRecorder is the mock recorder for MockrefreshTokenRepository.
+type MockrefreshTokenRepositoryMockRecorder struct {
+	mock *MockrefreshTokenRepository
+}
+
+// NewMockrefreshTokenRepository creates a new mock instance.
+func NewMockrefreshTokenRepository(ctrl *gomock.Controller) *MockrefreshTokenRepository {
+	mock := &MockrefreshTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockrefreshTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrefreshTokenRepository) EXPECT() *MockrefreshTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockrefreshTokenRepository) Create(ctx context.Context, refreshToken *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockrefreshTokenRepositoryMockRecorder) Create(ctx, refreshToken any) *MockrefreshTokenRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockrefreshTokenRepository)(nil).Create), ctx, refreshToken)
+	return &MockrefreshTokenRepositoryCreateCall{Call: call}
+}
+
+// MockrefreshTokenRepositoryCreateCall wrap *gomock.Call
+type MockrefreshTokenRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenRepositoryCreateCall) Return(arg0 error) *MockrefreshTokenRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenRepositoryCreateCall) Do(f func(context.Context, *models.RefreshToken) error) *MockrefreshTokenRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.RefreshToken) error) *MockrefreshTokenRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByHash mocks base method.
+func (m *MockrefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockrefreshTokenRepositoryMockRecorder) GetByHash(ctx, tokenHash any) *MockrefreshTokenRepositoryGetByHashCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockrefreshTokenRepository)(nil).GetByHash), ctx, tokenHash)
+	return &MockrefreshTokenRepositoryGetByHashCall{Call: call}
+}
+
+// MockrefreshTokenRepositoryGetByHashCall wrap *gomock.Call
+type MockrefreshTokenRepositoryGetByHashCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenRepositoryGetByHashCall) Return(arg0 models.RefreshToken, arg1 error) *MockrefreshTokenRepositoryGetByHashCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenRepositoryGetByHashCall) Do(f func(context.Context, string) (models.RefreshToken, error)) *MockrefreshTokenRepositoryGetByHashCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenRepositoryGetByHashCall) DoAndReturn(f func(context.Context, string) (models.RefreshToken, error)) *MockrefreshTokenRepositoryGetByHashCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkUsed mocks base method.
+func (m *MockrefreshTokenRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockrefreshTokenRepositoryMockRecorder) MarkUsed(ctx, id, usedAt any) *MockrefreshTokenRepositoryMarkUsedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockrefreshTokenRepository)(nil).MarkUsed), ctx, id, usedAt)
+	return &MockrefreshTokenRepositoryMarkUsedCall{Call: call}
+}
+
+// MockrefreshTokenRepositoryMarkUsedCall wrap *gomock.Call
+type MockrefreshTokenRepositoryMarkUsedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenRepositoryMarkUsedCall) Return(arg0 error) *MockrefreshTokenRepositoryMarkUsedCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenRepositoryMarkUsedCall) Do(f func(context.Context, uint, time.Time) error) *MockrefreshTokenRepositoryMarkUsedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenRepositoryMarkUsedCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MockrefreshTokenRepositoryMarkUsedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeByUser mocks base method.
+func (m *MockrefreshTokenRepository) RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByUser", ctx, userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeByUser indicates an expected call of RevokeByUser.
+func (mr *MockrefreshTokenRepositoryMockRecorder) RevokeByUser(ctx, userID, revokedAt any) *MockrefreshTokenRepositoryRevokeByUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByUser", reflect.TypeOf((*MockrefreshTokenRepository)(nil).RevokeByUser), ctx, userID, revokedAt)
+	return &MockrefreshTokenRepositoryRevokeByUserCall{Call: call}
+}
+
+// MockrefreshTokenRepositoryRevokeByUserCall wrap *gomock.Call
+type MockrefreshTokenRepositoryRevokeByUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenRepositoryRevokeByUserCall) Return(arg0 error) *MockrefreshTokenRepositoryRevokeByUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenRepositoryRevokeByUserCall) Do(f func(context.Context, uint, time.Time) error) *MockrefreshTokenRepositoryRevokeByUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenRepositoryRevokeByUserCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MockrefreshTokenRepositoryRevokeByUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeByUserExcept mocks base method.
+func (m *MockrefreshTokenRepository) RevokeByUserExcept(ctx context.Context, userID uint, familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByUserExcept", ctx, userID, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeByUserExcept indicates an expected call of RevokeByUserExcept.
+func (mr *MockrefreshTokenRepositoryMockRecorder) RevokeByUserExcept(ctx, userID, familyID, revokedAt any) *MockrefreshTokenRepositoryRevokeByUserExceptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByUserExcept", reflect.TypeOf((*MockrefreshTokenRepository)(nil).RevokeByUserExcept), ctx, userID, familyID, revokedAt)
+	return &MockrefreshTokenRepositoryRevokeByUserExceptCall{Call: call}
+}
+
+// MockrefreshTokenRepositoryRevokeByUserExceptCall wrap *gomock.Call
+type MockrefreshTokenRepositoryRevokeByUserExceptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenRepositoryRevokeByUserExceptCall) Return(arg0 error) *MockrefreshTokenRepositoryRevokeByUserExceptCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenRepositoryRevokeByUserExceptCall) Do(f func(context.Context, uint, string, time.Time) error) *MockrefreshTokenRepositoryRevokeByUserExceptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenRepositoryRevokeByUserExceptCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MockrefreshTokenRepositoryRevokeByUserExceptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeFamily mocks base method.
+func (m *MockrefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFamily", ctx, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeFamily indicates an expected call of RevokeFamily.
+func (mr *MockrefreshTokenRepositoryMockRecorder) RevokeFamily(ctx, familyID, revokedAt any) *MockrefreshTokenRepositoryRevokeFamilyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFamily", reflect.TypeOf((*MockrefreshTokenRepository)(nil).RevokeFamily), ctx, familyID, revokedAt)
+	return &MockrefreshTokenRepositoryRevokeFamilyCall{Call: call}
+}
+
+// MockrefreshTokenRepositoryRevokeFamilyCall wrap *gomock.Call
+type MockrefreshTokenRepositoryRevokeFamilyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenRepositoryRevokeFamilyCall) Return(arg0 error) *MockrefreshTokenRepositoryRevokeFamilyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenRepositoryRevokeFamilyCall) Do(f func(context.Context, string, time.Time) error) *MockrefreshTokenRepositoryRevokeFamilyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenRepositoryRevokeFamilyCall) DoAndReturn(f func(context.Context, string, time.Time) error) *MockrefreshTokenRepositoryRevokeFamilyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeUserFamily mocks base method.
+func (m *MockrefreshTokenRepository) RevokeUserFamily(ctx context.Context, userID uint, familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserFamily", ctx, userID, familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserFamily indicates an expected call of RevokeUserFamily.
+func (mr *MockrefreshTokenRepositoryMockRecorder) RevokeUserFamily(ctx, userID, familyID, revokedAt any) *MockrefreshTokenRepositoryRevokeUserFamilyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserFamily", reflect.TypeOf((*MockrefreshTokenRepository)(nil).RevokeUserFamily), ctx, userID, familyID, revokedAt)
+	return &MockrefreshTokenRepositoryRevokeUserFamilyCall{Call: call}
+}
+
+// MockrefreshTokenRepositoryRevokeUserFamilyCall wrap *gomock.Call
+type MockrefreshTokenRepositoryRevokeUserFamilyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrefreshTokenRepositoryRevokeUserFamilyCall) Return(arg0 error) *MockrefreshTokenRepositoryRevokeUserFamilyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrefreshTokenRepositoryRevokeUserFamilyCall) Do(f func(context.Context, uint, string, time.Time) error) *MockrefreshTokenRepositoryRevokeUserFamilyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrefreshTokenRepositoryRevokeUserFamilyCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MockrefreshTokenRepositoryRevokeUserFamilyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocksessionRepository is a mock of sessionRepository interface.
+type MocksessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MocksessionRepositoryMockRecorder is the mock recorder for MocksessionRepository.
+type MocksessionRepositoryMockRecorder struct {
+	mock *MocksessionRepository
+}
+
+// NewMocksessionRepository creates a new mock instance.
+func NewMocksessionRepository(ctrl *gomock.Controller) *MocksessionRepository {
+	mock := &MocksessionRepository{ctrl: ctrl}
+	mock.recorder = &MocksessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionRepository) EXPECT() *MocksessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MocksessionRepository) Create(ctx context.Context, session *models.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MocksessionRepositoryMockRecorder) Create(ctx, session any) *MocksessionRepositoryCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocksessionRepository)(nil).Create), ctx, session)
+	return &MocksessionRepositoryCreateCall{Call: call}
+}
+
+// MocksessionRepositoryCreateCall wrap *gomock.Call
+type MocksessionRepositoryCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRepositoryCreateCall) Return(arg0 error) *MocksessionRepositoryCreateCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRepositoryCreateCall) Do(f func(context.Context, *models.Session) error) *MocksessionRepositoryCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRepositoryCreateCall) DoAndReturn(f func(context.Context, *models.Session) error) *MocksessionRepositoryCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetActiveByUser mocks base method.
+func (m *MocksessionRepository) GetActiveByUser(ctx context.Context, userID uint, now time.Time) ([]models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByUser", ctx, userID, now)
+	ret0, _ := ret[0].([]models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByUser indicates an expected call of GetActiveByUser.
+func (mr *MocksessionRepositoryMockRecorder) GetActiveByUser(ctx, userID, now any) *MocksessionRepositoryGetActiveByUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByUser", reflect.TypeOf((*MocksessionRepository)(nil).GetActiveByUser), ctx, userID, now)
+	return &MocksessionRepositoryGetActiveByUserCall{Call: call}
+}
+
+// MocksessionRepositoryGetActiveByUserCall wrap *gomock.Call
+type MocksessionRepositoryGetActiveByUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRepositoryGetActiveByUserCall) Return(arg0 []models.Session, arg1 error) *MocksessionRepositoryGetActiveByUserCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRepositoryGetActiveByUserCall) Do(f func(context.Context, uint, time.Time) ([]models.Session, error)) *MocksessionRepositoryGetActiveByUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRepositoryGetActiveByUserCall) DoAndReturn(f func(context.Context, uint, time.Time) ([]models.Session, error)) *MocksessionRepositoryGetActiveByUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByID mocks base method.
+func (m *MocksessionRepository) GetByID(ctx context.Context, id string) (models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MocksessionRepositoryMockRecorder) GetByID(ctx, id any) *MocksessionRepositoryGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MocksessionRepository)(nil).GetByID), ctx, id)
+	return &MocksessionRepositoryGetByIDCall{Call: call}
+}
+
+// MocksessionRepositoryGetByIDCall wrap *gomock.Call
+type MocksessionRepositoryGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRepositoryGetByIDCall) Return(arg0 models.Session, arg1 error) *MocksessionRepositoryGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRepositoryGetByIDCall) Do(f func(context.Context, string) (models.Session, error)) *MocksessionRepositoryGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRepositoryGetByIDCall) DoAndReturn(f func(context.Context, string) (models.Session, error)) *MocksessionRepositoryGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Revoke mocks base method.
+func (m *MocksessionRepository) Revoke(ctx context.Context, userID uint, id string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, userID, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MocksessionRepositoryMockRecorder) Revoke(ctx, userID, id, revokedAt any) *MocksessionRepositoryRevokeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MocksessionRepository)(nil).Revoke), ctx, userID, id, revokedAt)
+	return &MocksessionRepositoryRevokeCall{Call: call}
+}
+
+// MocksessionRepositoryRevokeCall wrap *gomock.Call
+type MocksessionRepositoryRevokeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRepositoryRevokeCall) Return(arg0 error) *MocksessionRepositoryRevokeCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRepositoryRevokeCall) Do(f func(context.Context, uint, string, time.Time) error) *MocksessionRepositoryRevokeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRepositoryRevokeCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MocksessionRepositoryRevokeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeByUser mocks base method.
+func (m *MocksessionRepository) RevokeByUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByUser", ctx, userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeByUser indicates an expected call of RevokeByUser.
+func (mr *MocksessionRepositoryMockRecorder) RevokeByUser(ctx, userID, revokedAt any) *MocksessionRepositoryRevokeByUserCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByUser", reflect.TypeOf((*MocksessionRepository)(nil).RevokeByUser), ctx, userID, revokedAt)
+	return &MocksessionRepositoryRevokeByUserCall{Call: call}
+}
+
+// MocksessionRepositoryRevokeByUserCall wrap *gomock.Call
+type MocksessionRepositoryRevokeByUserCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRepositoryRevokeByUserCall) Return(arg0 error) *MocksessionRepositoryRevokeByUserCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRepositoryRevokeByUserCall) Do(f func(context.Context, uint, time.Time) error) *MocksessionRepositoryRevokeByUserCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRepositoryRevokeByUserCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MocksessionRepositoryRevokeByUserCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RevokeByUserExcept mocks base method.
+func (m *MocksessionRepository) RevokeByUserExcept(ctx context.Context, userID uint, id string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeByUserExcept", ctx, userID, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeByUserExcept indicates an expected call of RevokeByUserExcept.
+func (mr *MocksessionRepositoryMockRecorder) RevokeByUserExcept(ctx, userID, id, revokedAt any) *MocksessionRepositoryRevokeByUserExceptCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeByUserExcept", reflect.TypeOf((*MocksessionRepository)(nil).RevokeByUserExcept), ctx, userID, id, revokedAt)
+	return &MocksessionRepositoryRevokeByUserExceptCall{Call: call}
+}
+
+// MocksessionRepositoryRevokeByUserExceptCall wrap *gomock.Call
+type MocksessionRepositoryRevokeByUserExceptCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRepositoryRevokeByUserExceptCall) Return(arg0 error) *MocksessionRepositoryRevokeByUserExceptCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRepositoryRevokeByUserExceptCall) Do(f func(context.Context, uint, string, time.Time) error) *MocksessionRepositoryRevokeByUserExceptCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRepositoryRevokeByUserExceptCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MocksessionRepositoryRevokeByUserExceptCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Touch mocks base method.
+func (m *MocksessionRepository) Touch(ctx context.Context, id string, lastUsedAt, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id, lastUsedAt, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MocksessionRepositoryMockRecorder) Touch(ctx, id, lastUsedAt, expiresAt any) *MocksessionRepositoryTouchCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MocksessionRepository)(nil).Touch), ctx, id, lastUsedAt, expiresAt)
+	return &MocksessionRepositoryTouchCall{Call: call}
+}
+
+// MocksessionRepositoryTouchCall wrap *gomock.Call
+type MocksessionRepositoryTouchCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocksessionRepositoryTouchCall) Return(arg0 error) *MocksessionRepositoryTouchCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocksessionRepositoryTouchCall) Do(f func(context.Context, string, time.Time, time.Time) error) *MocksessionRepositoryTouchCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocksessionRepositoryTouchCall) DoAndReturn(f func(context.Context, string, time.Time, time.Time) error) *MocksessionRepositoryTouchCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MocktokenService is a mock of tokenService interface.
+type MocktokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MocktokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MocktokenServiceMockRecorder is the mock recorder for MocktokenService.
+type MocktokenServiceMockRecorder struct {
+	mock *MocktokenService
+}
+
+// NewMocktokenService creates a new mock instance.
+func NewMocktokenService(ctrl *gomock.Controller) *MocktokenService {
+	mock := &MocktokenService{ctrl: ctrl}
+	mock.recorder = &MocktokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktokenService) EXPECT() *MocktokenServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MocktokenService) CreateRefreshToken(ctx context.Context, user *models.User, scopes models.Scopes) (string, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, user, scopes)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MocktokenServiceMockRecorder) CreateRefreshToken(ctx, user, scopes any) *MocktokenServiceCreateRefreshTokenCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MocktokenService)(nil).CreateRefreshToken), ctx, user, scopes)
+	return &MocktokenServiceCreateRefreshTokenCall{Call: call}
+}
+
+// MocktokenServiceCreateRefreshTokenCall wrap *gomock.Call
+type MocktokenServiceCreateRefreshTokenCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MocktokenServiceCreateRefreshTokenCall) Return(arg0 string, arg1 int64, arg2 error) *MocktokenServiceCreateRefreshTokenCall {
+	c.Call = c.Call.Return(arg0, arg1, arg2)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MocktokenServiceCreateRefreshTokenCall) Do(f func(context.Context, *models.User, models.Scopes) (string, int64, error)) *MocktokenServiceCreateRefreshTokenCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MocktokenServiceCreateRefreshTokenCall) DoAndReturn(f func(context.Context, *models.User, models.Scopes) (string, int64, error)) *MocktokenServiceCreateRefreshTokenCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
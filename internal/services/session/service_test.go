@@ -0,0 +1,543 @@
+package session_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/session"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type serviceMocks struct {
+	refreshTokenRepository *MockrefreshTokenRepository
+	sessionRepository      *MocksessionRepository
+	tokenService           *MocktokenService
+}
+
+var (
+	currentTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	familyID    = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	scopes      = models.Scopes{models.ScopePostsRead}
+)
+
+func newService(t *testing.T) (*session.Service, serviceMocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	refreshTokenRepository := NewMockrefreshTokenRepository(ctrl)
+	sessionRepository := NewMocksessionRepository(ctrl)
+	tokenService := NewMocktokenService(ctrl)
+
+	service := session.NewService(
+		func() time.Time { return currentTime },
+		func() (uuid.UUID, error) { return familyID, nil },
+		refreshTokenRepository,
+		sessionRepository,
+		tokenService,
+	)
+
+	mocks := serviceMocks{
+		refreshTokenRepository: refreshTokenRepository,
+		sessionRepository:      sessionRepository,
+		tokenService:           tokenService,
+	}
+
+	return service, mocks
+}
+
+func hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestService_Create(t *testing.T) {
+	user := &models.User{Model: gorm.Model{ID: 1}}
+
+	testCases := map[string]struct {
+		userAgent     string
+		wantUserAgent string
+	}{
+		"It should record the session with the client": {
+			userAgent:     "Mozilla/5.0",
+			wantUserAgent: "Mozilla/5.0",
+		},
+		"It should truncate long user agent": {
+			userAgent:     strings.Repeat("ä", 600),
+			wantUserAgent: strings.Repeat("ä", 512),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mocks := newService(t)
+
+			mocks.tokenService.
+				EXPECT().
+				CreateRefreshToken(gomock.Any(), user, scopes).
+				Return("refresh-token", int64(1000), nil)
+
+			mocks.refreshTokenRepository.
+				EXPECT().
+				Create(gomock.Any(), &models.RefreshToken{
+					UserID:    1,
+					FamilyID:  familyID.String(),
+					TokenHash: hash("refresh-token"),
+					ExpiresAt: time.Unix(1000, 0),
+				}).
+				Return(nil)
+
+			mocks.sessionRepository.
+				EXPECT().
+				Create(gomock.Any(), &models.Session{
+					ID:         familyID.String(),
+					UserID:     1,
+					UserAgent:  testCase.wantUserAgent,
+					IPAddress:  "192.0.2.1",
+					Provider:   models.LoginProviderPassword,
+					CreatedAt:  currentTime,
+					LastUsedAt: currentTime,
+					ExpiresAt:  time.Unix(1000, 0),
+				}).
+				Return(nil)
+
+			client := models.Client{IPAddress: "192.0.2.1", UserAgent: testCase.userAgent}
+
+			refreshToken, sessionID, err := service.Create(t.Context(), user, scopes, client, models.LoginProviderPassword)
+			require.NoError(t, err)
+
+			assert.Equal(t, "refresh-token", refreshToken)
+			assert.Equal(t, familyID.String(), sessionID)
+		})
+	}
+}
+
+func TestService_List(t *testing.T) {
+	service, mocks := newService(t)
+
+	wantSessions := []models.Session{{ID: "family-id", UserID: 1}}
+
+	mocks.sessionRepository.
+		EXPECT().
+		GetActiveByUser(gomock.Any(), uint(1), currentTime).
+		Return(wantSessions, nil)
+
+	gotSessions, err := service.List(t.Context(), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, wantSessions, gotSessions)
+}
+
+func TestService_Rotate(t *testing.T) {
+	user := &models.User{Model: gorm.Model{ID: 1}}
+
+	storedToken := models.RefreshToken{
+		Model:     gorm.Model{ID: 10},
+		UserID:    1,
+		FamilyID:  "family-id",
+		TokenHash: hash("refresh-token"),
+	}
+
+	storedSession := models.Session{ID: "family-id", UserID: 1}
+
+	expectSession := func(mocks serviceMocks, session models.Session, err error) {
+		mocks.sessionRepository.
+			EXPECT().
+			GetByID(gomock.Any(), "family-id").
+			Return(session, err)
+	}
+
+	t.Run("It should return ErrInvalidAuthToken when refresh token is unknown", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(models.RefreshToken{}, models.ErrRefreshTokenNotFound)
+
+		_, _, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should return ErrInvalidAuthToken when refresh token belongs to another user", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(storedToken, nil)
+
+		_, _, err := service.Rotate(t.Context(), &models.User{Model: gorm.Model{ID: 2}}, "refresh-token", scopes)
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should return ErrInvalidAuthToken when refresh token family is revoked", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		revokedToken := storedToken
+		revokedToken.RevokedAt = &currentTime
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(revokedToken, nil)
+
+		_, _, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should revoke the family when refresh token has already been used", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		usedToken := storedToken
+		usedToken.UsedAt = &currentTime
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(usedToken, nil)
+
+		expectSession(mocks, storedSession, nil)
+
+		mocks.sessionRepository.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "family-id", currentTime).
+			Return(nil)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			RevokeFamily(gomock.Any(), "family-id", currentTime).
+			Return(nil)
+
+		_, _, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+	})
+
+	t.Run("It should revoke the family when refresh token was rotated concurrently", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(storedToken, nil)
+
+		expectSession(mocks, storedSession, nil)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			MarkUsed(gomock.Any(), uint(10), currentTime).
+			Return(models.ErrRefreshTokenReused)
+
+		mocks.sessionRepository.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "family-id", currentTime).
+			Return(nil)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			RevokeFamily(gomock.Any(), "family-id", currentTime).
+			Return(nil)
+
+		_, _, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+	})
+
+	t.Run("It should propagate an error from repository", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		repositoryErr := errors.New("repository error")
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(models.RefreshToken{}, repositoryErr)
+
+		_, _, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		assert.ErrorIs(t, err, repositoryErr)
+	})
+
+	t.Run("It should return ErrInvalidAuthToken when session is unknown", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(storedToken, nil)
+
+		expectSession(mocks, models.Session{}, models.ErrSessionNotFound)
+
+		_, _, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should return ErrInvalidAuthToken when session is revoked", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		revokedSession := storedSession
+		revokedSession.RevokedAt = &currentTime
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(storedToken, nil)
+
+		expectSession(mocks, revokedSession, nil)
+
+		_, _, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		assert.ErrorIs(t, err, models.ErrInvalidAuthToken)
+	})
+
+	t.Run("It should rotate refresh token within the same family", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(storedToken, nil)
+
+		expectSession(mocks, storedSession, nil)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			MarkUsed(gomock.Any(), uint(10), currentTime).
+			Return(nil)
+
+		mocks.tokenService.
+			EXPECT().
+			CreateRefreshToken(gomock.Any(), user, scopes).
+			Return("new-refresh-token", int64(1000), nil)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			Create(gomock.Any(), &models.RefreshToken{
+				UserID:    1,
+				FamilyID:  "family-id",
+				TokenHash: hash("new-refresh-token"),
+				ExpiresAt: time.Unix(1000, 0),
+			}).
+			Return(nil)
+
+		mocks.sessionRepository.
+			EXPECT().
+			Touch(gomock.Any(), "family-id", currentTime, time.Unix(1000, 0)).
+			Return(nil)
+
+		refreshToken, sessionID, err := service.Rotate(t.Context(), user, "refresh-token", scopes)
+		require.NoError(t, err)
+
+		assert.Equal(t, "new-refresh-token", refreshToken)
+		assert.Equal(t, "family-id", sessionID)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	t.Run("It should revoke session and its refresh tokens", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.sessionRepository.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "family-id", currentTime).
+			Return(nil)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			RevokeUserFamily(gomock.Any(), uint(1), "family-id", currentTime).
+			Return(nil)
+
+		err := service.Revoke(t.Context(), 1, "family-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("It should return ErrSessionNotFound when session doesn't exist", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.sessionRepository.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "family-id", currentTime).
+			Return(models.ErrSessionNotFound)
+
+		err := service.Revoke(t.Context(), 1, "family-id")
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+	})
+}
+
+func TestService_RevokeAll(t *testing.T) {
+	service, mocks := newService(t)
+
+	mocks.sessionRepository.
+		EXPECT().
+		RevokeByUser(gomock.Any(), uint(1), currentTime).
+		Return(nil)
+
+	mocks.refreshTokenRepository.
+		EXPECT().
+		RevokeByUser(gomock.Any(), uint(1), currentTime).
+		Return(nil)
+
+	err := service.RevokeAll(t.Context(), 1)
+	require.NoError(t, err)
+}
+
+func TestService_RevokeOthers(t *testing.T) {
+	service, mocks := newService(t)
+
+	mocks.sessionRepository.
+		EXPECT().
+		RevokeByUserExcept(gomock.Any(), uint(1), "family-id", currentTime).
+		Return(nil)
+
+	mocks.refreshTokenRepository.
+		EXPECT().
+		RevokeByUserExcept(gomock.Any(), uint(1), "family-id", currentTime).
+		Return(nil)
+
+	err := service.RevokeOthers(t.Context(), 1, "family-id")
+	require.NoError(t, err)
+}
+
+func TestService_IsActive(t *testing.T) {
+	usedAt := currentTime.Add(-time.Minute)
+	activeToken := models.RefreshToken{UserID: 1, FamilyID: "family-id", ExpiresAt: currentTime.Add(time.Hour)}
+
+	testCases := map[string]struct {
+		storedToken    models.RefreshToken
+		storedTokenErr error
+		session        *models.Session
+		sessionErr     error
+		wantActive     bool
+	}{
+		"It should report active refresh token": {
+			storedToken: activeToken,
+			session:     &models.Session{ID: "family-id"},
+			wantActive:  true,
+		},
+		"It should report unknown refresh token as inactive": {
+			storedTokenErr: models.ErrRefreshTokenNotFound,
+		},
+		"It should report used refresh token as inactive": {
+			storedToken: models.RefreshToken{UserID: 1, FamilyID: "family-id", ExpiresAt: currentTime.Add(time.Hour), UsedAt: &usedAt},
+		},
+		"It should report expired refresh token as inactive": {
+			storedToken: models.RefreshToken{UserID: 1, FamilyID: "family-id", ExpiresAt: currentTime},
+		},
+		"It should report refresh token of revoked session as inactive": {
+			storedToken: activeToken,
+			session:     &models.Session{ID: "family-id", RevokedAt: &usedAt},
+		},
+		"It should report refresh token of missing session as inactive": {
+			storedToken: activeToken,
+			sessionErr:  models.ErrSessionNotFound,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mocks := newService(t)
+
+			mocks.refreshTokenRepository.
+				EXPECT().
+				GetByHash(gomock.Any(), hash("refresh-token")).
+				Return(testCase.storedToken, testCase.storedTokenErr)
+
+			if testCase.session != nil || testCase.sessionErr != nil {
+				var session models.Session
+				if testCase.session != nil {
+					session = *testCase.session
+				}
+
+				mocks.sessionRepository.
+					EXPECT().
+					GetByID(gomock.Any(), "family-id").
+					Return(session, testCase.sessionErr)
+			}
+
+			active, err := service.IsActive(t.Context(), "refresh-token")
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantActive, active)
+		})
+	}
+}
+
+func TestService_RevokeByRefreshToken(t *testing.T) {
+	t.Run("It should revoke session of the refresh token", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(models.RefreshToken{UserID: 1, FamilyID: "family-id"}, nil)
+
+		mocks.sessionRepository.
+			EXPECT().
+			Revoke(gomock.Any(), uint(1), "family-id", currentTime).
+			Return(nil)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			RevokeFamily(gomock.Any(), "family-id", currentTime).
+			Return(nil)
+
+		err := service.RevokeByRefreshToken(t.Context(), "refresh-token")
+		require.NoError(t, err)
+	})
+
+	t.Run("It should return ErrRefreshTokenNotFound when refresh token is unknown", func(t *testing.T) {
+		service, mocks := newService(t)
+
+		mocks.refreshTokenRepository.
+			EXPECT().
+			GetByHash(gomock.Any(), hash("refresh-token")).
+			Return(models.RefreshToken{}, models.ErrRefreshTokenNotFound)
+
+		err := service.RevokeByRefreshToken(t.Context(), "refresh-token")
+		assert.ErrorIs(t, err, models.ErrRefreshTokenNotFound)
+	})
+}
+
+func TestService_IsRevoked(t *testing.T) {
+	revokedAt := currentTime.Add(-time.Minute)
+
+	testCases := map[string]struct {
+		session     models.Session
+		sessionErr  error
+		wantRevoked bool
+	}{
+		"It should report active session": {
+			session: models.Session{ID: "family-id"},
+		},
+		"It should report revoked session": {
+			session:     models.Session{ID: "family-id", RevokedAt: &revokedAt},
+			wantRevoked: true,
+		},
+		"It should report unknown session as revoked": {
+			sessionErr:  models.ErrSessionNotFound,
+			wantRevoked: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, mocks := newService(t)
+
+			mocks.sessionRepository.
+				EXPECT().
+				GetByID(gomock.Any(), "family-id").
+				Return(testCase.session, testCase.sessionErr)
+
+			revoked, err := service.IsRevoked(t.Context(), "family-id")
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.wantRevoked, revoked)
+		})
+	}
+}
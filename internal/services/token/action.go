@@ -0,0 +1,174 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=action_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type JwtActionClaims struct {
+	Type TokenType `json:"typ"`
+
+	ID      uint                 `json:"id"`
+	Purpose models.ActionPurpose `json:"purpose"`
+
+	// Email is the address of the user the token was issued for,
+	// so the token is rejected for the user whose email has changed since.
+	Email string `json:"email"`
+
+	// NewEmail is the address the user asked to switch to, set for [models.ActionChangeEmail] tokens only.
+	NewEmail string `json:"new_email,omitempty"`
+
+	// Scope is a space-separated list of scopes to grant after the second factor,
+	// set for [models.ActionMFALogin] tokens only.
+	Scope string `json:"scope,omitempty"`
+
+	// Provider is the first factor the user has passed, set for [models.ActionMFALogin] tokens only,
+	// so that the session records how it was started.
+	Provider string `json:"provider,omitempty"`
+
+	jwt.RegisteredClaims
+}
+
+// Scopes returns scopes to grant after the second factor.
+func (c *JwtActionClaims) Scopes() models.Scopes {
+	return models.ParseScopes(c.Scope)
+}
+
+// Validate rejects tokens of other kinds, see [jwt.ClaimsValidator].
+func (c *JwtActionClaims) Validate() error {
+	return validateType(c.Type, TypeAction)
+}
+
+type usedActionTokenRepository interface {
+	MarkUsed(ctx context.Context, tokenID string, expiresAt time.Time) error
+}
+
+// ActionService issues signed single-use tokens confirming actions out of band, e.g. by following an emailed link.
+type ActionService struct {
+	now                       func() time.Time
+	newUUID                   func() (uuid.UUID, error)
+	keys                      *Keyring
+	usedActionTokenRepository usedActionTokenRepository
+}
+
+func NewActionService(
+	now func() time.Time,
+	newUUID func() (uuid.UUID, error),
+	keys *Keyring,
+	usedActionTokenRepository usedActionTokenRepository,
+) *ActionService {
+	return &ActionService{
+		now:                       now,
+		newUUID:                   newUUID,
+		keys:                      keys,
+		usedActionTokenRepository: usedActionTokenRepository,
+	}
+}
+
+// Create creates a token confirming the action for the user, valid for the duration.
+func (s *ActionService) Create(
+	_ context.Context,
+	user *models.User,
+	purpose models.ActionPurpose,
+	duration time.Duration,
+) (string, error) {
+	return s.create(&JwtActionClaims{ID: user.ID, Purpose: purpose, Email: user.Email}, duration)
+}
+
+// CreateEmailChange creates a token confirming the change of the user email to the new one, valid for the duration.
+func (s *ActionService) CreateEmailChange(
+	_ context.Context,
+	user *models.User,
+	newEmail string,
+	duration time.Duration,
+) (string, error) {
+	claims := &JwtActionClaims{
+		ID:       user.ID,
+		Purpose:  models.ActionChangeEmail,
+		Email:    user.Email,
+		NewEmail: newEmail,
+	}
+
+	return s.create(claims, duration)
+}
+
+// CreateMFAChallenge creates a token proving the user has passed the first factor with the provider,
+// valid for the duration. It is exchanged for a session with the scopes once the second factor is verified.
+func (s *ActionService) CreateMFAChallenge(
+	_ context.Context,
+	user *models.User,
+	scopes models.Scopes,
+	provider string,
+	duration time.Duration,
+) (string, error) {
+	claims := &JwtActionClaims{
+		ID:       user.ID,
+		Purpose:  models.ActionMFALogin,
+		Email:    user.Email,
+		Scope:    scopes.String(),
+		Provider: provider,
+	}
+
+	return s.create(claims, duration)
+}
+
+func (s *ActionService) create(claims *JwtActionClaims, duration time.Duration) (string, error) {
+	tokenID, err := s.newUUID()
+	if err != nil {
+		return "", fmt.Errorf("new action token id: %w", err)
+	}
+
+	claims.Type = TypeAction
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        tokenID.String(),
+		ExpiresAt: jwt.NewNumericDate(s.now().Add(duration)),
+	}
+
+	actionToken, err := sign(s.keys.Current(), claims)
+	if err != nil {
+		return "", fmt.Errorf("sign action token: %w", err)
+	}
+
+	return actionToken, nil
+}
+
+// Consume verifies the token issued for the purpose and marks it as used.
+// It returns [models.ErrInvalidActionToken] when the token is invalid, expired, issued for another purpose
+// or already used.
+func (s *ActionService) Consume(
+	ctx context.Context,
+	purpose models.ActionPurpose,
+	actionToken string,
+) (*JwtActionClaims, error) {
+	claims := new(JwtActionClaims)
+
+	_, err := jwt.ParseWithClaims(
+		actionToken,
+		claims,
+		func(t *jwt.Token) (any, error) { return lookupVerifyKey(s.keys, t) },
+		jwt.WithTimeFunc(s.now),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, errors.Join(models.ErrInvalidActionToken, fmt.Errorf("parse action token: %w", err))
+	}
+
+	if claims.Purpose != purpose {
+		return nil, fmt.Errorf("%w: issued for %s", models.ErrInvalidActionToken, claims.Purpose)
+	}
+
+	if err := s.usedActionTokenRepository.MarkUsed(ctx, claims.RegisteredClaims.ID, claims.ExpiresAt.Time); err != nil {
+		return nil, fmt.Errorf("mark action token used: %w", err)
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: action.go
+//
+// Generated by this command:
+//
+//	mockgen -source=action.go -destination=action_mock_test.go -package=token_test -typed=true
+//
+
+// Package token_test is a generated GoMock package.
+package token_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockusedActionTokenRepository is a mock of usedActionTokenRepository interface.
+type MockusedActionTokenRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockusedActionTokenRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockusedActionTokenRepositoryMockRecorder is the mock recorder for MockusedActionTokenRepository.
+type MockusedActionTokenRepositoryMockRecorder struct {
+	mock *MockusedActionTokenRepository
+}
+
+// NewMockusedActionTokenRepository creates a new mock instance.
+func NewMockusedActionTokenRepository(ctrl *gomock.Controller) *MockusedActionTokenRepository {
+	mock := &MockusedActionTokenRepository{ctrl: ctrl}
+	mock.recorder = &MockusedActionTokenRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockusedActionTokenRepository) EXPECT() *MockusedActionTokenRepositoryMockRecorder {
+	return m.recorder
+}
+
+// MarkUsed mocks base method.
+func (m *MockusedActionTokenRepository) MarkUsed(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkUsed", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkUsed indicates an expected call of MarkUsed.
+func (mr *MockusedActionTokenRepositoryMockRecorder) MarkUsed(ctx, tokenID, expiresAt any) *MockusedActionTokenRepositoryMarkUsedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUsed", reflect.TypeOf((*MockusedActionTokenRepository)(nil).MarkUsed), ctx, tokenID, expiresAt)
+	return &MockusedActionTokenRepositoryMarkUsedCall{Call: call}
+}
+
+// MockusedActionTokenRepositoryMarkUsedCall wrap *gomock.Call
+type MockusedActionTokenRepositoryMarkUsedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockusedActionTokenRepositoryMarkUsedCall) Return(arg0 error) *MockusedActionTokenRepositoryMarkUsedCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockusedActionTokenRepositoryMarkUsedCall) Do(f func(context.Context, string, time.Time) error) *MockusedActionTokenRepositoryMarkUsedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockusedActionTokenRepositoryMarkUsedCall) DoAndReturn(f func(context.Context, string, time.Time) error) *MockusedActionTokenRepositoryMarkUsedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,169 @@
+package token_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func TestActionService(t *testing.T) {
+	currentTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := func() time.Time { return currentTime }
+
+	tokenID := uuid.MustParse("01964b3c-1f5e-7d2a-9c4b-2f8e5a6d7c10")
+	newUUID := func() (uuid.UUID, error) { return tokenID, nil }
+
+	user := &models.User{
+		Model: gorm.Model{ID: 100},
+		Email: "example@email.com",
+	}
+
+	currentKey := token.NewHMACSigningKey([]byte("current"))
+	retiredKey := token.NewHMACSigningKey([]byte("retired"))
+
+	newService := func(t *testing.T, keys *token.Keyring) (*token.ActionService, *MockusedActionTokenRepository) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		usedActionTokenRepository := NewMockusedActionTokenRepository(ctrl)
+
+		return token.NewActionService(now, newUUID, keys, usedActionTokenRepository), usedActionTokenRepository
+	}
+
+	t.Run("It should consume action token", func(t *testing.T) {
+		service, usedActionTokenRepository := newService(t, newKeyring(t, currentKey))
+
+		actionToken, err := service.Create(t.Context(), user, models.ActionVerifyEmail, time.Hour)
+		require.NoError(t, err)
+
+		usedActionTokenRepository.EXPECT().
+			MarkUsed(gomock.Any(), tokenID.String(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, expiresAt time.Time) error {
+				assert.True(t, currentTime.Add(time.Hour).Equal(expiresAt))
+				return nil
+			})
+
+		claims, err := service.Consume(t.Context(), models.ActionVerifyEmail, actionToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, user.ID, claims.ID)
+		assert.Equal(t, user.Email, claims.Email)
+		assert.Equal(t, models.ActionVerifyEmail, claims.Purpose)
+	})
+
+	t.Run("It should consume email change token", func(t *testing.T) {
+		service, usedActionTokenRepository := newService(t, newKeyring(t, currentKey))
+
+		actionToken, err := service.CreateEmailChange(t.Context(), user, "new@email.com", time.Hour)
+		require.NoError(t, err)
+
+		usedActionTokenRepository.EXPECT().MarkUsed(gomock.Any(), tokenID.String(), gomock.Any()).Return(nil)
+
+		claims, err := service.Consume(t.Context(), models.ActionChangeEmail, actionToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, user.ID, claims.ID)
+		assert.Equal(t, user.Email, claims.Email)
+		assert.Equal(t, "new@email.com", claims.NewEmail)
+	})
+
+	t.Run("It should consume mfa challenge token", func(t *testing.T) {
+		service, usedActionTokenRepository := newService(t, newKeyring(t, currentKey))
+
+		scopes := models.Scopes{models.ScopePostsRead}
+
+		actionToken, err := service.CreateMFAChallenge(t.Context(), user, scopes, models.LoginProviderMagicLink, time.Hour)
+		require.NoError(t, err)
+
+		usedActionTokenRepository.EXPECT().MarkUsed(gomock.Any(), tokenID.String(), gomock.Any()).Return(nil)
+
+		claims, err := service.Consume(t.Context(), models.ActionMFALogin, actionToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, user.ID, claims.ID)
+		assert.Equal(t, scopes, claims.Scopes())
+		assert.Equal(t, models.LoginProviderMagicLink, claims.Provider)
+	})
+
+	t.Run("It should consume action token signed with retired key", func(t *testing.T) {
+		oldService, _ := newService(t, newKeyring(t, retiredKey))
+
+		actionToken, err := oldService.Create(t.Context(), user, models.ActionVerifyEmail, time.Hour)
+		require.NoError(t, err)
+
+		service, usedActionTokenRepository := newService(t, newKeyring(t, currentKey, retiredKey))
+
+		usedActionTokenRepository.EXPECT().MarkUsed(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		_, err = service.Consume(t.Context(), models.ActionVerifyEmail, actionToken)
+		require.NoError(t, err)
+	})
+
+	t.Run("It should reject used action token", func(t *testing.T) {
+		service, usedActionTokenRepository := newService(t, newKeyring(t, currentKey))
+
+		actionToken, err := service.Create(t.Context(), user, models.ActionVerifyEmail, time.Hour)
+		require.NoError(t, err)
+
+		usedActionTokenRepository.EXPECT().
+			MarkUsed(gomock.Any(), tokenID.String(), gomock.Any()).
+			Return(models.ErrInvalidActionToken)
+
+		_, err = service.Consume(t.Context(), models.ActionVerifyEmail, actionToken)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should reject action token issued for another purpose", func(t *testing.T) {
+		service, _ := newService(t, newKeyring(t, currentKey))
+
+		actionToken, err := service.Create(t.Context(), user, "another_purpose", time.Hour)
+		require.NoError(t, err)
+
+		_, err = service.Consume(t.Context(), models.ActionVerifyEmail, actionToken)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should reject expired action token", func(t *testing.T) {
+		service, _ := newService(t, newKeyring(t, currentKey))
+
+		actionToken, err := service.Create(t.Context(), user, models.ActionVerifyEmail, -time.Minute)
+		require.NoError(t, err)
+
+		_, err = service.Consume(t.Context(), models.ActionVerifyEmail, actionToken)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should reject access token signed with the same key", func(t *testing.T) {
+		keys := newKeyring(t, currentKey)
+		tokenService := token.NewService(now, newUUID, time.Minute, time.Minute, keys, keys)
+
+		accessToken, _, err := tokenService.CreateAccessToken(t.Context(), user, "session-id", models.AllScopes())
+		require.NoError(t, err)
+
+		service, _ := newService(t, keys)
+
+		_, err = service.Consume(t.Context(), models.ActionMFALogin, accessToken)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should reject action token signed with unknown key", func(t *testing.T) {
+		anotherService, _ := newService(t, newKeyring(t, token.NewHMACSigningKey([]byte("another"))))
+
+		actionToken, err := anotherService.Create(t.Context(), user, models.ActionVerifyEmail, time.Hour)
+		require.NoError(t, err)
+
+		service, _ := newService(t, newKeyring(t, currentKey))
+
+		_, err = service.Consume(t.Context(), models.ActionVerifyEmail, actionToken)
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+}
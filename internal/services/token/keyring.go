@@ -0,0 +1,106 @@
+package token
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Keyring holds the current signing key and retired keys that are still accepted for verification.
+//
+// It allows rotating keys without invalidating issued tokens: a new key becomes current,
+// and the previous one stays in the keyring as retired until tokens signed with it expire.
+// Keys are selected by the "kid" token header. Keyring is safe for concurrent use and its keys
+// can be replaced at runtime.
+type Keyring struct {
+	mu      sync.RWMutex
+	current SigningKey
+	keys    map[string]SigningKey
+}
+
+// NewKeyring creates a keyring which signs tokens with the current key.
+func NewKeyring(current SigningKey, retired ...SigningKey) (*Keyring, error) {
+	keyring := new(Keyring)
+	if err := keyring.Replace(current, retired...); err != nil {
+		return nil, err
+	}
+
+	return keyring, nil
+}
+
+// Replace atomically replaces all keys of the keyring.
+func (k *Keyring) Replace(current SigningKey, retired ...SigningKey) error {
+	if !current.CanSign() {
+		return fmt.Errorf("current key %s can't sign tokens", current.ID)
+	}
+
+	keys := make(map[string]SigningKey, len(retired)+1)
+	for _, key := range retired {
+		keys[key.ID] = key
+	}
+
+	keys[current.ID] = current
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.current = current
+	k.keys = keys
+
+	return nil
+}
+
+// ReplaceWith atomically replaces all keys of the keyring with the keys of the other keyring.
+// Unlike [Keyring.Replace] it can't fail, so several keyrings can be built first and then replaced together.
+func (k *Keyring) ReplaceWith(other *Keyring) {
+	other.mu.RLock()
+	current, keys := other.current, other.keys
+	other.mu.RUnlock()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.current = current
+	k.keys = keys
+}
+
+// Current returns the key to sign new tokens with.
+func (k *Keyring) Current() SigningKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.current
+}
+
+// Lookup returns a current or retired key by its ID.
+func (k *Keyring) Lookup(id string) (SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	key, ok := k.keys[id]
+
+	return key, ok
+}
+
+// PublicKeys returns public parts of all asymmetric keys in the keyring.
+func (k *Keyring) PublicKeys() []JWK {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	publicKeys := make([]JWK, 0, len(k.keys))
+
+	// The current key goes first, so clients which take the first key pick the right one.
+	if k.current.publicJWK != nil {
+		publicKeys = append(publicKeys, *k.current.publicJWK)
+	}
+
+	ids := slices.Sorted(maps.Keys(k.keys))
+	for _, id := range ids {
+		if key := k.keys[id]; id != k.current.ID && key.publicJWK != nil {
+			publicKeys = append(publicKeys, *key.publicJWK)
+		}
+	}
+
+	return publicKeys
+}
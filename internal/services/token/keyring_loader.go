@@ -0,0 +1,147 @@
+package token
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/config"
+)
+
+// MinSecretLength is the minimum length of HMAC secrets: a shorter secret can be brute-forced to forge tokens.
+const MinSecretLength = 32
+
+// LoadAccessKeys loads the current and retired access token keys from the config.
+func LoadAccessKeys(cfg config.AuthConfig) (current SigningKey, retired []SigningKey, err error) {
+	if cfg.AccessSigningAlgorithm == AlgorithmHS256 {
+		current, err = loadSecret("ACCESS_SECRET", cfg.AccessSecret)
+		if err != nil {
+			return SigningKey{}, nil, err
+		}
+	} else {
+		current, err = loadKeyFile(cfg.AccessSigningAlgorithm, cfg.AccessPrivateKeyFile, ParseSigningKeyPEM)
+		if err != nil {
+			return SigningKey{}, nil, fmt.Errorf("load current access key: %w", err)
+		}
+	}
+
+	retired, err = loadRetiredSecrets("ACCESS_RETIRED_SECRETS", cfg.AccessRetiredSecrets)
+	if err != nil {
+		return SigningKey{}, nil, err
+	}
+
+	for _, keyFile := range cfg.AccessRetiredKeyFiles {
+		// Retired key files are configured as "<algorithm>:<path>", because the algorithm may change between rotations.
+		algorithm, path, ok := strings.Cut(keyFile, ":")
+		if !ok {
+			return SigningKey{}, nil, fmt.Errorf("retired access key file %q must be in <algorithm>:<path> format", keyFile)
+		}
+
+		key, err := loadKeyFile(algorithm, path, ParseVerificationKeyPEM)
+		if err != nil {
+			return SigningKey{}, nil, fmt.Errorf("load retired access key: %w", err)
+		}
+
+		retired = append(retired, key)
+	}
+
+	return current, retired, nil
+}
+
+// LoadRefreshKeys loads the current and retired refresh token keys from the config.
+func LoadRefreshKeys(cfg config.AuthConfig) (current SigningKey, retired []SigningKey, err error) {
+	current, err = loadSecret("REFRESH_SECRET", cfg.RefreshSecret)
+	if err != nil {
+		return SigningKey{}, nil, err
+	}
+
+	retired, err = loadRetiredSecrets("REFRESH_RETIRED_SECRETS", cfg.RefreshRetiredSecrets)
+	if err != nil {
+		return SigningKey{}, nil, err
+	}
+
+	return current, retired, nil
+}
+
+// LoadActionKeys loads the current and retired action token keys from the config.
+func LoadActionKeys(cfg config.AuthConfig) (current SigningKey, retired []SigningKey, err error) {
+	current, err = loadSecret("ACTION_SECRET", cfg.ActionSecret)
+	if err != nil {
+		return SigningKey{}, nil, err
+	}
+
+	retired, err = loadRetiredSecrets("ACTION_RETIRED_SECRETS", cfg.ActionRetiredSecrets)
+	if err != nil {
+		return SigningKey{}, nil, err
+	}
+
+	return current, retired, nil
+}
+
+// CheckSecretsDistinct returns an error when access, refresh and action keys share a secret,
+// current or retired, so that a leaked or guessed key of one kind can't be used to sign tokens of another.
+func CheckSecretsDistinct(cfg config.AuthConfig) error {
+	accessSecrets := cfg.AccessRetiredSecrets
+	if cfg.AccessSigningAlgorithm == AlgorithmHS256 {
+		accessSecrets = append([]string{cfg.AccessSecret}, accessSecrets...)
+	}
+
+	secretsByName := []struct {
+		name    string
+		secrets []string
+	}{
+		{name: "access", secrets: accessSecrets},
+		{name: "refresh", secrets: append([]string{cfg.RefreshSecret}, cfg.RefreshRetiredSecrets...)},
+		{name: "action", secrets: append([]string{cfg.ActionSecret}, cfg.ActionRetiredSecrets...)},
+	}
+
+	names := make(map[string]string)
+	for _, kind := range secretsByName {
+		for _, secret := range kind.secrets {
+			if name, ok := names[secret]; ok && name != kind.name {
+				return fmt.Errorf("%s and %s token secrets must be different", name, kind.name)
+			}
+
+			names[secret] = kind.name
+		}
+	}
+
+	return nil
+}
+
+// loadSecret returns the HMAC key for the secret, rejecting a secret anyone could guess, including an unset one.
+func loadSecret(name, secret string) (SigningKey, error) {
+	if len(secret) < MinSecretLength {
+		return SigningKey{}, fmt.Errorf("%s must be at least %d characters long", name, MinSecretLength)
+	}
+
+	return NewHMACSigningKey([]byte(secret)), nil
+}
+
+func loadRetiredSecrets(name string, secrets []string) ([]SigningKey, error) {
+	retired := make([]SigningKey, 0, len(secrets))
+	for _, secret := range secrets {
+		key, err := loadSecret(name, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		retired = append(retired, key)
+	}
+
+	return retired, nil
+}
+
+func loadKeyFile(algorithm, path string, parse func(algorithm string, keyPEM []byte) (SigningKey, error)) (SigningKey, error) {
+	keyPEM, err := os.ReadFile(path)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("read key file: %w", err)
+	}
+
+	key, err := parse(algorithm, keyPEM)
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("parse key file %s: %w", path, err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,116 @@
+package token_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/config"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeys(t *testing.T) {
+	secret := strings.Repeat("s", token.MinSecretLength)
+
+	validConfig := config.AuthConfig{
+		AccessSecret:           secret,
+		AccessSigningAlgorithm: token.AlgorithmHS256,
+		RefreshSecret:          secret,
+		ActionSecret:           secret,
+	}
+
+	loaders := map[string]func(cfg config.AuthConfig) (token.SigningKey, []token.SigningKey, error){
+		"access":  token.LoadAccessKeys,
+		"refresh": token.LoadRefreshKeys,
+		"action":  token.LoadActionKeys,
+	}
+
+	for name, load := range loaders {
+		t.Run("It should load "+name+" keys", func(t *testing.T) {
+			current, retired, err := load(validConfig)
+			require.NoError(t, err)
+
+			assert.True(t, current.CanSign())
+			assert.Empty(t, retired)
+		})
+	}
+
+	testCases := map[string]struct {
+		load   func(cfg config.AuthConfig) (token.SigningKey, []token.SigningKey, error)
+		modify func(cfg *config.AuthConfig)
+	}{
+		"It should reject empty access secret": {
+			load:   token.LoadAccessKeys,
+			modify: func(cfg *config.AuthConfig) { cfg.AccessSecret = "" },
+		},
+		"It should reject short refresh secret": {
+			load:   token.LoadRefreshKeys,
+			modify: func(cfg *config.AuthConfig) { cfg.RefreshSecret = "refresh_secret" },
+		},
+		"It should reject empty action secret": {
+			load:   token.LoadActionKeys,
+			modify: func(cfg *config.AuthConfig) { cfg.ActionSecret = "" },
+		},
+		"It should reject short retired action secret": {
+			load:   token.LoadActionKeys,
+			modify: func(cfg *config.AuthConfig) { cfg.ActionRetiredSecrets = []string{"action_secret"} },
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			cfg := validConfig
+			testCase.modify(&cfg)
+
+			_, _, err := testCase.load(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCheckSecretsDistinct(t *testing.T) {
+	validConfig := config.AuthConfig{
+		AccessSecret:           strings.Repeat("a", token.MinSecretLength),
+		AccessSigningAlgorithm: token.AlgorithmHS256,
+		RefreshSecret:          strings.Repeat("r", token.MinSecretLength),
+		ActionSecret:           strings.Repeat("s", token.MinSecretLength),
+	}
+
+	t.Run("It should accept distinct secrets", func(t *testing.T) {
+		cfg := validConfig
+		cfg.ActionRetiredSecrets = []string{validConfig.ActionSecret}
+
+		assert.NoError(t, token.CheckSecretsDistinct(cfg))
+	})
+
+	t.Run("It should ignore access secret when access tokens are signed with a private key", func(t *testing.T) {
+		cfg := validConfig
+		cfg.AccessSigningAlgorithm = token.AlgorithmES256
+		cfg.AccessSecret = validConfig.RefreshSecret
+
+		assert.NoError(t, token.CheckSecretsDistinct(cfg))
+	})
+
+	testCases := map[string]func(cfg *config.AuthConfig){
+		"It should reject the same access and refresh secrets": func(cfg *config.AuthConfig) {
+			cfg.RefreshSecret = cfg.AccessSecret
+		},
+		"It should reject the same refresh and action secrets": func(cfg *config.AuthConfig) {
+			cfg.ActionSecret = cfg.RefreshSecret
+		},
+		"It should reject retired action secret equal to access secret": func(cfg *config.AuthConfig) {
+			cfg.ActionRetiredSecrets = []string{cfg.AccessSecret}
+		},
+	}
+
+	for testName, modify := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			cfg := validConfig
+			modify(&cfg)
+
+			assert.Error(t, token.CheckSecretsDistinct(cfg))
+		})
+	}
+}
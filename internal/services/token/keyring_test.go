@@ -0,0 +1,157 @@
+package token_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newKeyring(t *testing.T, current token.SigningKey, retired ...token.SigningKey) *token.Keyring {
+	t.Helper()
+
+	keyring, err := token.NewKeyring(current, retired...)
+	require.NoError(t, err)
+
+	return keyring
+}
+
+func newECDSASigningKey(t *testing.T) (token.SigningKey, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signingKey, err := token.ParseSigningKeyPEM(token.AlgorithmES256, encodePrivateKeyPEM(t, privateKey))
+	require.NoError(t, err)
+
+	return signingKey, privateKey
+}
+
+func TestKeyring(t *testing.T) {
+	user := &models.User{Model: gorm.Model{ID: 123}, Name: "name"}
+
+	newTokenService := func(accessKeys *token.Keyring) *token.Service {
+		return token.NewService(
+			time.Now,
+			uuid.NewRandom,
+			time.Minute,
+			time.Minute,
+			accessKeys,
+			newKeyring(t, token.NewHMACSigningKey([]byte("refresh-secret"))),
+		)
+	}
+
+	t.Run("It should accept tokens signed with a retired key", func(t *testing.T) {
+		oldKey, _ := newECDSASigningKey(t)
+		newKey, _ := newECDSASigningKey(t)
+
+		accessKeys := newKeyring(t, oldKey)
+		service := newTokenService(accessKeys)
+
+		accessToken, _, err := service.CreateAccessToken(t.Context(), user, "session-id", models.AllScopes())
+		require.NoError(t, err)
+
+		require.NoError(t, accessKeys.Replace(newKey, oldKey))
+
+		claims, err := service.ParseAccessToken(t.Context(), accessToken)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, claims.ID)
+
+		newAccessToken, _, err := service.CreateAccessToken(t.Context(), user, "session-id", models.AllScopes())
+		require.NoError(t, err)
+
+		parsedToken, _, err := jwt.NewParser().ParseUnverified(newAccessToken, new(token.JwtCustomClaims))
+		require.NoError(t, err)
+		assert.Equal(t, newKey.ID, parsedToken.Header["kid"])
+	})
+
+	t.Run("It should reject tokens signed with a removed key", func(t *testing.T) {
+		oldKey, _ := newECDSASigningKey(t)
+		newKey, _ := newECDSASigningKey(t)
+
+		accessKeys := newKeyring(t, oldKey)
+		service := newTokenService(accessKeys)
+
+		accessToken, _, err := service.CreateAccessToken(t.Context(), user, "session-id", models.AllScopes())
+		require.NoError(t, err)
+
+		require.NoError(t, accessKeys.Replace(newKey))
+
+		_, err = service.ParseAccessToken(t.Context(), accessToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("It should replace keys with keys of another keyring", func(t *testing.T) {
+		oldKey, _ := newECDSASigningKey(t)
+		newKey, _ := newECDSASigningKey(t)
+
+		accessKeys := newKeyring(t, oldKey)
+		accessKeys.ReplaceWith(newKeyring(t, newKey))
+
+		assert.Equal(t, newKey.ID, accessKeys.Current().ID)
+
+		_, ok := accessKeys.Lookup(oldKey.ID)
+		assert.False(t, ok)
+	})
+
+	t.Run("It should publish current and retired keys in JWKS", func(t *testing.T) {
+		oldKey, _ := newECDSASigningKey(t)
+		newKey, _ := newECDSASigningKey(t)
+
+		keySet := newTokenService(newKeyring(t, newKey, oldKey)).JWKS()
+		require.Len(t, keySet.Keys, 2)
+		assert.Equal(t, newKey.ID, keySet.Keys[0].ID)
+		assert.Equal(t, oldKey.ID, keySet.Keys[1].ID)
+	})
+
+	t.Run("It should accept public keys as retired keys", func(t *testing.T) {
+		oldKey, oldPrivateKey := newECDSASigningKey(t)
+		newKey, _ := newECDSASigningKey(t)
+
+		der, err := x509.MarshalPKIXPublicKey(&oldPrivateKey.PublicKey)
+		require.NoError(t, err)
+
+		retiredKey, err := token.ParseVerificationKeyPEM(
+			token.AlgorithmES256,
+			pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		)
+		require.NoError(t, err)
+		assert.Equal(t, oldKey.ID, retiredKey.ID)
+		assert.False(t, retiredKey.CanSign())
+
+		accessToken, _, err := newTokenService(newKeyring(t, oldKey)).CreateAccessToken(t.Context(), user, "session-id", models.AllScopes())
+		require.NoError(t, err)
+
+		_, err = newTokenService(newKeyring(t, newKey, retiredKey)).ParseAccessToken(t.Context(), accessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("It should not allow a public key to be the current key", func(t *testing.T) {
+		_, privateKey := newECDSASigningKey(t)
+
+		der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		require.NoError(t, err)
+
+		publicKey, err := token.ParseVerificationKeyPEM(
+			token.AlgorithmES256,
+			pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		)
+		require.NoError(t, err)
+
+		_, err = token.NewKeyring(publicKey)
+		assert.Error(t, err)
+	})
+}
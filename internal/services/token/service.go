@@ -3,62 +3,198 @@ package token
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 
+	safecast "github.com/ccoveille/go-safecast"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// TokenType is the "typ" claim telling access, refresh and action tokens apart,
+// so that a token of one kind is never accepted as another, even if their keys are misconfigured to be the same.
+type TokenType string
+
+const (
+	TypeAccess  TokenType = "access"
+	TypeRefresh TokenType = "refresh"
+	TypeAction  TokenType = "action"
+)
+
+// validateType is called by [jwt.ClaimsValidator] implementations of the claims, so every parsed token is checked.
+func validateType(got, want TokenType) error {
+	if got != want {
+		return fmt.Errorf("%w: %s token is expected, got %q", jwt.ErrTokenInvalidClaims, want, got)
+	}
+
+	return nil
+}
+
 type JwtCustomClaims struct {
+	Type TokenType `json:"typ"`
+
 	Name string `json:"name"`
 	ID   uint   `json:"id"`
+
+	// SessionID is the refresh token family the access token was issued for.
+	SessionID string `json:"sid,omitempty"`
+
+	Roles models.Roles `json:"roles,omitempty"`
+
+	// Scope is a space-separated list of scopes granted to the token.
+	Scope string `json:"scope,omitempty"`
+
+	// Actor is set when another user acts on behalf of the user, e.g. an admin impersonating the user.
+	Actor *ActorClaims `json:"act,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
+// ActorClaims identify the user acting on behalf of the subject of the token as the "act" claim of RFC 8693.
+type ActorClaims struct {
+	// Subject is the ID of the acting user.
+	Subject string `json:"sub"`
+}
+
+// Scopes returns scopes granted to the token.
+func (c *JwtCustomClaims) Scopes() models.Scopes {
+	return models.ParseScopes(c.Scope)
+}
+
+// Validate rejects tokens of other kinds, see [jwt.ClaimsValidator].
+func (c *JwtCustomClaims) Validate() error {
+	return validateType(c.Type, TypeAccess)
+}
+
+// Impersonated reports whether the token was issued for another user acting on behalf of the user.
+func (c *JwtCustomClaims) Impersonated() bool {
+	return c.Actor != nil
+}
+
+// ImpersonatorID returns the ID of the user acting on behalf of the user, if the token is impersonated.
+func (c *JwtCustomClaims) ImpersonatorID() (uint, bool) {
+	if c.Actor == nil {
+		return 0, false
+	}
+
+	parsedID, err := strconv.ParseUint(c.Actor.Subject, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	id, err := safecast.Convert[uint](parsedID)
+	if err != nil {
+		return 0, false
+	}
+
+	return id, true
+}
+
 type JwtCustomRefreshClaims struct {
+	Type TokenType `json:"typ"`
+
 	ID uint `json:"id"`
+
+	// Scope is a space-separated list of scopes granted to the session.
+	// Access tokens issued with the refresh token may have only a subset of them.
+	Scope string `json:"scope,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
+// Scopes returns scopes granted to the session.
+func (c *JwtCustomRefreshClaims) Scopes() models.Scopes {
+	return models.ParseScopes(c.Scope)
+}
+
+// Validate rejects tokens of other kinds, see [jwt.ClaimsValidator].
+func (c *JwtCustomRefreshClaims) Validate() error {
+	return validateType(c.Type, TypeRefresh)
+}
+
 type Service struct {
 	now                  func() time.Time
+	newUUID              func() (uuid.UUID, error)
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
-	accessTokenSecret    []byte
-	refreshSecret        []byte
+	accessKeys           *Keyring
+	refreshKeys          *Keyring
 }
 
 func NewService(
 	now func() time.Time,
+	newUUID func() (uuid.UUID, error),
 	accessTokenDuration time.Duration,
 	refreshTokenDuration time.Duration,
-	accessSecret []byte,
-	refreshSecret []byte,
+	accessKeys *Keyring,
+	refreshKeys *Keyring,
 ) *Service {
 	return &Service{
 		now:                  now,
+		newUUID:              newUUID,
 		accessTokenDuration:  accessTokenDuration,
 		refreshTokenDuration: refreshTokenDuration,
-		accessTokenSecret:    accessSecret,
-		refreshSecret:        refreshSecret,
+		accessKeys:           accessKeys,
+		refreshKeys:          refreshKeys,
 	}
 }
 
-func (s *Service) CreateAccessToken(_ context.Context, user *models.User) (accessToken string, expires int64, err error) {
-	expiresAt := s.now().Add(s.accessTokenDuration)
+// CreateAccessToken creates an access token with the scopes for the user's session.
+// The token has a unique ID, so it can be revoked before it expires.
+func (s *Service) CreateAccessToken(
+	_ context.Context,
+	user *models.User,
+	sessionID string,
+	scopes models.Scopes,
+) (accessToken string, expires int64, err error) {
+	claims := &JwtCustomClaims{
+		Name:      user.Name,
+		ID:        user.ID,
+		SessionID: sessionID,
+		Roles:     user.Roles,
+		Scope:     scopes.String(),
+	}
+
+	return s.createAccessToken(claims, s.accessTokenDuration)
+}
 
+// CreateImpersonationToken creates an access token with the scopes for the impersonator acting on behalf of the user,
+// valid for the duration. The token isn't tied to a session, so it can't be refreshed.
+func (s *Service) CreateImpersonationToken(
+	_ context.Context,
+	user *models.User,
+	impersonatorID uint,
+	scopes models.Scopes,
+	duration time.Duration,
+) (accessToken string, expires int64, err error) {
 	claims := &JwtCustomClaims{
-		Name: user.Name,
-		ID:   user.ID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
-		},
+		Name:  user.Name,
+		ID:    user.ID,
+		Roles: user.Roles,
+		Scope: scopes.String(),
+		Actor: &ActorClaims{Subject: strconv.FormatUint(uint64(impersonatorID), 10)},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return s.createAccessToken(claims, duration)
+}
+
+func (s *Service) createAccessToken(claims *JwtCustomClaims, duration time.Duration) (string, int64, error) {
+	expiresAt := s.now().Add(duration)
 
-	accessToken, err = token.SignedString(s.accessTokenSecret)
+	tokenID, err := s.newUUID()
+	if err != nil {
+		return "", 0, fmt.Errorf("new access token id: %w", err)
+	}
+
+	claims.Type = TypeAccess
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        tokenID.String(),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	accessToken, err := sign(s.accessKeys.Current(), claims)
 	if err != nil {
 		return "", 0, fmt.Errorf("sign access token: %w", err)
 	}
@@ -66,29 +202,41 @@ func (s *Service) CreateAccessToken(_ context.Context, user *models.User) (acces
 	return accessToken, expiresAt.Unix(), nil
 }
 
-func (s *Service) CreateRefreshToken(_ context.Context, user *models.User) (string, error) {
+// CreateRefreshToken creates a refresh token with a unique ID, so tokens issued for the same user
+// within the same second never collide when they are persisted.
+func (s *Service) CreateRefreshToken(
+	_ context.Context,
+	user *models.User,
+	scopes models.Scopes,
+) (refreshToken string, expires int64, err error) {
 	expiresAt := s.now().Add(s.refreshTokenDuration)
 
+	tokenID, err := s.newUUID()
+	if err != nil {
+		return "", 0, fmt.Errorf("new refresh token id: %w", err)
+	}
+
 	claims := &JwtCustomRefreshClaims{
-		ID: user.ID,
+		Type:  TypeRefresh,
+		ID:    user.ID,
+		Scope: scopes.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	signed, err := token.SignedString(s.refreshSecret)
+	refreshToken, err = sign(s.refreshKeys.Current(), claims)
 	if err != nil {
-		return "", fmt.Errorf("sign refresh token: %w", err)
+		return "", 0, fmt.Errorf("sign refresh token: %w", err)
 	}
 
-	return signed, nil
+	return refreshToken, expiresAt.Unix(), nil
 }
 
 func (s *Service) ParseAccessToken(_ context.Context, token string) (*JwtCustomClaims, error) {
 	claims := new(JwtCustomClaims)
-	if err := s.parseToken(token, s.accessTokenSecret, claims); err != nil {
+	if err := s.parseToken(token, s.AccessTokenKeyfunc, claims); err != nil {
 		return nil, fmt.Errorf("parse token: %w", err)
 	}
 
@@ -97,22 +245,58 @@ func (s *Service) ParseAccessToken(_ context.Context, token string) (*JwtCustomC
 
 func (s *Service) ParseRefreshToken(_ context.Context, token string) (*JwtCustomRefreshClaims, error) {
 	claims := new(JwtCustomRefreshClaims)
-	if err := s.parseToken(token, s.refreshSecret, claims); err != nil {
+	if err := s.parseToken(token, s.refreshTokenKeyfunc, claims); err != nil {
 		return nil, fmt.Errorf("parse token: %w", err)
 	}
 
 	return claims, nil
 }
 
-func (s *Service) parseToken(token string, secret []byte, claims jwt.Claims) error {
-	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+// AccessTokenKeyfunc returns a key to verify the access token signature with.
+func (s *Service) AccessTokenKeyfunc(t *jwt.Token) (any, error) {
+	return lookupVerifyKey(s.accessKeys, t)
+}
+
+// JWKS returns public keys to verify access tokens with. Symmetric keys are never published.
+func (s *Service) JWKS() JWKSet {
+	return JWKSet{Keys: s.accessKeys.PublicKeys()}
+}
+
+func (s *Service) refreshTokenKeyfunc(t *jwt.Token) (any, error) {
+	return lookupVerifyKey(s.refreshKeys, t)
+}
+
+// lookupVerifyKey selects a key from the keyring by the "kid" token header. Tokens without the header
+// were issued before key IDs were introduced and are verified with the current key.
+func lookupVerifyKey(keyring *Keyring, t *jwt.Token) (any, error) {
+	key := keyring.Current()
+	if kid, ok := t.Header["kid"].(string); ok {
+		if key, ok = keyring.Lookup(kid); !ok {
+			return nil, fmt.Errorf("unknown key id: %s", kid)
 		}
+	}
+
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+
+	return key.verifyKey, nil
+}
 
-		return secret, nil
-	})
+func sign(key SigningKey, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+
+	signed, err := token.SignedString(key.signKey)
 	if err != nil {
+		return "", fmt.Errorf("sign token with key %s: %w", key.ID, err)
+	}
+
+	return signed, nil
+}
+
+func (s *Service) parseToken(token string, keyfunc jwt.Keyfunc, claims jwt.Claims) error {
+	if _, err := jwt.ParseWithClaims(token, claims, keyfunc); err != nil {
 		return fmt.Errorf("parse token with claims: %w", err)
 	}
 
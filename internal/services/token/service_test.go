@@ -8,6 +8,7 @@ import (
 	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
@@ -21,29 +22,40 @@ func TestService(t *testing.T) {
 
 	getCurrentTime := func() time.Time { return currentTime }
 	getExpiredTime := func() time.Time { return expiredTime }
+	newUUID := func() (uuid.UUID, error) { return uuid.MustParse("11111111-1111-1111-1111-111111111111"), nil }
 	accessTokenDuration := time.Minute
 	refreshTokenDuration := 2 * time.Minute
-	accessTokenSecret := []byte("access-secret")
-	refreshTokenSecret := []byte("refresh-secret")
+	accessKeys := newKeyring(t, token.NewHMACSigningKey([]byte("access-secret")))
+	scopes := models.Scopes{models.ScopePostsRead, models.ScopeAccount}
+	refreshKeys := newKeyring(t, token.NewHMACSigningKey([]byte("refresh-secret")))
 
 	user := &models.User{
 		Model:    gorm.Model{ID: 123},
 		Email:    "example@email.com",
 		Name:     "name",
 		Password: "password",
+		Roles:    models.Roles{models.RoleAdmin},
 	}
 
 	wantAccessClaims := &token.JwtCustomClaims{
-		Name: "name",
-		ID:   123,
+		Type:      token.TypeAccess,
+		Name:      "name",
+		ID:        123,
+		SessionID: "session-id",
+		Roles:     models.Roles{models.RoleAdmin},
+		Scope:     "posts:read account",
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "11111111-1111-1111-1111-111111111111",
 			ExpiresAt: jwt.NewNumericDate(currentTime.Add(accessTokenDuration)),
 		},
 	}
 
 	wantRefreshClaims := &token.JwtCustomRefreshClaims{
-		ID: 123,
+		Type:  token.TypeRefresh,
+		ID:    123,
+		Scope: "posts:read account",
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "11111111-1111-1111-1111-111111111111",
 			ExpiresAt: jwt.NewNumericDate(currentTime.Add(refreshTokenDuration)),
 		},
 	}
@@ -51,13 +63,14 @@ func TestService(t *testing.T) {
 	t.Run("It should return an error when access token is expired", func(t *testing.T) {
 		service := token.NewService(
 			getExpiredTime,
+			newUUID,
 			accessTokenDuration,
 			refreshTokenDuration,
-			accessTokenSecret,
-			refreshTokenSecret,
+			accessKeys,
+			refreshKeys,
 		)
 
-		accessToken, _, err := service.CreateAccessToken(t.Context(), user)
+		accessToken, _, err := service.CreateAccessToken(t.Context(), user, "session-id", scopes)
 		require.NoError(t, err)
 
 		_, err = service.ParseAccessToken(t.Context(), accessToken)
@@ -67,13 +80,14 @@ func TestService(t *testing.T) {
 	t.Run("It should generate access token and parse it", func(t *testing.T) {
 		service := token.NewService(
 			getCurrentTime,
+			newUUID,
 			accessTokenDuration,
 			refreshTokenDuration,
-			accessTokenSecret,
-			refreshTokenSecret,
+			accessKeys,
+			refreshKeys,
 		)
 
-		accessToken, _, err := service.CreateAccessToken(t.Context(), user)
+		accessToken, _, err := service.CreateAccessToken(t.Context(), user, "session-id", scopes)
 		require.NoError(t, err)
 
 		claims, err := service.ParseAccessToken(t.Context(), accessToken)
@@ -85,13 +99,14 @@ func TestService(t *testing.T) {
 	t.Run("It should return an error when refresh token is expired", func(t *testing.T) {
 		service := token.NewService(
 			getExpiredTime,
+			newUUID,
 			accessTokenDuration,
 			refreshTokenDuration,
-			accessTokenSecret,
-			refreshTokenSecret,
+			accessKeys,
+			refreshKeys,
 		)
 
-		refreshToken, err := service.CreateRefreshToken(t.Context(), user)
+		refreshToken, _, err := service.CreateRefreshToken(t.Context(), user, scopes)
 		require.NoError(t, err)
 
 		_, err = service.ParseRefreshToken(t.Context(), refreshToken)
@@ -101,13 +116,14 @@ func TestService(t *testing.T) {
 	t.Run("It should generate refresh token and parse it", func(t *testing.T) {
 		service := token.NewService(
 			getCurrentTime,
+			newUUID,
 			accessTokenDuration,
 			refreshTokenDuration,
-			accessTokenSecret,
-			refreshTokenSecret,
+			accessKeys,
+			refreshKeys,
 		)
 
-		refreshToken, err := service.CreateRefreshToken(t.Context(), user)
+		refreshToken, _, err := service.CreateRefreshToken(t.Context(), user, scopes)
 		require.NoError(t, err)
 
 		claims, err := service.ParseRefreshToken(t.Context(), refreshToken)
@@ -116,4 +132,63 @@ func TestService(t *testing.T) {
 		assert.Equal(t, wantRefreshClaims, claims)
 	})
 
+	t.Run("It should not accept tokens of another kind signed with the same key", func(t *testing.T) {
+		sharedKeys := newKeyring(t, token.NewHMACSigningKey([]byte("shared-secret")))
+		service := token.NewService(
+			getCurrentTime,
+			newUUID,
+			accessTokenDuration,
+			refreshTokenDuration,
+			sharedKeys,
+			sharedKeys,
+		)
+
+		refreshToken, _, err := service.CreateRefreshToken(t.Context(), user, scopes)
+		require.NoError(t, err)
+
+		_, err = service.ParseAccessToken(t.Context(), refreshToken)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
+
+		accessToken, _, err := service.CreateAccessToken(t.Context(), user, "session-id", scopes)
+		require.NoError(t, err)
+
+		_, err = service.ParseRefreshToken(t.Context(), accessToken)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
+
+		actionToken, err := token.NewActionService(getCurrentTime, newUUID, sharedKeys, nil).
+			CreateMFAChallenge(t.Context(), user, scopes, models.LoginProviderPassword, time.Minute)
+		require.NoError(t, err)
+
+		_, err = service.ParseAccessToken(t.Context(), actionToken)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidClaims)
+	})
+
+	t.Run("It should generate impersonation token and parse it", func(t *testing.T) {
+		service := token.NewService(
+			getCurrentTime,
+			newUUID,
+			accessTokenDuration,
+			refreshTokenDuration,
+			accessKeys,
+			refreshKeys,
+		)
+
+		accessToken, exp, err := service.CreateImpersonationToken(t.Context(), user, 7, scopes, 10*time.Second)
+		require.NoError(t, err)
+
+		assert.Equal(t, currentTime.Add(10*time.Second).Unix(), exp)
+
+		claims, err := service.ParseAccessToken(t.Context(), accessToken)
+		require.NoError(t, err)
+
+		assert.Equal(t, uint(123), claims.ID)
+		assert.Empty(t, claims.SessionID)
+		assert.Equal(t, &token.ActorClaims{Subject: "7"}, claims.Actor)
+		assert.Equal(t, scopes, claims.Scopes())
+		assert.True(t, claims.Impersonated())
+
+		impersonatorID, ok := claims.ImpersonatorID()
+		require.True(t, ok)
+		assert.Equal(t, uint(7), impersonatorID)
+	})
 }
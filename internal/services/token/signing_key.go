@@ -0,0 +1,244 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms of access tokens.
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey is a key used to sign tokens and verify their signatures.
+//
+// Asymmetric keys expose their public part as a JWK, so other services can verify tokens
+// without holding the signing key.
+type SigningKey struct {
+	// ID is a RFC 7638 thumbprint of the key, used as the "kid" token header.
+	ID     string
+	Method jwt.SigningMethod
+
+	signKey   any
+	verifyKey any
+
+	// publicJWK is nil for symmetric keys, which must never be published.
+	publicJWK *JWK
+}
+
+// NewHMACSigningKey creates a HS256 signing key from a shared secret.
+func NewHMACSigningKey(secret []byte) SigningKey {
+	return SigningKey{
+		ID:        thumbprint(map[string]string{"kty": "oct", "k": encode(secret)}),
+		Method:    jwt.SigningMethodHS256,
+		signKey:   secret,
+		verifyKey: secret,
+	}
+}
+
+// ParseSigningKeyPEM creates a signing key for one of RS256, ES256 and EdDSA algorithms from a PEM encoded private key.
+func ParseSigningKeyPEM(algorithm string, privateKeyPEM []byte) (SigningKey, error) {
+	var (
+		privateKey crypto.Signer
+		err        error
+	)
+
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+	case AlgorithmES256:
+		privateKey, err = jwt.ParseECPrivateKeyFromPEM(privateKeyPEM)
+	case AlgorithmEdDSA:
+		var parsedKey crypto.PrivateKey
+		parsedKey, err = jwt.ParseEdPrivateKeyFromPEM(privateKeyPEM)
+		if err == nil {
+			privateKey, _ = parsedKey.(crypto.Signer)
+		}
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("parse %s private key: %w", algorithm, err)
+	}
+
+	if privateKey == nil {
+		return SigningKey{}, fmt.Errorf("unexpected %s private key type", algorithm)
+	}
+
+	return newAsymmetricKey(algorithm, privateKey, privateKey.Public())
+}
+
+// ParseVerificationKeyPEM creates a key for one of RS256, ES256 and EdDSA algorithms from a PEM encoded
+// private or public key. A key created from a public key can only verify signatures.
+func ParseVerificationKeyPEM(algorithm string, keyPEM []byte) (SigningKey, error) {
+	if signingKey, err := ParseSigningKeyPEM(algorithm, keyPEM); err == nil {
+		return signingKey, nil
+	}
+
+	var (
+		publicKey crypto.PublicKey
+		err       error
+	)
+
+	switch algorithm {
+	case AlgorithmRS256:
+		publicKey, err = jwt.ParseRSAPublicKeyFromPEM(keyPEM)
+	case AlgorithmES256:
+		publicKey, err = jwt.ParseECPublicKeyFromPEM(keyPEM)
+	case AlgorithmEdDSA:
+		publicKey, err = jwt.ParseEdPublicKeyFromPEM(keyPEM)
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("parse %s public key: %w", algorithm, err)
+	}
+
+	return newAsymmetricKey(algorithm, nil, publicKey)
+}
+
+func newAsymmetricKey(algorithm string, signKey crypto.Signer, publicKey crypto.PublicKey) (SigningKey, error) {
+	var (
+		method    jwt.SigningMethod
+		publicJWK *JWK
+	)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if algorithm != AlgorithmRS256 {
+			return SigningKey{}, fmt.Errorf("rsa key can't be used with %s", algorithm)
+		}
+
+		method = jwt.SigningMethodRS256
+		publicJWK = &JWK{
+			KeyType: "RSA",
+			N:       encode(key.N.Bytes()),
+			E:       encode(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		if algorithm != AlgorithmES256 || key.Curve != elliptic.P256() {
+			return SigningKey{}, errors.New("ES256 requires a P-256 key")
+		}
+
+		ecdhKey, err := key.ECDH()
+		if err != nil {
+			return SigningKey{}, fmt.Errorf("convert ecdsa public key: %w", err)
+		}
+
+		// Uncompressed point encoding is 0x04 || X || Y.
+		point := ecdhKey.Bytes()
+		size := (len(point) - 1) / 2
+
+		method = jwt.SigningMethodES256
+		publicJWK = &JWK{
+			KeyType: "EC",
+			Curve:   "P-256",
+			X:       encode(point[1 : 1+size]),
+			Y:       encode(point[1+size:]),
+		}
+	case ed25519.PublicKey:
+		if algorithm != AlgorithmEdDSA {
+			return SigningKey{}, fmt.Errorf("ed25519 key can't be used with %s", algorithm)
+		}
+
+		method = jwt.SigningMethodEdDSA
+		publicJWK = &JWK{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       encode(key),
+		}
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+
+	publicJWK.ID = publicJWK.thumbprint()
+	publicJWK.Use = "sig"
+	publicJWK.Algorithm = method.Alg()
+
+	signingKey := SigningKey{
+		ID:        publicJWK.ID,
+		Method:    method,
+		signKey:   signKey,
+		verifyKey: publicKey,
+		publicJWK: publicJWK,
+	}
+
+	return signingKey, nil
+}
+
+// CanSign reports whether the key holds a private part and can be used to sign tokens.
+func (k SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+// JWK is a public JSON Web Key as defined by RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+
+	// RSA public key parameters.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// EC and OKP public key parameters.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JWKSet is a JSON Web Key Set as defined by RFC 7517.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// thumbprint returns a RFC 7638 thumbprint of the key, which only includes its required members.
+func (k *JWK) thumbprint() string {
+	members := map[string]string{"kty": k.KeyType}
+
+	switch k.KeyType {
+	case "RSA":
+		members["n"] = k.N
+		members["e"] = k.E
+	case "EC":
+		members["crv"] = k.Curve
+		members["x"] = k.X
+		members["y"] = k.Y
+	case "OKP":
+		members["crv"] = k.Curve
+		members["x"] = k.X
+	}
+
+	return thumbprint(members)
+}
+
+func thumbprint(members map[string]string) string {
+	// encoding/json sorts map keys and adds no whitespace, which is the canonical form required by RFC 7638.
+	// Marshaling a map of strings never fails.
+	rawMembers, _ := json.Marshal(members)
+
+	hash := sha256.Sum256(rawMembers)
+
+	return encode(hash[:])
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,150 @@
+package token_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func encodePrivateKeyPEM(t *testing.T, privateKey any) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestParseSigningKeyPEM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	user := &models.User{Model: gorm.Model{ID: 123}, Name: "name"}
+
+	testCases := map[string]struct {
+		algorithm   string
+		privateKey  any
+		wantKeyType string
+	}{
+		"It should sign and verify tokens with RS256": {
+			algorithm:   token.AlgorithmRS256,
+			privateKey:  rsaKey,
+			wantKeyType: "RSA",
+		},
+		"It should sign and verify tokens with ES256": {
+			algorithm:   token.AlgorithmES256,
+			privateKey:  ecdsaKey,
+			wantKeyType: "EC",
+		},
+		"It should sign and verify tokens with EdDSA": {
+			algorithm:   token.AlgorithmEdDSA,
+			privateKey:  ed25519Key,
+			wantKeyType: "OKP",
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			signingKey, err := token.ParseSigningKeyPEM(testCase.algorithm, encodePrivateKeyPEM(t, testCase.privateKey))
+			require.NoError(t, err)
+
+			service := token.NewService(
+				time.Now,
+				uuid.NewRandom,
+				time.Minute,
+				time.Minute,
+				newKeyring(t, signingKey),
+				newKeyring(t, token.NewHMACSigningKey([]byte("refresh-secret"))),
+			)
+
+			accessToken, _, err := service.CreateAccessToken(t.Context(), user, "session-id", models.AllScopes())
+			require.NoError(t, err)
+
+			claims, err := service.ParseAccessToken(t.Context(), accessToken)
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, claims.ID)
+
+			parsedToken, _, err := jwt.NewParser().ParseUnverified(accessToken, new(token.JwtCustomClaims))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.algorithm, parsedToken.Method.Alg())
+			assert.Equal(t, signingKey.ID, parsedToken.Header["kid"])
+
+			keySet := service.JWKS()
+			require.Len(t, keySet.Keys, 1)
+			assert.Equal(t, signingKey.ID, keySet.Keys[0].ID)
+			assert.Equal(t, testCase.algorithm, keySet.Keys[0].Algorithm)
+			assert.Equal(t, testCase.wantKeyType, keySet.Keys[0].KeyType)
+		})
+	}
+
+	t.Run("It should reject ES256 keys on other curves", func(t *testing.T) {
+		p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		_, err = token.ParseSigningKeyPEM(token.AlgorithmES256, encodePrivateKeyPEM(t, p384Key))
+		assert.Error(t, err)
+	})
+
+	t.Run("It should reject tokens signed with another key", func(t *testing.T) {
+		signingKey, err := token.ParseSigningKeyPEM(token.AlgorithmES256, encodePrivateKeyPEM(t, ecdsaKey))
+		require.NoError(t, err)
+
+		issuer := token.NewService(
+			time.Now,
+			uuid.NewRandom,
+			time.Minute,
+			time.Minute,
+			newKeyring(t, signingKey),
+			newKeyring(t, token.NewHMACSigningKey([]byte("refresh-secret"))),
+		)
+		verifier := token.NewService(
+			time.Now,
+			uuid.NewRandom,
+			time.Minute,
+			time.Minute,
+			newKeyring(t, token.NewHMACSigningKey([]byte("access-secret"))),
+			newKeyring(t, token.NewHMACSigningKey([]byte("refresh-secret"))),
+		)
+
+		accessToken, _, err := issuer.CreateAccessToken(t.Context(), user, "session-id", models.AllScopes())
+		require.NoError(t, err)
+
+		_, err = verifier.ParseAccessToken(t.Context(), accessToken)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewHMACSigningKey(t *testing.T) {
+	service := token.NewService(
+		time.Now,
+		uuid.NewRandom,
+		time.Minute,
+		time.Minute,
+		newKeyring(t, token.NewHMACSigningKey([]byte("access-secret"))),
+		newKeyring(t, token.NewHMACSigningKey([]byte("refresh-secret"))),
+	)
+
+	// Shared secrets must never be published.
+	assert.Empty(t, service.JWKS().Keys)
+}
@@ -3,11 +3,11 @@ package user
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/requests"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 //go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
@@ -17,36 +17,64 @@ type userRepository interface {
 	GetByID(ctx context.Context, id uint) (models.User, error)
 	GetUserByEmail(ctx context.Context, email string) (models.User, error)
 	CreateUserAndOAuthProvider(ctx context.Context, user *models.User, oauthProvider *models.OAuthProviders) error
+	MarkVerified(ctx context.Context, id uint, verifiedAt time.Time) error
+	UpdatePassword(ctx context.Context, id uint, passwordHash string) error
+	UpdateEmail(ctx context.Context, id uint, email string, verifiedAt time.Time) error
+}
+
+type passwordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) (needsRehash bool, err error)
+}
+
+type passwordPolicy interface {
+	Check(password, email, name string) error
 }
 
 type Service struct {
 	userRepository userRepository
+	passwordHasher passwordHasher
+	passwordPolicy passwordPolicy
 }
 
-func NewService(userRepository userRepository) *Service {
-	return &Service{userRepository: userRepository}
+func NewService(userRepository userRepository, passwordHasher passwordHasher, passwordPolicy passwordPolicy) *Service {
+	return &Service{
+		userRepository: userRepository,
+		passwordHasher: passwordHasher,
+		passwordPolicy: passwordPolicy,
+	}
+}
+
+// CheckPasswordPolicy checks the new password of the user against the password policy.
+// It is checked before the user is registered or the password is updated, which don't check it themselves.
+// It returns [*models.PasswordPolicyError] which explains every rule the password breaks.
+func (s *Service) CheckPasswordPolicy(user *models.User, password string) error {
+	if err := s.passwordPolicy.Check(password, user.Email, user.Name); err != nil {
+		return fmt.Errorf("check password policy: %w", err)
+	}
+
+	return nil
 }
 
-func (s *Service) Register(ctx context.Context, request *requests.RegisterRequest) error {
-	encryptedPassword, err := bcrypt.GenerateFromPassword(
-		[]byte(request.Password),
-		bcrypt.DefaultCost,
-	)
+// Register creates the user with an unverified email.
+func (s *Service) Register(ctx context.Context, request *requests.RegisterRequest) (models.User, error) {
+	passwordHash, err := s.passwordHasher.Hash(request.Password)
 	if err != nil {
-		return fmt.Errorf("encrypt password: %w", err)
+		return models.User{}, fmt.Errorf("hash password: %w", err)
 	}
 
 	user := &models.User{
 		Email:    request.Email,
 		Name:     request.Name,
-		Password: string(encryptedPassword),
+		Password: passwordHash,
+		Roles:    models.Roles{models.RoleUser},
 	}
 
 	if err := s.userRepository.Create(ctx, user); err != nil {
-		return fmt.Errorf("create user in repository: %w", err)
+		return models.User{}, fmt.Errorf("create user in repository: %w", err)
 	}
 
-	return nil
+	return *user, nil
 }
 
 func (s *Service) GetByID(ctx context.Context, id uint) (models.User, error) {
@@ -75,3 +103,64 @@ func (s *Service) CreateUserAndOAuthProvider(ctx context.Context, user *models.U
 
 	return nil
 }
+
+func (s *Service) MarkVerified(ctx context.Context, id uint, verifiedAt time.Time) error {
+	if err := s.userRepository.MarkVerified(ctx, id, verifiedAt); err != nil {
+		return fmt.Errorf("mark user verified in repository: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the password of the user.
+func (s *Service) UpdatePassword(ctx context.Context, id uint, password string) error {
+	passwordHash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+
+	if err := s.userRepository.UpdatePassword(ctx, id, passwordHash); err != nil {
+		return fmt.Errorf("update user password in repository: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEmail replaces the email of the user with the new one, which has been verified.
+func (s *Service) UpdateEmail(ctx context.Context, id uint, email string, verifiedAt time.Time) error {
+	if err := s.userRepository.UpdateEmail(ctx, id, email, verifiedAt); err != nil {
+		return fmt.Errorf("update user email in repository: %w", err)
+	}
+
+	return nil
+}
+
+// ComparePassword checks the password of the user. When the stored hash uses an outdated algorithm
+// or parameters, it is replaced with a new hash of the password.
+// It returns [models.ErrInvalidPassword] when the password doesn't match or the user has no password.
+// A zero user can be passed for unknown users, so that checking their password takes as long as for existing ones.
+func (s *Service) ComparePassword(ctx context.Context, user *models.User, password string) error {
+	needsRehash, err := s.passwordHasher.Compare(user.Password, password)
+	if err != nil {
+		return fmt.Errorf("compare password hash: %w", err)
+	}
+
+	if !needsRehash {
+		return nil
+	}
+
+	// The password has been checked already, so a failed rehash is retried on the next comparison.
+	passwordHash, err := s.passwordHasher.Hash(password)
+	if err == nil {
+		err = s.userRepository.UpdatePassword(ctx, user.ID, passwordHash)
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to rehash password", "err", err, "user_id", user.ID)
+		return nil
+	}
+
+	user.Password = passwordHash
+
+	return nil
+}
@@ -12,6 +12,7 @@ package user_test
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -194,3 +195,281 @@ func (c *MockuserRepositoryGetUserByEmailCall) DoAndReturn(f func(context.Contex
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// MarkVerified mocks base method.
+func (m *MockuserRepository) MarkVerified(ctx context.Context, id uint, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkVerified", ctx, id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkVerified indicates an expected call of MarkVerified.
+func (mr *MockuserRepositoryMockRecorder) MarkVerified(ctx, id, verifiedAt any) *MockuserRepositoryMarkVerifiedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkVerified", reflect.TypeOf((*MockuserRepository)(nil).MarkVerified), ctx, id, verifiedAt)
+	return &MockuserRepositoryMarkVerifiedCall{Call: call}
+}
+
+// MockuserRepositoryMarkVerifiedCall wrap *gomock.Call
+type MockuserRepositoryMarkVerifiedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryMarkVerifiedCall) Return(arg0 error) *MockuserRepositoryMarkVerifiedCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryMarkVerifiedCall) Do(f func(context.Context, uint, time.Time) error) *MockuserRepositoryMarkVerifiedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryMarkVerifiedCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MockuserRepositoryMarkVerifiedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateEmail mocks base method.
+func (m *MockuserRepository) UpdateEmail(ctx context.Context, id uint, email string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, id, email, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockuserRepositoryMockRecorder) UpdateEmail(ctx, id, email, verifiedAt any) *MockuserRepositoryUpdateEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockuserRepository)(nil).UpdateEmail), ctx, id, email, verifiedAt)
+	return &MockuserRepositoryUpdateEmailCall{Call: call}
+}
+
+// MockuserRepositoryUpdateEmailCall wrap *gomock.Call
+type MockuserRepositoryUpdateEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryUpdateEmailCall) Return(arg0 error) *MockuserRepositoryUpdateEmailCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryUpdateEmailCall) Do(f func(context.Context, uint, string, time.Time) error) *MockuserRepositoryUpdateEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryUpdateEmailCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MockuserRepositoryUpdateEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdatePassword mocks base method.
+func (m *MockuserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockuserRepositoryMockRecorder) UpdatePassword(ctx, id, passwordHash any) *MockuserRepositoryUpdatePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockuserRepository)(nil).UpdatePassword), ctx, id, passwordHash)
+	return &MockuserRepositoryUpdatePasswordCall{Call: call}
+}
+
+// MockuserRepositoryUpdatePasswordCall wrap *gomock.Call
+type MockuserRepositoryUpdatePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserRepositoryUpdatePasswordCall) Return(arg0 error) *MockuserRepositoryUpdatePasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserRepositoryUpdatePasswordCall) Do(f func(context.Context, uint, string) error) *MockuserRepositoryUpdatePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserRepositoryUpdatePasswordCall) DoAndReturn(f func(context.Context, uint, string) error) *MockuserRepositoryUpdatePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockpasswordHasher is a mock of passwordHasher interface.
+type MockpasswordHasher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordHasherMockRecorder
+	isgomock struct{}
+}
+
+// MockpasswordHasherMockRecorder is the mock recorder for MockpasswordHasher.
+type MockpasswordHasherMockRecorder struct {
+	mock *MockpasswordHasher
+}
+
+// NewMockpasswordHasher creates a new mock instance.
+func NewMockpasswordHasher(ctrl *gomock.Controller) *MockpasswordHasher {
+	mock := &MockpasswordHasher{ctrl: ctrl}
+	mock.recorder = &MockpasswordHasherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordHasher) EXPECT() *MockpasswordHasherMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockpasswordHasher) Compare(hash, password string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hash, password)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockpasswordHasherMockRecorder) Compare(hash, password any) *MockpasswordHasherCompareCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockpasswordHasher)(nil).Compare), hash, password)
+	return &MockpasswordHasherCompareCall{Call: call}
+}
+
+// MockpasswordHasherCompareCall wrap *gomock.Call
+type MockpasswordHasherCompareCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordHasherCompareCall) Return(needsRehash bool, err error) *MockpasswordHasherCompareCall {
+	c.Call = c.Call.Return(needsRehash, err)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordHasherCompareCall) Do(f func(string, string) (bool, error)) *MockpasswordHasherCompareCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordHasherCompareCall) DoAndReturn(f func(string, string) (bool, error)) *MockpasswordHasherCompareCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Hash mocks base method.
+func (m *MockpasswordHasher) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockpasswordHasherMockRecorder) Hash(password any) *MockpasswordHasherHashCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockpasswordHasher)(nil).Hash), password)
+	return &MockpasswordHasherHashCall{Call: call}
+}
+
+// MockpasswordHasherHashCall wrap *gomock.Call
+type MockpasswordHasherHashCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordHasherHashCall) Return(arg0 string, arg1 error) *MockpasswordHasherHashCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordHasherHashCall) Do(f func(string) (string, error)) *MockpasswordHasherHashCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordHasherHashCall) DoAndReturn(f func(string) (string, error)) *MockpasswordHasherHashCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockpasswordPolicy is a mock of passwordPolicy interface.
+type MockpasswordPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockpasswordPolicyMockRecorder is the mock recorder for MockpasswordPolicy.
+type MockpasswordPolicyMockRecorder struct {
+	mock *MockpasswordPolicy
+}
+
+// NewMockpasswordPolicy creates a new mock instance.
+func NewMockpasswordPolicy(ctrl *gomock.Controller) *MockpasswordPolicy {
+	mock := &MockpasswordPolicy{ctrl: ctrl}
+	mock.recorder = &MockpasswordPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordPolicy) EXPECT() *MockpasswordPolicyMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockpasswordPolicy) Check(password, email, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", password, email, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockpasswordPolicyMockRecorder) Check(password, email, name any) *MockpasswordPolicyCheckCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockpasswordPolicy)(nil).Check), password, email, name)
+	return &MockpasswordPolicyCheckCall{Call: call}
+}
+
+// MockpasswordPolicyCheckCall wrap *gomock.Call
+type MockpasswordPolicyCheckCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpasswordPolicyCheckCall) Return(arg0 error) *MockpasswordPolicyCheckCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpasswordPolicyCheckCall) Do(f func(string, string, string) error) *MockpasswordPolicyCheckCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpasswordPolicyCheckCall) DoAndReturn(f func(string, string, string) error) *MockpasswordPolicyCheckCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -2,6 +2,7 @@ package user_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
@@ -11,13 +12,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 func TestService_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userRepository := NewMockuserRepository(ctrl)
-	userService := user.NewService(userRepository)
+	passwordHasher := NewMockpasswordHasher(ctrl)
+	userService := user.NewService(userRepository, passwordHasher, NewMockpasswordPolicy(ctrl))
 
 	request := &requests.RegisterRequest{
 		BasicAuth: requests.BasicAuth{
@@ -30,30 +32,33 @@ func TestService_Register(t *testing.T) {
 	wantUser := &models.User{
 		Email: "example@email.com",
 		Name:  "name",
+		Roles: models.Roles{models.RoleUser},
 	}
 
+	passwordHasher.EXPECT().Hash("some-password").Return("password-hash", nil)
+
 	userRepository.
 		EXPECT().
 		Create(gomock.Any(), gomock.Any()).
 		DoAndReturn(func(_ context.Context, got *models.User) error {
-			err := bcrypt.CompareHashAndPassword([]byte(got.Password), []byte("some-password"))
-			require.NoError(t, err)
-
-			wantUser.Password = got.Password
+			wantUser.Password = "password-hash"
 
 			assert.Equal(t, wantUser, got)
 
 			return nil
 		})
 
-	err := userService.Register(t.Context(), request)
+	gotUser, err := userService.Register(t.Context(), request)
 	require.NoError(t, err)
+
+	assert.Equal(t, *wantUser, gotUser)
 }
 
 func TestService_GetByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userRepository := NewMockuserRepository(ctrl)
-	userService := user.NewService(userRepository)
+	passwordHasher := NewMockpasswordHasher(ctrl)
+	userService := user.NewService(userRepository, passwordHasher, NewMockpasswordPolicy(ctrl))
 
 	wantUser := models.User{
 		Email:    "example@email.com",
@@ -75,7 +80,8 @@ func TestService_GetByID(t *testing.T) {
 func TestService_GetUserByEmail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	userRepository := NewMockuserRepository(ctrl)
-	userService := user.NewService(userRepository)
+	passwordHasher := NewMockpasswordHasher(ctrl)
+	userService := user.NewService(userRepository, passwordHasher, NewMockpasswordPolicy(ctrl))
 
 	wantUser := models.User{
 		Email:    "example@gmail.com",
@@ -93,3 +99,106 @@ func TestService_GetUserByEmail(t *testing.T) {
 
 	assert.Equal(t, wantUser, gotUser)
 }
+
+func TestService_UpdatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userRepository := NewMockuserRepository(ctrl)
+	passwordHasher := NewMockpasswordHasher(ctrl)
+	userService := user.NewService(userRepository, passwordHasher, NewMockpasswordPolicy(ctrl))
+
+	passwordHasher.EXPECT().Hash("new-password").Return("new-password-hash", nil)
+
+	userRepository.
+		EXPECT().
+		UpdatePassword(gomock.Any(), uint(123), "new-password-hash").
+		Return(nil)
+
+	err := userService.UpdatePassword(t.Context(), 123, "new-password")
+	require.NoError(t, err)
+}
+
+func TestService_CheckPasswordPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		policyErr error
+		wantErr   error
+	}{
+		"It should accept password following the policy": {},
+		"It should reject password breaking the policy": {
+			policyErr: &models.PasswordPolicyError{Violations: []string{"must contain a digit"}},
+			wantErr:   models.ErrWeakPassword,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			passwordPolicy := NewMockpasswordPolicy(ctrl)
+			userService := user.NewService(NewMockuserRepository(ctrl), NewMockpasswordHasher(ctrl), passwordPolicy)
+
+			passwordPolicy.EXPECT().Check("new-password", "example@email.com", "name").Return(testCase.policyErr)
+
+			err := userService.CheckPasswordPolicy(&models.User{Email: "example@email.com", Name: "name"}, "new-password")
+			assert.ErrorIs(t, err, testCase.wantErr)
+		})
+	}
+}
+
+func TestService_ComparePassword(t *testing.T) {
+	newService := func(t *testing.T) (*user.Service, *MockuserRepository, *MockpasswordHasher) {
+		t.Helper()
+
+		ctrl := gomock.NewController(t)
+		userRepository := NewMockuserRepository(ctrl)
+		passwordHasher := NewMockpasswordHasher(ctrl)
+
+		return user.NewService(userRepository, passwordHasher, NewMockpasswordPolicy(ctrl)), userRepository, passwordHasher
+	}
+
+	t.Run("It should accept valid password", func(t *testing.T) {
+		userService, _, passwordHasher := newService(t)
+
+		passwordHasher.EXPECT().Compare("password-hash", "some-password").Return(false, nil)
+
+		err := userService.ComparePassword(t.Context(), &models.User{Password: "password-hash"}, "some-password")
+		require.NoError(t, err)
+	})
+
+	t.Run("It should reject invalid password", func(t *testing.T) {
+		userService, _, passwordHasher := newService(t)
+
+		passwordHasher.EXPECT().Compare("password-hash", "another-password").Return(false, models.ErrInvalidPassword)
+
+		err := userService.ComparePassword(t.Context(), &models.User{Password: "password-hash"}, "another-password")
+		assert.ErrorIs(t, err, models.ErrInvalidPassword)
+	})
+
+	t.Run("It should rehash outdated password hash", func(t *testing.T) {
+		userService, userRepository, passwordHasher := newService(t)
+
+		passwordHasher.EXPECT().Compare("outdated-hash", "some-password").Return(true, nil)
+		passwordHasher.EXPECT().Hash("some-password").Return("new-hash", nil)
+		userRepository.EXPECT().UpdatePassword(gomock.Any(), uint(123), "new-hash").Return(nil)
+
+		user := &models.User{Model: gorm.Model{ID: 123}, Password: "outdated-hash"}
+
+		err := userService.ComparePassword(t.Context(), user, "some-password")
+		require.NoError(t, err)
+
+		assert.Equal(t, "new-hash", user.Password)
+	})
+
+	t.Run("It should accept valid password when rehash fails", func(t *testing.T) {
+		userService, userRepository, passwordHasher := newService(t)
+
+		passwordHasher.EXPECT().Compare("outdated-hash", "some-password").Return(true, nil)
+		passwordHasher.EXPECT().Hash("some-password").Return("new-hash", nil)
+		userRepository.EXPECT().UpdatePassword(gomock.Any(), uint(123), "new-hash").Return(errors.New("repository error"))
+
+		user := &models.User{Model: gorm.Model{ID: 123}, Password: "outdated-hash"}
+
+		err := userService.ComparePassword(t.Context(), user, "some-password")
+		require.NoError(t, err)
+
+		assert.Equal(t, "outdated-hash", user.Password)
+	})
+}
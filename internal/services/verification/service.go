@@ -0,0 +1,255 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+)
+
+//go:generate go tool mockgen -source=$GOFILE -destination=service_mock_test.go -package=${GOPACKAGE}_test -typed=true
+
+type userService interface {
+	GetByID(ctx context.Context, id uint) (models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (models.User, error)
+	MarkVerified(ctx context.Context, id uint, verifiedAt time.Time) error
+	UpdateEmail(ctx context.Context, id uint, email string, verifiedAt time.Time) error
+	ComparePassword(ctx context.Context, user *models.User, password string) error
+}
+
+type actionTokenService interface {
+	Create(ctx context.Context, user *models.User, purpose models.ActionPurpose, duration time.Duration) (string, error)
+	CreateEmailChange(ctx context.Context, user *models.User, newEmail string, duration time.Duration) (string, error)
+	Consume(ctx context.Context, purpose models.ActionPurpose, actionToken string) (*token.JwtActionClaims, error)
+}
+
+type mailSender interface {
+	Send(ctx context.Context, message mail.Message) error
+}
+
+// Service verifies the ownership of user emails with single-use links sent to them,
+// both after registration and before the email of a user is changed.
+type Service struct {
+	now                  func() time.Time
+	userService          userService
+	actionTokenService   actionTokenService
+	mailSender           mailSender
+	mailFrom             string
+	linkBaseURL          string
+	verificationDuration time.Duration
+}
+
+func NewService(
+	now func() time.Time,
+	userService userService,
+	actionTokenService actionTokenService,
+	mailSender mailSender,
+	mailFrom string,
+	linkBaseURL string,
+	verificationDuration time.Duration,
+) *Service {
+	return &Service{
+		now:                  now,
+		userService:          userService,
+		actionTokenService:   actionTokenService,
+		mailSender:           mailSender,
+		mailFrom:             mailFrom,
+		linkBaseURL:          linkBaseURL,
+		verificationDuration: verificationDuration,
+	}
+}
+
+// SendVerification emails the verification link to the user, unless the email is verified already.
+func (s *Service) SendVerification(ctx context.Context, user *models.User) error {
+	if user.VerifiedAt != nil {
+		return nil
+	}
+
+	actionToken, err := s.actionTokenService.Create(ctx, user, models.ActionVerifyEmail, s.verificationDuration)
+	if err != nil {
+		return fmt.Errorf("create verification token: %w", err)
+	}
+
+	link, err := url.JoinPath(s.linkBaseURL, "verify-email")
+	if err != nil {
+		return fmt.Errorf("join verification link path: %w", err)
+	}
+
+	message := mail.Message{
+		From:    s.mailFrom,
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nConfirm your email by following the link, it is valid for %s:\n%s?token=%s\n",
+			user.Name,
+			s.verificationDuration,
+			link,
+			url.QueryEscape(actionToken),
+		),
+	}
+
+	if err := s.mailSender.Send(ctx, message); err != nil {
+		return fmt.Errorf("send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// SendAccountExists tells the user that someone tried to register with the email of the existing account.
+// It is sent instead of an error response, so that registration can't be used to check who is registered.
+func (s *Service) SendAccountExists(ctx context.Context, user *models.User) error {
+	message := mail.Message{
+		From:    s.mailFrom,
+		To:      user.Email,
+		Subject: "You already have an account",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nSomeone tried to register with your email, but you already have an account.\n"+
+				"If it was you, log in or reset your password if you forgot it. Otherwise, ignore this email.\n",
+			user.Name,
+		),
+	}
+
+	if err := s.mailSender.Send(ctx, message); err != nil {
+		return fmt.Errorf("send account exists email: %w", err)
+	}
+
+	return nil
+}
+
+// ResendVerification emails a new verification link to the user with the email.
+// It succeeds for unknown and verified emails as well, so that it can't be used to check who is registered.
+func (s *Service) ResendVerification(ctx context.Context, email string) error {
+	user, err := s.userService.GetUserByEmail(ctx, email)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("get user by email: %w", err)
+	}
+
+	return s.SendVerification(ctx, &user)
+}
+
+// VerifyEmail marks the email of the user the token was issued for as verified.
+// It returns [models.ErrInvalidActionToken] when the token is invalid, expired, already used,
+// or the email has changed since the token was issued.
+func (s *Service) VerifyEmail(ctx context.Context, verificationToken string) error {
+	claims, err := s.actionTokenService.Consume(ctx, models.ActionVerifyEmail, verificationToken)
+	if err != nil {
+		return fmt.Errorf("consume verification token: %w", err)
+	}
+
+	user, err := s.userService.GetByID(ctx, claims.ID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return errors.Join(models.ErrInvalidActionToken, err)
+	} else if err != nil {
+		return fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user.Email != claims.Email {
+		return fmt.Errorf("%w: issued for another email", models.ErrInvalidActionToken)
+	}
+
+	if user.VerifiedAt != nil {
+		return nil
+	}
+
+	if err := s.userService.MarkVerified(ctx, user.ID, s.now()); err != nil {
+		return fmt.Errorf("mark user verified: %w", err)
+	}
+
+	return nil
+}
+
+// RequestEmailChange emails a link confirming the change to the new email after checking the user password.
+// The email is switched only once the link is followed. It returns [models.ErrInvalidPassword]
+// when the password doesn't match, and [models.ErrUserAlreadyExists] when the new email is taken.
+func (s *Service) RequestEmailChange(ctx context.Context, userID uint, newEmail, password string) error {
+	user, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user by id: %w", err)
+	}
+
+	if err := s.userService.ComparePassword(ctx, &user, password); err != nil {
+		return fmt.Errorf("compare password: %w", err)
+	}
+
+	if err := s.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
+	}
+
+	actionToken, err := s.actionTokenService.CreateEmailChange(ctx, &user, newEmail, s.verificationDuration)
+	if err != nil {
+		return fmt.Errorf("create email change token: %w", err)
+	}
+
+	link, err := url.JoinPath(s.linkBaseURL, "email", "confirm")
+	if err != nil {
+		return fmt.Errorf("join email change link path: %w", err)
+	}
+
+	message := mail.Message{
+		From:    s.mailFrom,
+		To:      newEmail,
+		Subject: "Confirm your new email",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nConfirm your new email by following the link, it is valid for %s:\n%s?token=%s\n",
+			user.Name,
+			s.verificationDuration,
+			link,
+			url.QueryEscape(actionToken),
+		),
+	}
+
+	if err := s.mailSender.Send(ctx, message); err != nil {
+		return fmt.Errorf("send email change email: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange switches the email of the user the token was issued for to the new, now verified, email.
+// It returns [models.ErrInvalidActionToken] when the token is invalid, expired, already used,
+// or the email has changed since the token was issued, and [models.ErrUserAlreadyExists] when the new email is taken.
+func (s *Service) ConfirmEmailChange(ctx context.Context, changeToken string) error {
+	claims, err := s.actionTokenService.Consume(ctx, models.ActionChangeEmail, changeToken)
+	if err != nil {
+		return fmt.Errorf("consume email change token: %w", err)
+	}
+
+	user, err := s.userService.GetByID(ctx, claims.ID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return errors.Join(models.ErrInvalidActionToken, err)
+	} else if err != nil {
+		return fmt.Errorf("get user by id: %w", err)
+	}
+
+	if user.Email != claims.Email {
+		return fmt.Errorf("%w: issued for another email", models.ErrInvalidActionToken)
+	}
+
+	if err := s.checkEmailAvailable(ctx, claims.NewEmail); err != nil {
+		return err
+	}
+
+	if err := s.userService.UpdateEmail(ctx, user.ID, claims.NewEmail, s.now()); err != nil {
+		return fmt.Errorf("update user email: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) checkEmailAvailable(ctx context.Context, email string) error {
+	_, err := s.userService.GetUserByEmail(ctx, email)
+	if err == nil {
+		return models.ErrUserAlreadyExists
+	} else if !errors.Is(err, models.ErrUserNotFound) {
+		return fmt.Errorf("get user by email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,440 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=verification_test -typed=true
+//
+
+// Package verification_test is a generated GoMock package.
+package verification_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	mail "github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	models "github.com/nix-united/golang-echo-boilerplate/internal/models"
+	token "github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockuserService is a mock of userService interface.
+type MockuserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockuserServiceMockRecorder is the mock recorder for MockuserService.
+type MockuserServiceMockRecorder struct {
+	mock *MockuserService
+}
+
+// NewMockuserService creates a new mock instance.
+func NewMockuserService(ctrl *gomock.Controller) *MockuserService {
+	mock := &MockuserService{ctrl: ctrl}
+	mock.recorder = &MockuserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserService) EXPECT() *MockuserServiceMockRecorder {
+	return m.recorder
+}
+
+// ComparePassword mocks base method.
+func (m *MockuserService) ComparePassword(ctx context.Context, user *models.User, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComparePassword", ctx, user, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ComparePassword indicates an expected call of ComparePassword.
+func (mr *MockuserServiceMockRecorder) ComparePassword(ctx, user, password any) *MockuserServiceComparePasswordCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComparePassword", reflect.TypeOf((*MockuserService)(nil).ComparePassword), ctx, user, password)
+	return &MockuserServiceComparePasswordCall{Call: call}
+}
+
+// MockuserServiceComparePasswordCall wrap *gomock.Call
+type MockuserServiceComparePasswordCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceComparePasswordCall) Return(arg0 error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceComparePasswordCall) Do(f func(context.Context, *models.User, string) error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceComparePasswordCall) DoAndReturn(f func(context.Context, *models.User, string) error) *MockuserServiceComparePasswordCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetByID mocks base method.
+func (m *MockuserService) GetByID(ctx context.Context, id uint) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockuserServiceMockRecorder) GetByID(ctx, id any) *MockuserServiceGetByIDCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockuserService)(nil).GetByID), ctx, id)
+	return &MockuserServiceGetByIDCall{Call: call}
+}
+
+// MockuserServiceGetByIDCall wrap *gomock.Call
+type MockuserServiceGetByIDCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetByIDCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetByIDCall) Do(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetByIDCall) DoAndReturn(f func(context.Context, uint) (models.User, error)) *MockuserServiceGetByIDCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockuserService) GetUserByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockuserServiceMockRecorder) GetUserByEmail(ctx, email any) *MockuserServiceGetUserByEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockuserService)(nil).GetUserByEmail), ctx, email)
+	return &MockuserServiceGetUserByEmailCall{Call: call}
+}
+
+// MockuserServiceGetUserByEmailCall wrap *gomock.Call
+type MockuserServiceGetUserByEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceGetUserByEmailCall) Return(arg0 models.User, arg1 error) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceGetUserByEmailCall) Do(f func(context.Context, string) (models.User, error)) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceGetUserByEmailCall) DoAndReturn(f func(context.Context, string) (models.User, error)) *MockuserServiceGetUserByEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MarkVerified mocks base method.
+func (m *MockuserService) MarkVerified(ctx context.Context, id uint, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkVerified", ctx, id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkVerified indicates an expected call of MarkVerified.
+func (mr *MockuserServiceMockRecorder) MarkVerified(ctx, id, verifiedAt any) *MockuserServiceMarkVerifiedCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkVerified", reflect.TypeOf((*MockuserService)(nil).MarkVerified), ctx, id, verifiedAt)
+	return &MockuserServiceMarkVerifiedCall{Call: call}
+}
+
+// MockuserServiceMarkVerifiedCall wrap *gomock.Call
+type MockuserServiceMarkVerifiedCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceMarkVerifiedCall) Return(arg0 error) *MockuserServiceMarkVerifiedCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceMarkVerifiedCall) Do(f func(context.Context, uint, time.Time) error) *MockuserServiceMarkVerifiedCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceMarkVerifiedCall) DoAndReturn(f func(context.Context, uint, time.Time) error) *MockuserServiceMarkVerifiedCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// UpdateEmail mocks base method.
+func (m *MockuserService) UpdateEmail(ctx context.Context, id uint, email string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, id, email, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockuserServiceMockRecorder) UpdateEmail(ctx, id, email, verifiedAt any) *MockuserServiceUpdateEmailCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockuserService)(nil).UpdateEmail), ctx, id, email, verifiedAt)
+	return &MockuserServiceUpdateEmailCall{Call: call}
+}
+
+// MockuserServiceUpdateEmailCall wrap *gomock.Call
+type MockuserServiceUpdateEmailCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockuserServiceUpdateEmailCall) Return(arg0 error) *MockuserServiceUpdateEmailCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockuserServiceUpdateEmailCall) Do(f func(context.Context, uint, string, time.Time) error) *MockuserServiceUpdateEmailCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockuserServiceUpdateEmailCall) DoAndReturn(f func(context.Context, uint, string, time.Time) error) *MockuserServiceUpdateEmailCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockactionTokenService is a mock of actionTokenService interface.
+type MockactionTokenService struct {
+	ctrl     *gomock.Controller
+	recorder *MockactionTokenServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockactionTokenServiceMockRecorder is the mock recorder for MockactionTokenService.
+type MockactionTokenServiceMockRecorder struct {
+	mock *MockactionTokenService
+}
+
+// NewMockactionTokenService creates a new mock instance.
+func NewMockactionTokenService(ctrl *gomock.Controller) *MockactionTokenService {
+	mock := &MockactionTokenService{ctrl: ctrl}
+	mock.recorder = &MockactionTokenServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockactionTokenService) EXPECT() *MockactionTokenServiceMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockactionTokenService) Consume(ctx context.Context, purpose models.ActionPurpose, actionToken string) (*token.JwtActionClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, purpose, actionToken)
+	ret0, _ := ret[0].(*token.JwtActionClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockactionTokenServiceMockRecorder) Consume(ctx, purpose, actionToken any) *MockactionTokenServiceConsumeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockactionTokenService)(nil).Consume), ctx, purpose, actionToken)
+	return &MockactionTokenServiceConsumeCall{Call: call}
+}
+
+// MockactionTokenServiceConsumeCall wrap *gomock.Call
+type MockactionTokenServiceConsumeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockactionTokenServiceConsumeCall) Return(arg0 *token.JwtActionClaims, arg1 error) *MockactionTokenServiceConsumeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockactionTokenServiceConsumeCall) Do(f func(context.Context, models.ActionPurpose, string) (*token.JwtActionClaims, error)) *MockactionTokenServiceConsumeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockactionTokenServiceConsumeCall) DoAndReturn(f func(context.Context, models.ActionPurpose, string) (*token.JwtActionClaims, error)) *MockactionTokenServiceConsumeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Create mocks base method.
+func (m *MockactionTokenService) Create(ctx context.Context, user *models.User, purpose models.ActionPurpose, duration time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, user, purpose, duration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockactionTokenServiceMockRecorder) Create(ctx, user, purpose, duration any) *MockactionTokenServiceCreateCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockactionTokenService)(nil).Create), ctx, user, purpose, duration)
+	return &MockactionTokenServiceCreateCall{Call: call}
+}
+
+// MockactionTokenServiceCreateCall wrap *gomock.Call
+type MockactionTokenServiceCreateCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockactionTokenServiceCreateCall) Return(arg0 string, arg1 error) *MockactionTokenServiceCreateCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockactionTokenServiceCreateCall) Do(f func(context.Context, *models.User, models.ActionPurpose, time.Duration) (string, error)) *MockactionTokenServiceCreateCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockactionTokenServiceCreateCall) DoAndReturn(f func(context.Context, *models.User, models.ActionPurpose, time.Duration) (string, error)) *MockactionTokenServiceCreateCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// CreateEmailChange mocks base method.
+func (m *MockactionTokenService) CreateEmailChange(ctx context.Context, user *models.User, newEmail string, duration time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailChange", ctx, user, newEmail, duration)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmailChange indicates an expected call of CreateEmailChange.
+func (mr *MockactionTokenServiceMockRecorder) CreateEmailChange(ctx, user, newEmail, duration any) *MockactionTokenServiceCreateEmailChangeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailChange", reflect.TypeOf((*MockactionTokenService)(nil).CreateEmailChange), ctx, user, newEmail, duration)
+	return &MockactionTokenServiceCreateEmailChangeCall{Call: call}
+}
+
+// MockactionTokenServiceCreateEmailChangeCall wrap *gomock.Call
+type MockactionTokenServiceCreateEmailChangeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockactionTokenServiceCreateEmailChangeCall) Return(arg0 string, arg1 error) *MockactionTokenServiceCreateEmailChangeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockactionTokenServiceCreateEmailChangeCall) Do(f func(context.Context, *models.User, string, time.Duration) (string, error)) *MockactionTokenServiceCreateEmailChangeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockactionTokenServiceCreateEmailChangeCall) DoAndReturn(f func(context.Context, *models.User, string, time.Duration) (string, error)) *MockactionTokenServiceCreateEmailChangeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockmailSender is a mock of mailSender interface.
+type MockmailSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockmailSenderMockRecorder is the mock recorder for MockmailSender.
+type MockmailSenderMockRecorder struct {
+	mock *MockmailSender
+}
+
+// NewMockmailSender creates a new mock instance.
+func NewMockmailSender(ctrl *gomock.Controller) *MockmailSender {
+	mock := &MockmailSender{ctrl: ctrl}
+	mock.recorder = &MockmailSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmailSender) EXPECT() *MockmailSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockmailSender) Send(ctx context.Context, message mail.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailSenderMockRecorder) Send(ctx, message any) *MockmailSenderSendCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockmailSender)(nil).Send), ctx, message)
+	return &MockmailSenderSendCall{Call: call}
+}
+
+// MockmailSenderSendCall wrap *gomock.Call
+type MockmailSenderSendCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockmailSenderSendCall) Return(arg0 error) *MockmailSenderSendCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockmailSenderSendCall) Do(f func(context.Context, mail.Message) error) *MockmailSenderSendCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockmailSenderSendCall) DoAndReturn(f func(context.Context, mail.Message) error) *MockmailSenderSendCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
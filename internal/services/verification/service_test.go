@@ -0,0 +1,339 @@
+package verification_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/mail"
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/token"
+	"github.com/nix-united/golang-echo-boilerplate/internal/services/verification"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+var currentTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newService(t *testing.T) (*verification.Service, *MockuserService, *MockactionTokenService, *mail.MemorySender) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	userService := NewMockuserService(ctrl)
+	actionTokenService := NewMockactionTokenService(ctrl)
+	mailSender := mail.NewMemorySender()
+
+	service := verification.NewService(
+		func() time.Time { return currentTime },
+		userService,
+		actionTokenService,
+		mailSender,
+		"no-reply@example.com",
+		"https://app.example.com",
+		24*time.Hour,
+	)
+
+	return service, userService, actionTokenService, mailSender
+}
+
+func TestService_SendVerification(t *testing.T) {
+	t.Run("It should email verification link", func(t *testing.T) {
+		service, _, actionTokenService, mailSender := newService(t)
+
+		user := &models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", Name: "name"}
+
+		actionTokenService.EXPECT().
+			Create(gomock.Any(), user, models.ActionVerifyEmail, 24*time.Hour).
+			Return("verification-token", nil)
+
+		err := service.SendVerification(t.Context(), user)
+		require.NoError(t, err)
+
+		messages := mailSender.Messages()
+		require.Len(t, messages, 1)
+
+		assert.Equal(t, "no-reply@example.com", messages[0].From)
+		assert.Equal(t, "user@example.com", messages[0].To)
+		assert.Contains(t, messages[0].Body, "https://app.example.com/verify-email?token=verification-token")
+	})
+
+	t.Run("It should not email verified user", func(t *testing.T) {
+		service, _, _, mailSender := newService(t)
+
+		err := service.SendVerification(t.Context(), &models.User{Email: "user@example.com", VerifiedAt: &currentTime})
+		require.NoError(t, err)
+
+		assert.Empty(t, mailSender.Messages())
+	})
+}
+
+func TestService_SendAccountExists(t *testing.T) {
+	service, _, _, mailSender := newService(t)
+
+	err := service.SendAccountExists(t.Context(), &models.User{Email: "user@example.com", Name: "name"})
+	require.NoError(t, err)
+
+	messages := mailSender.Messages()
+	require.Len(t, messages, 1)
+
+	assert.Equal(t, "user@example.com", messages[0].To)
+	assert.Equal(t, "You already have an account", messages[0].Subject)
+}
+
+func TestService_ResendVerification(t *testing.T) {
+	t.Run("It should email verification link to registered user", func(t *testing.T) {
+		service, userService, actionTokenService, mailSender := newService(t)
+
+		user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com"}
+
+		userService.EXPECT().GetUserByEmail(gomock.Any(), "user@example.com").Return(user, nil)
+		actionTokenService.EXPECT().
+			Create(gomock.Any(), &user, models.ActionVerifyEmail, 24*time.Hour).
+			Return("verification-token", nil)
+
+		err := service.ResendVerification(t.Context(), "user@example.com")
+		require.NoError(t, err)
+
+		assert.Len(t, mailSender.Messages(), 1)
+	})
+
+	t.Run("It should succeed for unknown email without sending anything", func(t *testing.T) {
+		service, userService, _, mailSender := newService(t)
+
+		userService.EXPECT().
+			GetUserByEmail(gomock.Any(), "unknown@example.com").
+			Return(models.User{}, models.ErrUserNotFound)
+
+		err := service.ResendVerification(t.Context(), "unknown@example.com")
+		require.NoError(t, err)
+
+		assert.Empty(t, mailSender.Messages())
+	})
+}
+
+func TestService_VerifyEmail(t *testing.T) {
+	errTest := errors.New("test error")
+
+	claims := &token.JwtActionClaims{ID: 100, Purpose: models.ActionVerifyEmail, Email: "user@example.com"}
+
+	testCases := map[string]struct {
+		setExpectations func(userService *MockuserService, actionTokenService *MockactionTokenService)
+		wantErr         error
+	}{
+		"It should mark user verified": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionVerifyEmail, "verification-token").
+					Return(claims, nil)
+
+				userService.EXPECT().
+					GetByID(gomock.Any(), uint(100)).
+					Return(models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com"}, nil)
+
+				userService.EXPECT().MarkVerified(gomock.Any(), uint(100), currentTime).Return(nil)
+			},
+		},
+		"It should succeed for verified user": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionVerifyEmail, "verification-token").
+					Return(claims, nil)
+
+				userService.EXPECT().
+					GetByID(gomock.Any(), uint(100)).
+					Return(models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", VerifiedAt: &currentTime}, nil)
+			},
+		},
+		"It should reject invalid token": {
+			setExpectations: func(_ *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionVerifyEmail, "verification-token").
+					Return(nil, models.ErrInvalidActionToken)
+			},
+			wantErr: models.ErrInvalidActionToken,
+		},
+		"It should reject token issued for another email": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionVerifyEmail, "verification-token").
+					Return(claims, nil)
+
+				userService.EXPECT().
+					GetByID(gomock.Any(), uint(100)).
+					Return(models.User{Model: gorm.Model{ID: 100}, Email: "changed@example.com"}, nil)
+			},
+			wantErr: models.ErrInvalidActionToken,
+		},
+		"It should reject token of deleted user": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionVerifyEmail, "verification-token").
+					Return(claims, nil)
+
+				userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(models.User{}, models.ErrUserNotFound)
+			},
+			wantErr: models.ErrInvalidActionToken,
+		},
+		"It should return an error when failed to mark user verified": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionVerifyEmail, "verification-token").
+					Return(claims, nil)
+
+				userService.EXPECT().
+					GetByID(gomock.Any(), uint(100)).
+					Return(models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com"}, nil)
+
+				userService.EXPECT().MarkVerified(gomock.Any(), uint(100), currentTime).Return(errTest)
+			},
+			wantErr: errTest,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, userService, actionTokenService, _ := newService(t)
+
+			testCase.setExpectations(userService, actionTokenService)
+
+			err := service.VerifyEmail(t.Context(), "verification-token")
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_RequestEmailChange(t *testing.T) {
+	user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com", Password: "password-hash"}
+
+	t.Run("It should return ErrInvalidPassword error when password is invalid", func(t *testing.T) {
+		service, userService, _, mailSender := newService(t)
+
+		userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		userService.EXPECT().ComparePassword(gomock.Any(), &user, "password").Return(models.ErrInvalidPassword)
+
+		err := service.RequestEmailChange(t.Context(), 100, "new@example.com", "password")
+		assert.ErrorIs(t, err, models.ErrInvalidPassword)
+
+		assert.Empty(t, mailSender.Messages())
+	})
+
+	t.Run("It should return ErrUserAlreadyExists error when new email is taken", func(t *testing.T) {
+		service, userService, _, mailSender := newService(t)
+
+		userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		userService.EXPECT().ComparePassword(gomock.Any(), &user, "password").Return(nil)
+		userService.EXPECT().GetUserByEmail(gomock.Any(), "new@example.com").Return(models.User{}, nil)
+
+		err := service.RequestEmailChange(t.Context(), 100, "new@example.com", "password")
+		assert.ErrorIs(t, err, models.ErrUserAlreadyExists)
+
+		assert.Empty(t, mailSender.Messages())
+	})
+
+	t.Run("It should email confirmation link to new email", func(t *testing.T) {
+		service, userService, actionTokenService, mailSender := newService(t)
+
+		userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+		userService.EXPECT().ComparePassword(gomock.Any(), &user, "password").Return(nil)
+		userService.EXPECT().
+			GetUserByEmail(gomock.Any(), "new@example.com").
+			Return(models.User{}, models.ErrUserNotFound)
+		actionTokenService.EXPECT().
+			CreateEmailChange(gomock.Any(), &user, "new@example.com", 24*time.Hour).
+			Return("change-token", nil)
+
+		err := service.RequestEmailChange(t.Context(), 100, "new@example.com", "password")
+		require.NoError(t, err)
+
+		messages := mailSender.Messages()
+		require.Len(t, messages, 1)
+
+		assert.Equal(t, "new@example.com", messages[0].To)
+		assert.Contains(t, messages[0].Body, "https://app.example.com/email/confirm?token=change-token")
+	})
+}
+
+func TestService_ConfirmEmailChange(t *testing.T) {
+	claims := &token.JwtActionClaims{
+		ID:       100,
+		Purpose:  models.ActionChangeEmail,
+		Email:    "user@example.com",
+		NewEmail: "new@example.com",
+	}
+
+	user := models.User{Model: gorm.Model{ID: 100}, Email: "user@example.com"}
+
+	testCases := map[string]struct {
+		setExpectations func(userService *MockuserService, actionTokenService *MockactionTokenService)
+		wantErr         error
+	}{
+		"It should switch user email": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionChangeEmail, "change-token").
+					Return(claims, nil)
+
+				userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+				userService.EXPECT().
+					GetUserByEmail(gomock.Any(), "new@example.com").
+					Return(models.User{}, models.ErrUserNotFound)
+				userService.EXPECT().UpdateEmail(gomock.Any(), uint(100), "new@example.com", currentTime).Return(nil)
+			},
+		},
+		"It should reject invalid token": {
+			setExpectations: func(_ *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionChangeEmail, "change-token").
+					Return(nil, models.ErrInvalidActionToken)
+			},
+			wantErr: models.ErrInvalidActionToken,
+		},
+		"It should reject token issued before another email change": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionChangeEmail, "change-token").
+					Return(claims, nil)
+
+				userService.EXPECT().
+					GetByID(gomock.Any(), uint(100)).
+					Return(models.User{Model: gorm.Model{ID: 100}, Email: "changed@example.com"}, nil)
+			},
+			wantErr: models.ErrInvalidActionToken,
+		},
+		"It should return ErrUserAlreadyExists error when new email has been taken since": {
+			setExpectations: func(userService *MockuserService, actionTokenService *MockactionTokenService) {
+				actionTokenService.EXPECT().
+					Consume(gomock.Any(), models.ActionChangeEmail, "change-token").
+					Return(claims, nil)
+
+				userService.EXPECT().GetByID(gomock.Any(), uint(100)).Return(user, nil)
+				userService.EXPECT().GetUserByEmail(gomock.Any(), "new@example.com").Return(models.User{}, nil)
+			},
+			wantErr: models.ErrUserAlreadyExists,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			service, userService, actionTokenService, _ := newService(t)
+
+			testCase.setExpectations(userService, actionTokenService)
+
+			err := service.ConfirmEmailChange(t.Context(), "change-token")
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, err, testCase.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -123,3 +123,9 @@ func ContextWithBaggage(ctx context.Context, key string, value any) context.Cont
 func ContextWithUserID(ctx context.Context, userID uint) context.Context {
 	return ContextWithBaggage(ctx, "user_id", userID)
 }
+
+// ContextWithImpersonatorID appends impersonator_id field to all log messages,
+// so that everything done while impersonating the user is attributed to the impersonator as well.
+func ContextWithImpersonatorID(ctx context.Context, impersonatorID uint) context.Context {
+	return ContextWithBaggage(ctx, "impersonator_id", impersonatorID)
+}
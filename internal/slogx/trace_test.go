@@ -79,3 +79,34 @@ func TestTrace(t *testing.T) {
 		assert.Equal(t, wantLogs[i], gotLog)
 	}
 }
+
+func TestContextWithImpersonatorID(t *testing.T) {
+	tracer := NewTraceStarter(func() (uuid.UUID, error) {
+		return uuid.MustParse("11111111-1111-1111-1111-111111111111"), nil
+	})
+
+	buffer := new(bytes.Buffer)
+
+	logger := slog.New(newTraceHandler(slog.NewJSONHandler(buffer, nil)))
+
+	ctx, err := tracer.Start(t.Context())
+	require.NoError(t, err)
+
+	ctx = ContextWithUserID(ctx, 123)
+	ctx = ContextWithImpersonatorID(ctx, 7)
+
+	logger.InfoContext(ctx, "Message while impersonating")
+
+	var gotLog struct {
+		Trace struct {
+			UserID         uint `json:"user_id"`
+			ImpersonatorID uint `json:"impersonator_id"`
+		} `json:"trace"`
+	}
+
+	err = json.Unmarshal(buffer.Bytes(), &gotLog)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(123), gotLog.Trace.UserID)
+	assert.Equal(t, uint(7), gotLog.Trace.ImpersonatorID)
+}
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE o_auth_providers ADD COLUMN subject VARCHAR(255) NULL AFTER provider;
+-- +goose StatementEnd
+
+-- Identities created before subjects were stored get a placeholder subject, adopted on the next login with the verified email.
+-- +goose StatementBegin
+UPDATE o_auth_providers SET subject = CONCAT('legacy:', id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE o_auth_providers
+    MODIFY subject VARCHAR(255) NOT NULL,
+    ADD UNIQUE INDEX o_auth_providers_provider_subject_idx (provider, subject);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE o_auth_providers
+    DROP INDEX o_auth_providers_provider_subject_idx,
+    DROP COLUMN subject;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN verified_at TIMESTAMP NULL;
+-- +goose StatementEnd
+
+-- Users registered before email verification was introduced are trusted as verified.
+-- +goose StatementBegin
+UPDATE users SET verified_at = created_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE used_action_tokens (
+    token_id VARCHAR(36) NOT NULL PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX used_action_tokens_expires_at_idx (expires_at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE used_action_tokens;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN verified_at;
+-- +goose StatementEnd
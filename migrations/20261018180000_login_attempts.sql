@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE login_attempts (
+    attempt_key VARCHAR(72) NOT NULL PRIMARY KEY,
+    failures INT NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP NULL,
+    expires_at TIMESTAMP NOT NULL,
+    INDEX login_attempts_expires_at_idx (expires_at)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE login_attempts;
+-- +goose StatementEnd
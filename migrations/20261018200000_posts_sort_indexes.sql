@@ -0,0 +1,26 @@
+-- +goose Up
+-- Posts are paged by keyset, ties in the sort field are broken by id.
+-- +goose StatementBegin
+CREATE INDEX posts_created_at_id_idx ON posts (created_at, id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX posts_updated_at_id_idx ON posts (updated_at, id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX posts_title_id_idx ON posts (title, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX posts_title_id_idx ON posts;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX posts_updated_at_id_idx ON posts;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP INDEX posts_created_at_id_idx ON posts;
+-- +goose StatementEnd
@@ -103,4 +103,100 @@ func TestAcceptance(t *testing.T) {
 
 		require.Equal(t, http.StatusCreated, httpResponse.StatusCode)
 	})
+
+	var apiKey string
+
+	t.Run("It should create a read-only api key", func(t *testing.T) {
+		httpRequest, err := http.NewRequest(
+			http.MethodPost,
+			applicationURL.JoinPath("/me/api-keys").String(),
+			bytes.NewReader([]byte(`{"name":"CI","scopes":["posts:read"]}`)),
+		)
+		require.NoError(t, err)
+
+		httpRequest.Header.Set("Content-Type", "application/json")
+		httpRequest.Header.Set("Authorization", "Bearer "+accessToken)
+
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, httpResponse.Body.Close())
+		}()
+
+		require.Equal(t, http.StatusCreated, httpResponse.StatusCode)
+
+		var apiKeyResponse responses.CreatedAPIKeyResponse
+		err = json.NewDecoder(httpResponse.Body).Decode(&apiKeyResponse)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, apiKeyResponse.Key)
+
+		apiKey = apiKeyResponse.Key
+	})
+
+	t.Run("It should get posts with the api key", func(t *testing.T) {
+		httpRequest, err := http.NewRequest(http.MethodGet, applicationURL.JoinPath("/posts").String(), http.NoBody)
+		require.NoError(t, err)
+
+		httpRequest.Header.Set("Authorization", "ApiKey "+apiKey)
+
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, httpResponse.Body.Close())
+		}()
+
+		require.Equal(t, http.StatusOK, httpResponse.StatusCode)
+	})
+
+	t.Run("It should not create a post with the read-only api key", func(t *testing.T) {
+		httpRequest, err := http.NewRequest(
+			http.MethodPost,
+			applicationURL.JoinPath("/posts").String(),
+			bytes.NewReader(rawCreatePostRequest),
+		)
+		require.NoError(t, err)
+
+		httpRequest.Header.Set("Content-Type", "application/json")
+		httpRequest.Header.Set("Authorization", "ApiKey "+apiKey)
+
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, httpResponse.Body.Close())
+		}()
+
+		require.Equal(t, http.StatusForbidden, httpResponse.StatusCode)
+		require.Contains(t, httpResponse.Header.Get("WWW-Authenticate"), "insufficient_scope")
+	})
+
+	t.Run("It should logout", func(t *testing.T) {
+		httpRequest, err := http.NewRequest(http.MethodPost, applicationURL.JoinPath("/logout").String(), http.NoBody)
+		require.NoError(t, err)
+
+		httpRequest.Header.Set("Authorization", "Bearer "+accessToken)
+
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, httpResponse.Body.Close())
+		}()
+
+		require.Equal(t, http.StatusNoContent, httpResponse.StatusCode)
+	})
+
+	t.Run("It should reject access token after logout", func(t *testing.T) {
+		httpRequest, err := http.NewRequest(http.MethodGet, applicationURL.JoinPath("/posts").String(), http.NoBody)
+		require.NoError(t, err)
+
+		httpRequest.Header.Set("Authorization", "Bearer "+accessToken)
+
+		httpResponse, err := http.DefaultClient.Do(httpRequest)
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, httpResponse.Body.Close())
+		}()
+
+		require.Equal(t, http.StatusUnauthorized, httpResponse.StatusCode)
+	})
 }
@@ -0,0 +1,78 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	apiKeyRepository := repositories.NewAPIKeyRepository(gormDB)
+
+	user := &models.User{
+		Email:    "test_api_key_repository@email.com",
+		Name:     "test_api_key_repository",
+		Password: "test_api_key_repository",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	newAPIKey := &models.APIKey{
+		UserID:  user.ID,
+		Name:    "ci",
+		Prefix:  "ak_ABCDEFGH",
+		KeyHash: "0000000000000000000000000000000000000000000000000000000000000002",
+		Scopes:  models.Scopes{models.ScopePostsRead},
+	}
+
+	t.Run("It should create an api key", func(t *testing.T) {
+		err := apiKeyRepository.Create(t.Context(), newAPIKey)
+		require.NoError(t, err)
+		assert.NotZero(t, newAPIKey.ID)
+	})
+
+	t.Run("It should fetch api key by hash", func(t *testing.T) {
+		gotAPIKey, err := apiKeyRepository.GetByHash(t.Context(), newAPIKey.KeyHash)
+		require.NoError(t, err)
+
+		assert.Equal(t, newAPIKey.ID, gotAPIKey.ID)
+		assert.Equal(t, newAPIKey.Name, gotAPIKey.Name)
+		assert.Nil(t, gotAPIKey.ExpiresAt)
+	})
+
+	t.Run("It should return an error if api key not found", func(t *testing.T) {
+		_, err := apiKeyRepository.GetByHash(t.Context(), "unknown")
+		assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+	})
+
+	t.Run("It should list active api keys of the user", func(t *testing.T) {
+		gotAPIKeys, err := apiKeyRepository.GetByUser(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		require.Len(t, gotAPIKeys, 1)
+		assert.Equal(t, newAPIKey.ID, gotAPIKeys[0].ID)
+	})
+
+	t.Run("It should not revoke an api key of another user", func(t *testing.T) {
+		err := apiKeyRepository.Revoke(t.Context(), user.ID+1, newAPIKey.ID, time.Now())
+		assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+	})
+
+	t.Run("It should revoke an api key", func(t *testing.T) {
+		err := apiKeyRepository.Revoke(t.Context(), user.ID, newAPIKey.ID, time.Now())
+		require.NoError(t, err)
+
+		gotAPIKeys, err := apiKeyRepository.GetByUser(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, gotAPIKeys)
+
+		err = apiKeyRepository.Revoke(t.Context(), user.ID, newAPIKey.ID, time.Now())
+		assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+	})
+}
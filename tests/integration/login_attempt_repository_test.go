@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginAttemptRepository(t *testing.T) {
+	loginAttemptRepository := repositories.NewLoginAttemptRepository(gormDB, time.Now)
+
+	t.Run("It should return zero attempt for unknown key", func(t *testing.T) {
+		attempt, err := loginAttemptRepository.Get(t.Context(), "unknown")
+		require.NoError(t, err)
+		assert.Zero(t, attempt.Failures)
+		assert.Nil(t, attempt.LockedUntil)
+	})
+
+	t.Run("It should count failures", func(t *testing.T) {
+		failures, err := loginAttemptRepository.AddFailure(t.Context(), "counted", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, failures)
+
+		failures, err = loginAttemptRepository.AddFailure(t.Context(), "counted", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 2, failures)
+
+		attempt, err := loginAttemptRepository.Get(t.Context(), "counted")
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempt.Failures)
+	})
+
+	t.Run("It should forget expired failures", func(t *testing.T) {
+		_, err := loginAttemptRepository.AddFailure(t.Context(), "expired", time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		attempt, err := loginAttemptRepository.Get(t.Context(), "expired")
+		require.NoError(t, err)
+		assert.Zero(t, attempt.Failures)
+
+		failures, err := loginAttemptRepository.AddFailure(t.Context(), "expired", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, failures)
+	})
+
+	t.Run("It should lock key", func(t *testing.T) {
+		_, err := loginAttemptRepository.AddFailure(t.Context(), "locked", time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		lockedUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+		require.NoError(t, loginAttemptRepository.Lock(t.Context(), "locked", lockedUntil))
+
+		attempt, err := loginAttemptRepository.Get(t.Context(), "locked")
+		require.NoError(t, err)
+		require.NotNil(t, attempt.LockedUntil)
+		assert.True(t, lockedUntil.Equal(*attempt.LockedUntil))
+		assert.False(t, attempt.ExpiresAt.Before(lockedUntil))
+	})
+
+	t.Run("It should reset failures", func(t *testing.T) {
+		_, err := loginAttemptRepository.AddFailure(t.Context(), "reset", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, loginAttemptRepository.Reset(t.Context(), "reset"))
+
+		attempt, err := loginAttemptRepository.Get(t.Context(), "reset")
+		require.NoError(t, err)
+		assert.Zero(t, attempt.Failures)
+	})
+}
@@ -0,0 +1,91 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFARepository(t *testing.T) {
+	userRepository := repositories.NewUserRepository(gormDB)
+	mfaRepository := repositories.NewMFARepository(gormDB)
+
+	user := &models.User{
+		Email:    "test_mfa_repository@email.com",
+		Name:     "test_mfa_repository",
+		Password: "test_mfa_repository",
+	}
+	require.NoError(t, userRepository.Create(t.Context(), user))
+
+	enroll := func(t *testing.T, secret string, codeHashes ...string) error {
+		t.Helper()
+
+		recoveryCodes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, codeHash := range codeHashes {
+			recoveryCodes = append(recoveryCodes, models.RecoveryCode{UserID: user.ID, CodeHash: codeHash})
+		}
+
+		return mfaRepository.Enroll(t.Context(), &models.TOTPSecret{UserID: user.ID, Secret: secret}, recoveryCodes)
+	}
+
+	t.Run("It should return ErrMFANotEnrolled when user has no secret", func(t *testing.T) {
+		_, err := mfaRepository.GetTOTPSecret(t.Context(), user.ID)
+		assert.ErrorIs(t, err, models.ErrMFANotEnrolled)
+	})
+
+	t.Run("It should replace pending enrollment", func(t *testing.T) {
+		require.NoError(t, enroll(t, "first-secret", "first-code-hash"))
+		require.NoError(t, enroll(t, "second-secret", "second-code-hash"))
+
+		totpSecret, err := mfaRepository.GetTOTPSecret(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "second-secret", totpSecret.Secret)
+		assert.Nil(t, totpSecret.ConfirmedAt)
+
+		err = mfaRepository.UseRecoveryCode(t.Context(), user.ID, "first-code-hash", time.Now())
+		assert.ErrorIs(t, err, models.ErrInvalidMFACode)
+	})
+
+	t.Run("It should not accept codes before enrollment is confirmed", func(t *testing.T) {
+		err := mfaRepository.UseTOTPStep(t.Context(), user.ID, 100)
+		assert.ErrorIs(t, err, models.ErrInvalidMFACode)
+	})
+
+	t.Run("It should confirm enrollment only once", func(t *testing.T) {
+		require.NoError(t, mfaRepository.ConfirmTOTPSecret(t.Context(), user.ID, 100, time.Now()))
+
+		err := mfaRepository.ConfirmTOTPSecret(t.Context(), user.ID, 101, time.Now())
+		assert.ErrorIs(t, err, models.ErrMFANotEnrolled)
+
+		err = enroll(t, "third-secret", "third-code-hash")
+		assert.ErrorIs(t, err, models.ErrMFAAlreadyEnabled)
+
+		totpSecret, err := mfaRepository.GetTOTPSecret(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "second-secret", totpSecret.Secret)
+		assert.NotNil(t, totpSecret.ConfirmedAt)
+		assert.Equal(t, int64(100), totpSecret.LastUsedStep)
+	})
+
+	t.Run("It should accept every time step only once", func(t *testing.T) {
+		err := mfaRepository.UseTOTPStep(t.Context(), user.ID, 100)
+		assert.ErrorIs(t, err, models.ErrInvalidMFACode)
+
+		require.NoError(t, mfaRepository.UseTOTPStep(t.Context(), user.ID, 101))
+
+		err = mfaRepository.UseTOTPStep(t.Context(), user.ID, 101)
+		assert.ErrorIs(t, err, models.ErrInvalidMFACode)
+	})
+
+	t.Run("It should use recovery code only once", func(t *testing.T) {
+		require.NoError(t, mfaRepository.UseRecoveryCode(t.Context(), user.ID, "second-code-hash", time.Now()))
+
+		err := mfaRepository.UseRecoveryCode(t.Context(), user.ID, "second-code-hash", time.Now())
+		assert.ErrorIs(t, err, models.ErrInvalidMFACode)
+	})
+}
@@ -0,0 +1,143 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthProviderRepository(t *testing.T) {
+	oAuthProviderRepository := repositories.NewOAuthProviderRepository(gormDB)
+
+	user := &models.User{
+		Email: "test_oauth_provider_repository@email.com",
+		Name:  "test_oauth_provider_repository",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	googleIdentity := &models.OAuthProviders{
+		UserID:   user.ID,
+		Provider: models.GOOGLE,
+		Subject:  "test_oauth_provider_repository_google",
+		Token:    "id-token",
+	}
+
+	keycloakIdentity := &models.OAuthProviders{
+		UserID:   user.ID,
+		Provider: "keycloak",
+		Subject:  "test_oauth_provider_repository_keycloak",
+		Token:    "id-token",
+	}
+
+	t.Run("It should create identities", func(t *testing.T) {
+		err := oAuthProviderRepository.Create(t.Context(), googleIdentity)
+		require.NoError(t, err)
+		assert.NotZero(t, googleIdentity.ID)
+
+		err = oAuthProviderRepository.Create(t.Context(), keycloakIdentity)
+		require.NoError(t, err)
+		assert.NotZero(t, keycloakIdentity.ID)
+	})
+
+	t.Run("It should not create the same identity twice", func(t *testing.T) {
+		err := oAuthProviderRepository.Create(t.Context(), &models.OAuthProviders{
+			UserID:   user.ID,
+			Provider: models.GOOGLE,
+			Subject:  googleIdentity.Subject,
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("It should fetch identity by subject", func(t *testing.T) {
+		gotIdentity, err := oAuthProviderRepository.GetBySubject(t.Context(), models.GOOGLE, googleIdentity.Subject)
+		require.NoError(t, err)
+
+		assert.Equal(t, googleIdentity.ID, gotIdentity.ID)
+		assert.Equal(t, user.ID, gotIdentity.UserID)
+	})
+
+	t.Run("It should return an error if identity not found", func(t *testing.T) {
+		_, err := oAuthProviderRepository.GetBySubject(t.Context(), "keycloak", googleIdentity.Subject)
+		assert.ErrorIs(t, err, models.ErrOAuthIdentityNotFound)
+	})
+
+	t.Run("It should list identities of the user", func(t *testing.T) {
+		gotIdentities, err := oAuthProviderRepository.GetByUser(t.Context(), user.ID)
+		require.NoError(t, err)
+
+		require.Len(t, gotIdentities, 2)
+		assert.Equal(t, googleIdentity.ID, gotIdentities[0].ID)
+		assert.Equal(t, keycloakIdentity.ID, gotIdentities[1].ID)
+	})
+
+	t.Run("It should not delete identity of another user", func(t *testing.T) {
+		err := oAuthProviderRepository.Delete(t.Context(), user.ID+1000, googleIdentity.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("It should delete identity", func(t *testing.T) {
+		err := oAuthProviderRepository.Delete(t.Context(), user.ID, googleIdentity.ID)
+		require.NoError(t, err)
+
+		_, err = oAuthProviderRepository.GetBySubject(t.Context(), models.GOOGLE, googleIdentity.Subject)
+		assert.ErrorIs(t, err, models.ErrOAuthIdentityNotFound)
+	})
+
+	t.Run("It should not delete the last login method of the user without password", func(t *testing.T) {
+		err := oAuthProviderRepository.Delete(t.Context(), user.ID, keycloakIdentity.ID)
+		assert.ErrorIs(t, err, models.ErrLastLoginMethod)
+
+		_, err = oAuthProviderRepository.GetBySubject(t.Context(), "keycloak", keycloakIdentity.Subject)
+		assert.NoError(t, err)
+	})
+
+	t.Run("It should not count legacy identities as a login method", func(t *testing.T) {
+		legacyIdentity := &models.OAuthProviders{
+			UserID:   user.ID,
+			Provider: models.GOOGLE,
+			Subject:  models.LegacyOAuthSubjectPrefix + "test_oauth_provider_repository",
+		}
+
+		err := oAuthProviderRepository.Create(t.Context(), legacyIdentity)
+		require.NoError(t, err)
+
+		err = oAuthProviderRepository.Delete(t.Context(), user.ID, keycloakIdentity.ID)
+		assert.ErrorIs(t, err, models.ErrLastLoginMethod)
+	})
+
+	t.Run("It should adopt legacy identity", func(t *testing.T) {
+		adoptedIdentity, err := oAuthProviderRepository.AdoptLegacy(
+			t.Context(),
+			user.ID,
+			models.GOOGLE,
+			"test_oauth_provider_repository_adopted",
+			"new-id-token",
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "test_oauth_provider_repository_adopted", adoptedIdentity.Subject)
+
+		gotIdentity, err := oAuthProviderRepository.GetBySubject(t.Context(), models.GOOGLE, adoptedIdentity.Subject)
+		require.NoError(t, err)
+		assert.Equal(t, adoptedIdentity.ID, gotIdentity.ID)
+		assert.Equal(t, "new-id-token", gotIdentity.Token)
+	})
+
+	t.Run("It should return an error if there is no legacy identity to adopt", func(t *testing.T) {
+		_, err := oAuthProviderRepository.AdoptLegacy(t.Context(), user.ID, models.GOOGLE, "another_subject", "id-token")
+		assert.ErrorIs(t, err, models.ErrOAuthIdentityNotFound)
+	})
+
+	t.Run("It should delete the last identity of the user with password", func(t *testing.T) {
+		err := gormDB.Model(user).Update("password", "password").Error
+		require.NoError(t, err)
+
+		err = oAuthProviderRepository.Delete(t.Context(), user.ID, keycloakIdentity.ID)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthStateRepository(t *testing.T) {
+	oAuthStateRepository := repositories.NewOAuthStateRepository(gormDB, time.Now)
+
+	t.Run("It should return an error for unknown state", func(t *testing.T) {
+		_, err := oAuthStateRepository.Take(t.Context(), "unknown")
+		assert.ErrorIs(t, err, models.ErrInvalidOAuthState)
+	})
+
+	t.Run("It should take state only once", func(t *testing.T) {
+		oAuthState := models.OAuthState{
+			Provider:     "google",
+			CodeVerifier: "verifier",
+			Nonce:        "nonce",
+			ExpiresAt:    time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+
+		err := oAuthStateRepository.Save(t.Context(), "taken-state", oAuthState)
+		require.NoError(t, err)
+
+		gotState, err := oAuthStateRepository.Take(t.Context(), "taken-state")
+		require.NoError(t, err)
+		assert.Equal(t, "taken-state", gotState.State)
+		assert.Equal(t, oAuthState.Provider, gotState.Provider)
+		assert.Equal(t, oAuthState.CodeVerifier, gotState.CodeVerifier)
+		assert.Equal(t, oAuthState.Nonce, gotState.Nonce)
+		assert.True(t, oAuthState.ExpiresAt.Equal(gotState.ExpiresAt))
+
+		_, err = oAuthStateRepository.Take(t.Context(), "taken-state")
+		assert.ErrorIs(t, err, models.ErrInvalidOAuthState)
+	})
+
+	t.Run("It should return an error for expired state", func(t *testing.T) {
+		err := gormDB.Create(&models.OAuthState{
+			State:     "expired-state",
+			Provider:  "google",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}).Error
+		require.NoError(t, err)
+
+		_, err = oAuthStateRepository.Take(t.Context(), "expired-state")
+		assert.ErrorIs(t, err, models.ErrInvalidOAuthState)
+	})
+
+	t.Run("It should prune expired states", func(t *testing.T) {
+		err := gormDB.Create(&models.OAuthState{
+			State:     "pruned-state",
+			Provider:  "google",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}).Error
+		require.NoError(t, err)
+
+		err = oAuthStateRepository.Save(t.Context(), "another-state", models.OAuthState{
+			Provider:  "google",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		var count int64
+		err = gormDB.Model(&models.OAuthState{}).Where("state = ?", "pruned-state").Count(&count).Error
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordResetRepository(t *testing.T) {
+	userRepository := repositories.NewUserRepository(gormDB)
+	passwordResetRepository := repositories.NewPasswordResetRepository(gormDB, time.Now)
+
+	user := &models.User{
+		Email:    "test_password_reset_repository@email.com",
+		Name:     "test_password_reset_repository",
+		Password: "test_password_reset_repository",
+	}
+	require.NoError(t, userRepository.Create(t.Context(), user))
+
+	newPasswordReset := func(t *testing.T, tokenHash string, expiresAt time.Time) {
+		t.Helper()
+
+		err := passwordResetRepository.Create(t.Context(), &models.PasswordReset{
+			UserID:    user.ID,
+			TokenHash: tokenHash,
+			ExpiresAt: expiresAt,
+		})
+		require.NoError(t, err)
+	}
+
+	t.Run("It should consume password reset only once", func(t *testing.T) {
+		newPasswordReset(t, "a-first-token-hash", time.Now().Add(time.Hour))
+		newPasswordReset(t, "a-second-token-hash", time.Now().Add(time.Hour))
+
+		passwordReset, err := passwordResetRepository.Consume(t.Context(), "a-second-token-hash")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, passwordReset.UserID)
+
+		_, err = passwordResetRepository.Consume(t.Context(), "a-second-token-hash")
+		assert.ErrorIs(t, err, models.ErrInvalidResetToken)
+
+		// Tokens requested earlier are consumed as well.
+		_, err = passwordResetRepository.Consume(t.Context(), "a-first-token-hash")
+		assert.ErrorIs(t, err, models.ErrInvalidResetToken)
+	})
+
+	t.Run("It should get password reset without consuming it", func(t *testing.T) {
+		newPasswordReset(t, "a-got-token-hash", time.Now().Add(time.Hour))
+
+		passwordReset, err := passwordResetRepository.Get(t.Context(), "a-got-token-hash")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, passwordReset.UserID)
+
+		_, err = passwordResetRepository.Consume(t.Context(), "a-got-token-hash")
+		require.NoError(t, err)
+
+		_, err = passwordResetRepository.Get(t.Context(), "a-got-token-hash")
+		assert.ErrorIs(t, err, models.ErrInvalidResetToken)
+	})
+
+	t.Run("It should count password resets created since the time", func(t *testing.T) {
+		since := time.Now().Add(-time.Minute)
+
+		newPasswordReset(t, "a-counted-token-hash", time.Now().Add(time.Hour))
+
+		count, err := passwordResetRepository.CountCreatedSince(t.Context(), user.ID, since)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("It should not get or consume expired password reset", func(t *testing.T) {
+		err := gormDB.Create(&models.PasswordReset{
+			UserID:    user.ID,
+			TokenHash: "an-expired-token-hash",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}).Error
+		require.NoError(t, err)
+
+		_, err = passwordResetRepository.Get(t.Context(), "an-expired-token-hash")
+		assert.ErrorIs(t, err, models.ErrInvalidResetToken)
+
+		_, err = passwordResetRepository.Consume(t.Context(), "an-expired-token-hash")
+		assert.ErrorIs(t, err, models.ErrInvalidResetToken)
+	})
+}
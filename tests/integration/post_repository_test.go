@@ -2,7 +2,9 @@ package integration
 
 import (
 	"testing"
+	"time"
 
+	"github.com/nix-united/golang-echo-boilerplate/internal/domain"
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
 
@@ -53,10 +55,62 @@ func TestPostRepository(t *testing.T) {
 		assert.ErrorIs(t, err, models.ErrPostNotFound)
 	})
 
-	t.Run("It should fetch all posts", func(t *testing.T) {
-		posts, err := postRepository.GetPosts(t.Context())
+	t.Run("It should fetch posts with their authors", func(t *testing.T) {
+		posts, err := postRepository.GetPosts(t.Context(), domain.PostsQuery{
+			Filter: domain.PostFilter{AuthorID: &user.ID},
+			Sort:   domain.PostSort{Field: domain.PostSortCreatedAt, Descending: true},
+			Limit:  10,
+		})
 		require.NoError(t, err)
-		require.NotEmpty(t, posts)
+		require.Len(t, posts, 1)
+
+		assert.Equal(t, newPost.ID, posts[0].ID)
+		assert.Equal(t, user.Name, posts[0].User.Name)
+	})
+
+	t.Run("It should page posts by keyset", func(t *testing.T) {
+		titles := []string{"b", "a", "c", "a"}
+		for _, title := range titles {
+			err := postRepository.Create(t.Context(), &models.Post{Title: title, Content: "content", UserID: user.ID})
+			require.NoError(t, err)
+		}
+
+		query := domain.PostsQuery{
+			Filter: domain.PostFilter{AuthorID: &user.ID},
+			Sort:   domain.PostSort{Field: domain.PostSortTitle},
+			Limit:  2,
+		}
+
+		var gotTitles []string
+		for range 3 {
+			posts, err := postRepository.GetPosts(t.Context(), query)
+			require.NoError(t, err)
+
+			for _, post := range posts {
+				gotTitles = append(gotTitles, post.Title)
+			}
+
+			if len(posts) < query.Limit {
+				break
+			}
+
+			last := posts[len(posts)-1]
+			query.After = &domain.PostCursor{Sort: query.Sort, Title: last.Title, ID: last.ID}
+		}
+
+		assert.Equal(t, []string{"a", "a", "b", "c", "Post title"}, gotTitles)
+	})
+
+	t.Run("It should filter posts by creation time", func(t *testing.T) {
+		createdTo := newPost.CreatedAt.Add(-time.Hour)
+
+		posts, err := postRepository.GetPosts(t.Context(), domain.PostsQuery{
+			Filter: domain.PostFilter{AuthorID: &user.ID, CreatedTo: &createdTo},
+			Sort:   domain.PostSort{Field: domain.PostSortCreatedAt},
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, posts)
 	})
 
 	t.Run("It should update post", func(t *testing.T) {
@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRepository(t *testing.T) {
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(gormDB)
+
+	user := &models.User{
+		Email:    "test_refresh_token_repository@email.com",
+		Name:     "test_refresh_token_repository",
+		Password: "test_refresh_token_repository",
+	}
+
+	err := gormDB.Create(user).Error
+	require.NoError(t, err)
+
+	newRefreshToken := &models.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  "11111111-1111-1111-1111-111111111111",
+		TokenHash: "0000000000000000000000000000000000000000000000000000000000000001",
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+
+	t.Run("It should create a refresh token", func(t *testing.T) {
+		err := refreshTokenRepository.Create(t.Context(), newRefreshToken)
+		require.NoError(t, err)
+		assert.NotZero(t, newRefreshToken.ID)
+	})
+
+	t.Run("It should fetch refresh token by hash", func(t *testing.T) {
+		gotRefreshToken, err := refreshTokenRepository.GetByHash(t.Context(), newRefreshToken.TokenHash)
+		require.NoError(t, err)
+
+		assert.Equal(t, newRefreshToken.ID, gotRefreshToken.ID)
+		assert.Equal(t, newRefreshToken.FamilyID, gotRefreshToken.FamilyID)
+		assert.Nil(t, gotRefreshToken.UsedAt)
+	})
+
+	t.Run("It should return an error if refresh token not found", func(t *testing.T) {
+		_, err := refreshTokenRepository.GetByHash(t.Context(), "unknown")
+		assert.ErrorIs(t, err, models.ErrRefreshTokenNotFound)
+	})
+
+	t.Run("It should mark refresh token as used only once", func(t *testing.T) {
+		err := refreshTokenRepository.MarkUsed(t.Context(), newRefreshToken.ID, time.Now())
+		require.NoError(t, err)
+
+		err = refreshTokenRepository.MarkUsed(t.Context(), newRefreshToken.ID, time.Now())
+		assert.ErrorIs(t, err, models.ErrRefreshTokenReused)
+	})
+
+	t.Run("It should revoke refresh token family", func(t *testing.T) {
+		err := refreshTokenRepository.RevokeFamily(t.Context(), newRefreshToken.FamilyID, time.Now())
+		require.NoError(t, err)
+
+		gotRefreshToken, err := refreshTokenRepository.GetByHash(t.Context(), newRefreshToken.TokenHash)
+		require.NoError(t, err)
+
+		assert.NotNil(t, gotRefreshToken.RevokedAt)
+	})
+
+	t.Run("It should revoke other refresh token families of the user", func(t *testing.T) {
+		currentRefreshToken := &models.RefreshToken{
+			UserID:    user.ID,
+			FamilyID:  "22222222-2222-2222-2222-222222222222",
+			TokenHash: "0000000000000000000000000000000000000000000000000000000000000002",
+			ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+		otherRefreshToken := &models.RefreshToken{
+			UserID:    user.ID,
+			FamilyID:  "33333333-3333-3333-3333-333333333333",
+			TokenHash: "0000000000000000000000000000000000000000000000000000000000000003",
+			ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+
+		require.NoError(t, refreshTokenRepository.Create(t.Context(), currentRefreshToken))
+		require.NoError(t, refreshTokenRepository.Create(t.Context(), otherRefreshToken))
+
+		err := refreshTokenRepository.RevokeByUserExcept(t.Context(), user.ID, currentRefreshToken.FamilyID, time.Now())
+		require.NoError(t, err)
+
+		gotCurrentRefreshToken, err := refreshTokenRepository.GetByHash(t.Context(), currentRefreshToken.TokenHash)
+		require.NoError(t, err)
+		assert.Nil(t, gotCurrentRefreshToken.RevokedAt)
+
+		gotOtherRefreshToken, err := refreshTokenRepository.GetByHash(t.Context(), otherRefreshToken.TokenHash)
+		require.NoError(t, err)
+		assert.NotNil(t, gotOtherRefreshToken.RevokedAt)
+	})
+}
@@ -0,0 +1,51 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokedAccessTokenRepository(t *testing.T) {
+	revokedAccessTokenRepository := repositories.NewRevokedAccessTokenRepository(gormDB, time.Now)
+
+	t.Run("It should not report unknown token as revoked", func(t *testing.T) {
+		revoked, err := revokedAccessTokenRepository.IsRevoked(t.Context(), "unknown")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	})
+
+	t.Run("It should report revoked token", func(t *testing.T) {
+		err := revokedAccessTokenRepository.Revoke(t.Context(), "revoked-token-id", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		// Revoking the same token twice must not fail.
+		err = revokedAccessTokenRepository.Revoke(t.Context(), "revoked-token-id", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		revoked, err := revokedAccessTokenRepository.IsRevoked(t.Context(), "revoked-token-id")
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	})
+
+	t.Run("It should prune expired tokens", func(t *testing.T) {
+		err := gormDB.Create(&models.RevokedAccessToken{
+			TokenID:   "expired-token-id",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}).Error
+		require.NoError(t, err)
+
+		err = revokedAccessTokenRepository.Revoke(t.Context(), "another-token-id", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		var count int64
+		err = gormDB.Model(&models.RevokedAccessToken{}).Where("token_id = ?", "expired-token-id").Count(&count).Error
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,120 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRepository(t *testing.T) {
+	sessionRepository := repositories.NewSessionRepository(gormDB)
+
+	user := &models.User{
+		Email:    "test_session_repository@email.com",
+		Name:     "test_session_repository",
+		Password: "test_session_repository",
+	}
+	require.NoError(t, gormDB.Create(user).Error)
+
+	now := time.Now().Truncate(time.Second)
+
+	newSession := func(t *testing.T, id string, lastUsedAt time.Time) *models.Session {
+		t.Helper()
+
+		session := &models.Session{
+			ID:         id,
+			UserID:     user.ID,
+			UserAgent:  "Mozilla/5.0",
+			IPAddress:  "192.0.2.1",
+			Provider:   models.LoginProviderPassword,
+			LastUsedAt: lastUsedAt,
+			ExpiresAt:  now.Add(time.Hour),
+		}
+		require.NoError(t, sessionRepository.Create(t.Context(), session))
+
+		return session
+	}
+
+	t.Run("It should create and fetch session", func(t *testing.T) {
+		session := newSession(t, "11111111-0000-0000-0000-000000000001", now)
+
+		gotSession, err := sessionRepository.GetByID(t.Context(), session.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, user.ID, gotSession.UserID)
+		assert.Equal(t, "Mozilla/5.0", gotSession.UserAgent)
+		assert.Equal(t, "192.0.2.1", gotSession.IPAddress)
+		assert.Equal(t, models.LoginProviderPassword, gotSession.Provider)
+		assert.Nil(t, gotSession.RevokedAt)
+	})
+
+	t.Run("It should return ErrSessionNotFound when session doesn't exist", func(t *testing.T) {
+		_, err := sessionRepository.GetByID(t.Context(), "unknown")
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+	})
+
+	t.Run("It should touch session", func(t *testing.T) {
+		session := newSession(t, "11111111-0000-0000-0000-000000000002", now.Add(-time.Hour))
+
+		err := sessionRepository.Touch(t.Context(), session.ID, now, now.Add(2*time.Hour))
+		require.NoError(t, err)
+
+		gotSession, err := sessionRepository.GetByID(t.Context(), session.ID)
+		require.NoError(t, err)
+
+		assert.True(t, now.Equal(gotSession.LastUsedAt))
+		assert.True(t, now.Add(2*time.Hour).Equal(gotSession.ExpiresAt))
+	})
+
+	t.Run("It should list active sessions most recently used first", func(t *testing.T) {
+		require.NoError(t, sessionRepository.RevokeByUser(t.Context(), user.ID, now))
+
+		older := newSession(t, "11111111-0000-0000-0000-000000000003", now.Add(-time.Minute))
+		newer := newSession(t, "11111111-0000-0000-0000-000000000004", now)
+
+		expired := newSession(t, "11111111-0000-0000-0000-000000000005", now)
+		err := gormDB.Model(expired).Update("expires_at", now.Add(-time.Minute)).Error
+		require.NoError(t, err)
+
+		sessions, err := sessionRepository.GetActiveByUser(t.Context(), user.ID, now)
+		require.NoError(t, err)
+
+		require.Len(t, sessions, 2)
+		assert.Equal(t, newer.ID, sessions[0].ID)
+		assert.Equal(t, older.ID, sessions[1].ID)
+	})
+
+	t.Run("It should revoke session of the user only once", func(t *testing.T) {
+		session := newSession(t, "11111111-0000-0000-0000-000000000006", now)
+
+		err := sessionRepository.Revoke(t.Context(), user.ID+1, session.ID, now)
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+
+		err = sessionRepository.Revoke(t.Context(), user.ID, session.ID, now)
+		require.NoError(t, err)
+
+		err = sessionRepository.Revoke(t.Context(), user.ID, session.ID, now)
+		assert.ErrorIs(t, err, models.ErrSessionNotFound)
+	})
+
+	t.Run("It should revoke other sessions of the user", func(t *testing.T) {
+		current := newSession(t, "11111111-0000-0000-0000-000000000007", now)
+		other := newSession(t, "11111111-0000-0000-0000-000000000008", now)
+
+		err := sessionRepository.RevokeByUserExcept(t.Context(), user.ID, current.ID, now)
+		require.NoError(t, err)
+
+		gotCurrent, err := sessionRepository.GetByID(t.Context(), current.ID)
+		require.NoError(t, err)
+		assert.Nil(t, gotCurrent.RevokedAt)
+
+		gotOther, err := sessionRepository.GetByID(t.Context(), other.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, gotOther.RevokedAt)
+	})
+}
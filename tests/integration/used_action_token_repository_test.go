@@ -0,0 +1,40 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nix-united/golang-echo-boilerplate/internal/models"
+	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsedActionTokenRepository(t *testing.T) {
+	usedActionTokenRepository := repositories.NewUsedActionTokenRepository(gormDB, time.Now)
+
+	t.Run("It should mark token used only once", func(t *testing.T) {
+		err := usedActionTokenRepository.MarkUsed(t.Context(), "used-action-token-id", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		err = usedActionTokenRepository.MarkUsed(t.Context(), "used-action-token-id", time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, models.ErrInvalidActionToken)
+	})
+
+	t.Run("It should prune expired tokens", func(t *testing.T) {
+		err := gormDB.Create(&models.UsedActionToken{
+			TokenID:   "expired-action-token-id",
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}).Error
+		require.NoError(t, err)
+
+		err = usedActionTokenRepository.MarkUsed(t.Context(), "another-action-token-id", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		var count int64
+		err = gormDB.Model(&models.UsedActionToken{}).Where("token_id = ?", "expired-action-token-id").Count(&count).Error
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
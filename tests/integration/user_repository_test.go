@@ -2,6 +2,7 @@ package integration
 
 import (
 	"testing"
+	"time"
 
 	"github.com/nix-united/golang-echo-boilerplate/internal/models"
 	"github.com/nix-united/golang-echo-boilerplate/internal/repositories"
@@ -54,4 +55,38 @@ func TestUserRepository(t *testing.T) {
 		_, err := userRepository.GetUserByEmail(t.Context(), "unknown_email@gmail.com")
 		assert.ErrorIs(t, err, models.ErrUserNotFound)
 	})
+
+	t.Run("It should mark user verified", func(t *testing.T) {
+		verifiedAt := time.Now().Truncate(time.Second)
+
+		err := userRepository.MarkVerified(t.Context(), newUser.ID, verifiedAt)
+		require.NoError(t, err)
+
+		gotUser, err := userRepository.GetByID(t.Context(), newUser.ID)
+		require.NoError(t, err)
+		require.NotNil(t, gotUser.VerifiedAt)
+		assert.True(t, verifiedAt.Equal(*gotUser.VerifiedAt))
+	})
+
+	t.Run("It should update user password", func(t *testing.T) {
+		err := userRepository.UpdatePassword(t.Context(), newUser.ID, "new_password_hash")
+		require.NoError(t, err)
+
+		gotUser, err := userRepository.GetByID(t.Context(), newUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new_password_hash", gotUser.Password)
+	})
+
+	t.Run("It should update user email", func(t *testing.T) {
+		verifiedAt := time.Now().Truncate(time.Second)
+
+		err := userRepository.UpdateEmail(t.Context(), newUser.ID, "test_user_repository_new@email.com", verifiedAt)
+		require.NoError(t, err)
+
+		gotUser, err := userRepository.GetByID(t.Context(), newUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "test_user_repository_new@email.com", gotUser.Email)
+		require.NotNil(t, gotUser.VerifiedAt)
+		assert.True(t, verifiedAt.Equal(*gotUser.VerifiedAt))
+	})
 }
@@ -46,8 +46,10 @@ func SetupApplication(
 					"DB_HOST":         mySQLConfig.ContainerName,
 					"DB_PORT":         mySQLConfig.LocalPort,
 					"DB_NAME":         mySQLConfig.Name,
-					"ACCESS_SECRET":   "jwt-secret",
-					"REFRESH_SECRET":  "jwt-refresh-secret",
+					"ACCESS_SECRET":   "jwt-secret-of-at-least-32-characters",
+					"REFRESH_SECRET":  "jwt-refresh-secret-of-at-least-32-characters",
+					"ACTION_SECRET":   "jwt-action-secret-of-at-least-32-characters",
+					"MAIL_SENDER":     "memory",
 				},
 				WaitingFor: wait.
 					ForAll(wait.ForHTTP("/health")).